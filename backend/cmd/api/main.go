@@ -6,19 +6,28 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
-	"time"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
-	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/letyshub/project-management/internal/authz"
 	"github.com/letyshub/project-management/internal/config"
+	"github.com/letyshub/project-management/internal/domain"
 	"github.com/letyshub/project-management/internal/handler"
+	"github.com/letyshub/project-management/internal/jobs"
+	"github.com/letyshub/project-management/internal/jwtkeys"
+	"github.com/letyshub/project-management/internal/logging"
+	"github.com/letyshub/project-management/internal/mail"
+	"github.com/letyshub/project-management/internal/metrics"
 	"github.com/letyshub/project-management/internal/middleware"
-	"github.com/letyshub/project-management/internal/migrate"
-	"github.com/letyshub/project-management/internal/repository/postgres"
+	"github.com/letyshub/project-management/internal/oidc"
+	"github.com/letyshub/project-management/internal/requestinfo"
 	"github.com/letyshub/project-management/internal/service"
+	"github.com/letyshub/project-management/internal/tracing"
 )
 
 func main() {
@@ -27,89 +36,245 @@ func main() {
 		slog.Error("failed to load config", "error", err)
 		os.Exit(1)
 	}
+	slog.SetDefault(logging.New(os.Stderr, cfg.Log))
 
-	// Database
-	pool, err := pgxpool.New(context.Background(), cfg.Database.DSN())
+	// Cancelled on SIGTERM or Ctrl-C, which starts a graceful shutdown.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	// Tracing
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
 	if err != nil {
-		slog.Error("failed to connect to database", "error", err)
+		slog.Error("failed to set up tracing", "error", err)
 		os.Exit(1)
 	}
-	defer pool.Close()
+	if cfg.Tracing.Enabled() {
+		slog.Info("tracing enabled", "exporter", cfg.Tracing.Exporter, "sample_ratio", cfg.Tracing.SampleRatio)
+	}
 
-	if err := pool.Ping(context.Background()); err != nil {
-		slog.Error("failed to ping database", "error", err)
+	// Storage
+	repos, err := openStorage(ctx, cfg.Database)
+	if err != nil {
+		slog.Error("failed to open storage", "error", err)
 		os.Exit(1)
 	}
-	slog.Info("connected to database")
+	defer repos.close()
 
-	// Run migrations
-	if err := migrate.Run(context.Background(), pool, "migrations"); err != nil {
-		slog.Error("failed to run migrations", "error", err)
+	// Mail
+	mailer, err := mail.New(cfg.Mail)
+	if err != nil {
+		slog.Error("failed to configure mailer", "error", err)
 		os.Exit(1)
 	}
 
-	// Repositories
-	userRepo := postgres.NewUserRepo(pool)
-	refreshTokenRepo := postgres.NewRefreshTokenRepo(pool)
-	projectRepo := postgres.NewProjectRepo(pool)
-	boardRepo := postgres.NewBoardRepo(pool)
-	columnRepo := postgres.NewColumnRepo(pool)
-	taskRepo := postgres.NewTaskRepo(pool)
-	commentRepo := postgres.NewCommentRepo(pool)
-	labelRepo := postgres.NewLabelRepo(pool)
+	// Authorization
+	policy := authz.NewProjectPolicy(repos.projects, repos.members, repos.boards, repos.columns, repos.tasks, repos.labels, repos.comments)
+
+	// Token signing keys
+	signingKeys := jwtkeys.NewHMAC(cfg.JWT.Secret)
+	if cfg.JWT.SigningKeyFile != "" {
+		signingKeys, err = jwtkeys.Load(cfg.JWT.SigningKeyFile, cfg.JWT.VerificationKeyFiles)
+		if err != nil {
+			slog.Error("failed to load jwt keys", "error", err)
+			os.Exit(1)
+		}
+	}
+	// 2FA challenges get a key of their own that is never in the JWKS.
+	challengeKeys := jwtkeys.NewDerivedHMAC(cfg.JWT.Secret, "2fa-challenge")
 
 	// Services
-	authService := service.NewAuthService(userRepo, refreshTokenRepo, cfg.JWT)
-	projectService := service.NewProjectService(projectRepo, boardRepo, columnRepo)
-	boardService := service.NewBoardService(boardRepo, columnRepo, projectRepo)
-	taskService := service.NewTaskService(taskRepo, columnRepo, boardRepo, projectRepo)
-	commentService := service.NewCommentService(commentRepo)
-	labelService := service.NewLabelService(labelRepo, projectRepo)
+	auditService := service.NewAuditService(repos.auditEvents, repos.tx, policy)
+	emailVerificationService := service.NewEmailVerificationService(
+		repos.users, repos.userTokens, repos.tx, mailer, cfg.Server.AppURL,
+		cfg.Auth.EmailVerificationExpiration, cfg.Auth.EmailVerificationResendInterval,
+	)
+	authService := service.NewAuthService(
+		repos.users, repos.refreshTokens, repos.twoFactor, repos.securityEvents, repos.loginThrottles,
+		emailVerificationService, auditService, signingKeys, challengeKeys, cfg.JWT, cfg.Auth,
+	)
+	sessionService := service.NewSessionService(repos.refreshTokens)
+	twoFactorService := service.NewTwoFactorService(repos.twoFactor, repos.users, repos.tx, authService, cfg.Auth.TOTPIssuer)
+	passwordService := service.NewPasswordService(
+		repos.users, repos.refreshTokens, repos.accessTokens, repos.userTokens, repos.tx, authService, auditService,
+		mailer, cfg.Server.AppURL, cfg.Auth.PasswordResetExpiration, cfg.Auth.PasswordResetResendInterval,
+	)
+	adminService := service.NewAdminService(repos.users, repos.refreshTokens, auditService, passwordService)
+	tokenService := service.NewTokenService(repos.accessTokens, repos.users, auditService, policy)
+	orgService := service.NewOrganizationService(repos.orgs, repos.orgMembers, repos.users, repos.projects, repos.members, auditService, repos.tx)
+	projectService := service.NewProjectService(repos.projects, repos.members, repos.boards, repos.columns, orgService, auditService, repos.tx, policy)
+	memberService := service.NewProjectMemberService(repos.members, repos.users, repos.projects, repos.orgMembers, auditService, policy)
+	boardService := service.NewBoardService(repos.boards, repos.columns, auditService, policy)
+	taskService := service.NewTaskService(repos.tasks, repos.transitions, auditService, policy)
+	commentService := service.NewCommentService(repos.comments, auditService, policy)
+	labelService := service.NewLabelService(repos.labels, auditService, policy)
+	taskActivityService := service.NewTaskActivityService(repos.auditEvents, repos.comments, policy)
+	flowService := service.NewFlowAnalyticsService(repos.tasks, repos.columns, repos.transitions, policy)
+	invitationService := service.NewInvitationService(
+		repos.invitations, repos.members, repos.projects, repos.orgMembers, repos.users, repos.tx, authService, auditService, policy,
+		mailer, cfg.Server.AppURL, cfg.Auth.InvitationExpiration,
+	)
+
+	// Background jobs
+	go jobs.Every(ctx, "purge-refresh-tokens", cfg.Auth.TokenPurgeInterval, func(ctx context.Context) error {
+		n, err := authService.PurgeExpiredTokens(ctx)
+		if err == nil && n > 0 {
+			slog.Info("purged expired refresh tokens", "count", n)
+		}
+		return err
+	})
+	go jobs.Every(ctx, "purge-login-throttles", cfg.Auth.TokenPurgeInterval, func(ctx context.Context) error {
+		_, err := authService.PurgeStaleThrottles(ctx)
+		return err
+	})
+	go jobs.Every(ctx, "purge-2fa-challenges", cfg.Auth.TokenPurgeInterval, func(ctx context.Context) error {
+		_, err := authService.PurgeExpiredChallenges(ctx)
+		return err
+	})
+
+	// Single sign-on (optional)
+	var ssoHandler *handler.SSOHandler
+	if cfg.OIDC.Enabled() {
+		provider, err := oidc.Discover(context.Background(), nil, oidc.Config{
+			Issuer:       cfg.OIDC.Issuer,
+			ClientID:     cfg.OIDC.ClientID,
+			ClientSecret: cfg.OIDC.ClientSecret,
+			RedirectURL:  cfg.OIDC.RedirectURL,
+			Scopes:       cfg.OIDC.Scopes,
+		})
+		if err != nil {
+			slog.Error("failed to set up oidc", "error", err)
+			os.Exit(1)
+		}
+		ssoService := service.NewSSOService(provider, repos.users, repos.identities, repos.tx, authService, cfg.OIDC.AllowedDomains, jwtkeys.DeriveKey(cfg.JWT.Secret, "oidc-flow"))
+		ssoHandler = handler.NewSSOHandler(ssoService, cfg.Server.AppURL, strings.HasPrefix(cfg.OIDC.RedirectURL, "https://"))
+		slog.Info("oidc single sign-on enabled", "issuer", cfg.OIDC.Issuer)
+	}
 
 	// Handlers
-	healthHandler := handler.NewHealthHandler()
+	healthHandler := handler.NewHealthHandler(repos.checks...)
+	jwksHandler := handler.NewJWKSHandler(signingKeys)
 	authHandler := handler.NewAuthHandler(authService)
+	passwordHandler := handler.NewPasswordHandler(passwordService)
+	emailVerificationHandler := handler.NewEmailVerificationHandler(emailVerificationService)
+	tokenHandler := handler.NewTokenHandler(tokenService)
+	twoFactorHandler := handler.NewTwoFactorHandler(twoFactorService)
+	sessionHandler := handler.NewSessionHandler(sessionService)
+	adminHandler := handler.NewAdminHandler(adminService)
+	orgHandler := handler.NewOrganizationHandler(orgService)
 	projectHandler := handler.NewProjectHandler(projectService)
+	memberHandler := handler.NewProjectMemberHandler(memberService)
+	invitationHandler := handler.NewInvitationHandler(invitationService)
 	boardHandler := handler.NewBoardHandler(boardService)
 	taskHandler := handler.NewTaskHandler(taskService)
 	commentHandler := handler.NewCommentHandler(commentService)
 	labelHandler := handler.NewLabelHandler(labelService)
-	profileHandler := handler.NewProfileHandler(userRepo)
+	auditHandler := handler.NewAuditHandler(auditService)
+	taskActivityHandler := handler.NewTaskActivityHandler(taskActivityService)
+	flowHandler := handler.NewFlowAnalyticsHandler(flowService)
+	profileHandler := handler.NewProfileHandler(repos.users)
 	exportHandler := handler.NewExportHandler(taskService, boardService)
 
 	// Router
 	r := chi.NewRouter()
 
 	r.Use(chimiddleware.RequestID)
+	if cfg.Tracing.Enabled() {
+		r.Use(middleware.Tracing)
+	}
 	r.Use(chimiddleware.RealIP)
-	r.Use(middleware.Logger)
-	r.Use(chimiddleware.Recoverer)
+	r.Use(requestinfo.Middleware)
+	r.Use(middleware.Logger(cfg.Log.SuccessSampleRatio))
+	if cfg.Server.MetricsEnabled {
+		r.Use(middleware.Metrics)
+	}
+	r.Use(middleware.Recoverer)
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   cfg.Server.CORSOrigins,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", middleware.OrganizationHeader},
 		ExposedHeaders:   []string{"Link"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
 
+	r.Get("/.well-known/jwks.json", jwksHandler.JWKS)
+	r.Get("/livez", healthHandler.Live)
+	r.Get("/readyz", healthHandler.Ready)
+	if cfg.Server.MetricsEnabled && cfg.Server.AdminPort == 0 {
+		r.Handle("/metrics", metrics.Handler())
+	}
+
 	r.Route("/api/v1", func(r chi.Router) {
-		r.Get("/health", healthHandler.Health)
+		r.Get("/health", healthHandler.Live) // kept for existing monitors; prefer /livez
 
 		// Auth (public)
 		r.Post("/auth/register", authHandler.Register)
 		r.Post("/auth/login", authHandler.Login)
+		r.Post("/auth/2fa/verify", twoFactorHandler.Verify)
 		r.Post("/auth/refresh", authHandler.Refresh)
 		r.Post("/auth/logout", authHandler.Logout)
+		r.Post("/auth/password/forgot", passwordHandler.Forgot)
+		r.Post("/auth/password/reset", passwordHandler.Reset)
+		r.Post("/auth/email/verify", emailVerificationHandler.Verify)
+		r.Post("/auth/email/resend", emailVerificationHandler.Resend)
+		if ssoHandler != nil {
+			r.Get("/auth/oidc/login", ssoHandler.Login)
+			r.Get("/auth/oidc/callback", ssoHandler.Callback)
+		}
+
+		// Invitations (public)
+		r.Get("/invitations/{token}", invitationHandler.Preview)
+		r.Post("/invitations/{token}/register", invitationHandler.Register)
 
 		// Protected routes
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.Auth(authService))
+			r.Use(middleware.Auth(authService, tokenService))
+			if cfg.Auth.EmailVerification == config.EmailVerificationWrite {
+				r.Use(middleware.RequireVerifiedEmail)
+			}
+			// Active organization, from /orgs/{orgID}/... or the header
+			r.Use(middleware.Organization(orgService))
 
 			// Profile
 			r.Get("/me", profileHandler.GetMe)
 			r.Patch("/me", profileHandler.UpdateMe)
 
+			// Account credentials (not available to personal access tokens)
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.SessionOnly)
+				r.Post("/me/password", passwordHandler.Change)
+				r.Post("/me/tokens", tokenHandler.Create)
+				r.Get("/me/tokens", tokenHandler.List)
+				r.Delete("/me/tokens/{tokenID}", tokenHandler.Revoke)
+				r.Get("/me/2fa", twoFactorHandler.Status)
+				r.Post("/me/2fa/totp", twoFactorHandler.Enroll)
+				r.Post("/me/2fa/totp/confirm", twoFactorHandler.Confirm)
+				r.Post("/me/2fa/disable", twoFactorHandler.Disable)
+				r.Post("/me/2fa/recovery-codes", twoFactorHandler.RegenerateRecoveryCodes)
+				r.Get("/me/sessions", sessionHandler.List)
+				r.Delete("/me/sessions", sessionHandler.RevokeOthers)
+				r.Delete("/me/sessions/{sessionID}", sessionHandler.Revoke)
+			})
+
+			// Organizations
+			r.Get("/orgs", orgHandler.List)
+			r.With(middleware.SessionOnly).Post("/orgs", orgHandler.Create)
+			r.Route("/orgs/{orgID}", func(r chi.Router) {
+				r.Get("/", orgHandler.Get)
+				r.Get("/members", orgHandler.ListMembers)
+				r.Post("/projects", projectHandler.Create)
+				r.Get("/projects", projectHandler.List)
+
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.SessionOnly)
+					r.Patch("/", orgHandler.Update)
+					r.Delete("/", orgHandler.Delete)
+					r.Post("/members", orgHandler.AddMember)
+					r.Patch("/members/{userID}", orgHandler.UpdateMember)
+					r.Delete("/members/{userID}", orgHandler.RemoveMember)
+				})
+			})
+
 			// Projects
 			r.Post("/projects", projectHandler.Create)
 			r.Get("/projects", projectHandler.List)
@@ -117,6 +282,18 @@ func main() {
 			r.Patch("/projects/{projectID}", projectHandler.Update)
 			r.Delete("/projects/{projectID}", projectHandler.Delete)
 
+			// Project members
+			r.Post("/projects/{projectID}/members", memberHandler.Add)
+			r.Get("/projects/{projectID}/members", memberHandler.List)
+			r.Patch("/projects/{projectID}/members/{userID}", memberHandler.UpdateRole)
+			r.Delete("/projects/{projectID}/members/{userID}", memberHandler.Remove)
+
+			// Invitations
+			r.Post("/projects/{projectID}/invitations", invitationHandler.Create)
+			r.Get("/projects/{projectID}/invitations", invitationHandler.List)
+			r.Delete("/projects/{projectID}/invitations/{invitationID}", invitationHandler.Revoke)
+			r.Post("/invitations/{token}/accept", invitationHandler.Accept)
+
 			// Boards
 			r.Post("/projects/{projectID}/boards", boardHandler.Create)
 			r.Get("/projects/{projectID}/boards", boardHandler.List)
@@ -137,7 +314,9 @@ func main() {
 			r.Patch("/tasks/{taskID}", taskHandler.Update)
 			r.Put("/tasks/{taskID}/move", taskHandler.Move)
 			r.Delete("/tasks/{taskID}", taskHandler.Delete)
+			r.Get("/tasks/{taskID}/activity", taskActivityHandler.Timeline)
 			r.Get("/boards/{boardID}/tasks/export", exportHandler.TasksCSV)
+			r.Get("/boards/{boardID}/analytics/flow", flowHandler.Flow)
 
 			// Comments
 			r.Post("/tasks/{taskID}/comments", commentHandler.Create)
@@ -152,6 +331,24 @@ func main() {
 			r.Post("/tasks/{taskID}/labels", labelHandler.AddToTask)
 			r.Delete("/tasks/{taskID}/labels/{labelID}", labelHandler.RemoveFromTask)
 			r.Get("/tasks/{taskID}/labels", labelHandler.ListByTask)
+
+			// Audit log
+			r.Get("/projects/{projectID}/audit", auditHandler.ListByProject)
+
+			// Administration (global admins, login sessions only)
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(middleware.SessionOnly)
+				r.Use(middleware.RequireRole(domain.UserRoleAdmin))
+				r.Get("/users", adminHandler.ListUsers)
+				r.Patch("/users/{userID}/role", adminHandler.SetRole)
+				r.Post("/users/{userID}/deactivate", adminHandler.Deactivate)
+				r.Post("/users/{userID}/reactivate", adminHandler.Reactivate)
+				r.Post("/users/{userID}/password-reset", adminHandler.ForcePasswordReset)
+				r.Delete("/users/{userID}/2fa", twoFactorHandler.Reset)
+				r.Delete("/users/{userID}/lockout", authHandler.Unlock)
+				r.Get("/audit", auditHandler.List)
+				r.Get("/stats", adminHandler.Stats)
+			})
 		})
 	})
 
@@ -164,9 +361,49 @@ func main() {
 		IdleTimeout:  cfg.Server.IdleTimeout,
 	}
 
-	slog.Info("server starting", "addr", addr, "time", time.Now().Format(time.RFC3339))
-	if err := srv.ListenAndServe(); err != nil {
+	servers := []*http.Server{srv}
+
+	// Admin server (optional), so /metrics can stay off the public port
+	if cfg.Server.MetricsEnabled && cfg.Server.AdminPort != 0 {
+		admin := chi.NewRouter()
+		admin.Handle("/metrics", metrics.Handler())
+		servers = append(servers, &http.Server{
+			Addr:         fmt.Sprintf(":%d", cfg.Server.AdminPort),
+			Handler:      admin,
+			ReadTimeout:  cfg.Server.ReadTimeout,
+			WriteTimeout: cfg.Server.WriteTimeout,
+			IdleTimeout:  cfg.Server.IdleTimeout,
+		})
+	}
+
+	serverErr := make(chan error, len(servers))
+	for _, s := range servers {
+		slog.Info("server starting", "addr", s.Addr)
+		go func() {
+			serverErr <- s.ListenAndServe()
+		}()
+	}
+
+	select {
+	case err := <-serverErr:
 		slog.Error("server failed", "error", err)
 		os.Exit(1)
+	case <-ctx.Done():
+	}
+
+	// A second signal kills the process instead of waiting for the drain.
+	stop()
+	slog.Info("shutting down", "timeout", cfg.Server.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	for _, s := range servers {
+		if err := s.Shutdown(shutdownCtx); err != nil {
+			slog.Warn("drain timed out, closing remaining connections", "addr", s.Addr, "error", err)
+			s.Close()
+		}
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Warn("failed to flush traces", "error", err)
 	}
+	slog.Info("server stopped")
 }
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+
+	"github.com/letyshub/project-management/internal/config"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/handler"
+	"github.com/letyshub/project-management/internal/metrics"
+	"github.com/letyshub/project-management/internal/migrate"
+	"github.com/letyshub/project-management/internal/repository/memory"
+	"github.com/letyshub/project-management/internal/repository/postgres"
+	"github.com/letyshub/project-management/internal/repository/sqlite"
+	"github.com/letyshub/project-management/internal/tracing"
+	"github.com/letyshub/project-management/migrations"
+)
+
+// repositories is every store the services depend on, backed by the
+// implementation selected with STORAGE.
+type repositories struct {
+	users          domain.UserRepository
+	refreshTokens  domain.RefreshTokenRepository
+	orgs           domain.OrganizationRepository
+	orgMembers     domain.OrganizationMemberRepository
+	projects       domain.ProjectRepository
+	members        domain.ProjectMemberRepository
+	boards         domain.BoardRepository
+	columns        domain.ColumnRepository
+	tasks          domain.TaskRepository
+	comments       domain.CommentRepository
+	labels         domain.LabelRepository
+	invitations    domain.InvitationRepository
+	userTokens     domain.UserTokenRepository
+	accessTokens   domain.PersonalAccessTokenRepository
+	identities     domain.UserIdentityRepository
+	twoFactor      domain.TwoFactorRepository
+	securityEvents domain.SecurityEventRepository
+	auditEvents    domain.AuditEventRepository
+	transitions    domain.TaskTransitionRepository
+	loginThrottles domain.LoginThrottleRepository
+	tx             domain.TxManager
+
+	// checks are what /readyz reports for this backend.
+	checks []handler.HealthCheck
+	close  func()
+}
+
+func openStorage(ctx context.Context, cfg config.DatabaseConfig) (*repositories, error) {
+	switch cfg.Storage {
+	case config.StorageSQLite:
+		return sqliteRepositories(ctx, cfg)
+	case config.StorageMemory:
+		slog.Warn("using in-memory storage, data is lost on restart")
+		return memoryRepositories(memory.NewStore()), nil
+	default:
+		return postgresRepositories(ctx, cfg)
+	}
+}
+
+func postgresRepositories(ctx context.Context, cfg config.DatabaseConfig) (*repositories, error) {
+	poolCfg, err := pgxpool.ParseConfig(cfg.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("parse database config: %w", err)
+	}
+	poolCfg.ConnConfig.Tracer = tracing.PgxTracer{}
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("connect to database: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("ping database: %w", err)
+	}
+	slog.Info("connected to database")
+	metrics.Register(metrics.NewPoolCollector(pool))
+
+	migrator, err := migrate.NewPostgres(pool, migrations.Postgres)
+	if err == nil {
+		err = migrator.Up(ctx)
+	}
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("run migrations: %w", err)
+	}
+
+	return &repositories{
+		users:          postgres.NewUserRepo(pool),
+		refreshTokens:  postgres.NewRefreshTokenRepo(pool),
+		orgs:           postgres.NewOrganizationRepo(pool),
+		orgMembers:     postgres.NewOrganizationMemberRepo(pool),
+		projects:       postgres.NewProjectRepo(pool),
+		members:        postgres.NewProjectMemberRepo(pool),
+		boards:         postgres.NewBoardRepo(pool),
+		columns:        postgres.NewColumnRepo(pool),
+		tasks:          postgres.NewTaskRepo(pool),
+		comments:       postgres.NewCommentRepo(pool),
+		labels:         postgres.NewLabelRepo(pool),
+		invitations:    postgres.NewInvitationRepo(pool),
+		userTokens:     postgres.NewUserTokenRepo(pool),
+		accessTokens:   postgres.NewPersonalAccessTokenRepo(pool),
+		identities:     postgres.NewUserIdentityRepo(pool),
+		twoFactor:      postgres.NewTwoFactorRepo(pool),
+		securityEvents: postgres.NewSecurityEventRepo(pool),
+		auditEvents:    postgres.NewAuditEventRepo(pool),
+		transitions:    postgres.NewTaskTransitionRepo(pool),
+		loginThrottles: postgres.NewLoginThrottleRepo(pool),
+		tx:             postgres.NewTxManager(pool),
+		checks: []handler.HealthCheck{
+			{Name: "database", Check: func(ctx context.Context) (any, error) {
+				stat := pool.Stat()
+				return map[string]any{
+					"total_conns":         stat.TotalConns(),
+					"idle_conns":          stat.IdleConns(),
+					"acquired_conns":      stat.AcquiredConns(),
+					"max_conns":           stat.MaxConns(),
+					"acquire_count":       stat.AcquireCount(),
+					"empty_acquire_count": stat.EmptyAcquireCount(),
+				}, pool.Ping(ctx)
+			}},
+			migrationsCheck(migrator),
+		},
+		close: pool.Close,
+	}, nil
+}
+
+func sqliteRepositories(ctx context.Context, cfg config.DatabaseConfig) (*repositories, error) {
+	db, err := sqlite.Open(cfg.SQLitePath)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	slog.Info("opened sqlite database", "path", cfg.SQLitePath)
+	metrics.Register(collectors.NewDBStatsCollector(db, "sqlite"))
+
+	migrator, err := migrate.NewSQLite(db, migrations.SQLite)
+	if err == nil {
+		err = migrator.Up(ctx)
+	}
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("run migrations: %w", err)
+	}
+
+	return &repositories{
+		users:          sqlite.NewUserRepo(db),
+		refreshTokens:  sqlite.NewRefreshTokenRepo(db),
+		orgs:           sqlite.NewOrganizationRepo(db),
+		orgMembers:     sqlite.NewOrganizationMemberRepo(db),
+		projects:       sqlite.NewProjectRepo(db),
+		members:        sqlite.NewProjectMemberRepo(db),
+		boards:         sqlite.NewBoardRepo(db),
+		columns:        sqlite.NewColumnRepo(db),
+		tasks:          sqlite.NewTaskRepo(db),
+		comments:       sqlite.NewCommentRepo(db),
+		labels:         sqlite.NewLabelRepo(db),
+		invitations:    sqlite.NewInvitationRepo(db),
+		userTokens:     sqlite.NewUserTokenRepo(db),
+		accessTokens:   sqlite.NewPersonalAccessTokenRepo(db),
+		identities:     sqlite.NewUserIdentityRepo(db),
+		twoFactor:      sqlite.NewTwoFactorRepo(db),
+		securityEvents: sqlite.NewSecurityEventRepo(db),
+		auditEvents:    sqlite.NewAuditEventRepo(db),
+		transitions:    sqlite.NewTaskTransitionRepo(db),
+		loginThrottles: sqlite.NewLoginThrottleRepo(db),
+		tx:             sqlite.NewTxManager(db),
+		checks: []handler.HealthCheck{
+			{Name: "database", Check: func(ctx context.Context) (any, error) {
+				stats := db.Stats()
+				return map[string]any{
+					"open_connections": stats.OpenConnections,
+					"in_use":           stats.InUse,
+					"idle":             stats.Idle,
+					"wait_count":       stats.WaitCount,
+				}, db.PingContext(ctx)
+			}},
+			migrationsCheck(migrator),
+		},
+		close: func() { db.Close() },
+	}, nil
+}
+
+func memoryRepositories(store *memory.Store) *repositories {
+	return &repositories{
+		users:          memory.NewUserRepo(store),
+		refreshTokens:  memory.NewRefreshTokenRepo(store),
+		orgs:           memory.NewOrganizationRepo(store),
+		orgMembers:     memory.NewOrganizationMemberRepo(store),
+		projects:       memory.NewProjectRepo(store),
+		members:        memory.NewProjectMemberRepo(store),
+		boards:         memory.NewBoardRepo(store),
+		columns:        memory.NewColumnRepo(store),
+		tasks:          memory.NewTaskRepo(store),
+		comments:       memory.NewCommentRepo(store),
+		labels:         memory.NewLabelRepo(store),
+		invitations:    memory.NewInvitationRepo(store),
+		userTokens:     memory.NewUserTokenRepo(store),
+		accessTokens:   memory.NewPersonalAccessTokenRepo(store),
+		identities:     memory.NewUserIdentityRepo(store),
+		twoFactor:      memory.NewTwoFactorRepo(store),
+		securityEvents: memory.NewSecurityEventRepo(store),
+		auditEvents:    memory.NewAuditEventRepo(store),
+		transitions:    memory.NewTaskTransitionRepo(store),
+		loginThrottles: memory.NewLoginThrottleRepo(store),
+		tx:             memory.NewTxManager(store),
+		close:          func() {},
+	}
+}
+
+func migrationsCheck(m *migrate.Migrator) handler.HealthCheck {
+	return handler.HealthCheck{Name: "migrations", Check: func(ctx context.Context) (any, error) {
+		pending, err := m.Pending(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(pending) > 0 {
+			return map[string]any{"pending": pending}, fmt.Errorf("%d migrations pending", len(pending))
+		}
+		return nil, nil
+	}}
+}
@@ -0,0 +1,167 @@
+// Command migrate applies and rolls back the schema migrations embedded in
+// the binary, against the database selected by the same STORAGE and DB_*
+// variables as the API.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/letyshub/project-management/internal/config"
+	"github.com/letyshub/project-management/internal/migrate"
+	"github.com/letyshub/project-management/internal/repository/sqlite"
+	"github.com/letyshub/project-management/migrations"
+)
+
+const usage = `Usage: migrate <command> [arguments]
+
+Commands:
+  up                apply every pending migration
+  down [N]          roll back the last N migrations (default 1)
+  redo              roll back the last migration and apply it again
+  status            list migrations and whether they are applied
+  create [-dir DIR] NAME
+                    write empty up and down files for a new migration
+                    (DIR defaults to migrations)
+`
+
+var errUsage = errors.New("invalid arguments")
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	if err := run(context.Background(), flag.Args()); err != nil {
+		if errors.Is(err, errUsage) {
+			flag.Usage()
+			os.Exit(2)
+		}
+		slog.Error("migrate failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	cmd, args := args[0], args[1:]
+
+	if cmd == "create" {
+		return create(args)
+	}
+
+	cfg, err := config.LoadDatabase()
+	if err != nil {
+		return err
+	}
+	m, closeDB, err := open(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	switch cmd {
+	case "up":
+		if len(args) != 0 {
+			return errUsage
+		}
+		return m.Up(ctx)
+	case "down":
+		n := 1
+		switch len(args) {
+		case 0:
+		case 1:
+			if n, err = strconv.Atoi(args[0]); err != nil || n < 1 {
+				return errUsage
+			}
+		default:
+			return errUsage
+		}
+		return m.Down(ctx, n)
+	case "redo":
+		if len(args) != 0 {
+			return errUsage
+		}
+		return m.Redo(ctx)
+	case "status":
+		if len(args) != 0 {
+			return errUsage
+		}
+		return status(ctx, m)
+	default:
+		return errUsage
+	}
+}
+
+func open(ctx context.Context, cfg *config.DatabaseConfig) (*migrate.Migrator, func(), error) {
+	switch cfg.Storage {
+	case config.StoragePostgres:
+		pool, err := pgxpool.New(ctx, cfg.DSN())
+		if err != nil {
+			return nil, nil, fmt.Errorf("connect to database: %w", err)
+		}
+		m, err := migrate.NewPostgres(pool, migrations.Postgres)
+		if err != nil {
+			pool.Close()
+			return nil, nil, err
+		}
+		return m, pool.Close, nil
+	case config.StorageSQLite:
+		db, err := sqlite.Open(cfg.SQLitePath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("open database: %w", err)
+		}
+		m, err := migrate.NewSQLite(db, migrations.SQLite)
+		if err != nil {
+			db.Close()
+			return nil, nil, err
+		}
+		return m, func() { db.Close() }, nil
+	default:
+		return nil, nil, fmt.Errorf("STORAGE=%s has no schema to migrate", cfg.Storage)
+	}
+}
+
+func status(ctx context.Context, m *migrate.Migrator) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MIGRATION\tSTATE\tAPPLIED AT")
+	for _, s := range statuses {
+		appliedAt := "-"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Local().Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", s.Name, s.State, appliedAt)
+	}
+	return w.Flush()
+}
+
+func create(args []string) error {
+	flags := flag.NewFlagSet("create", flag.ContinueOnError)
+	flags.Usage = func() {}
+	dir := flags.String("dir", "migrations", "")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 1 {
+		return errUsage
+	}
+
+	up, down, err := migrate.Create(*dir, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	fmt.Println(up)
+	fmt.Println(down)
+	return nil
+}
@@ -0,0 +1,77 @@
+// Package authz decides whether a user may perform an action on a resource.
+// Every service asks the Policy before reading or changing project data, so
+// access rules live in one place instead of being repeated per method.
+package authz
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type Action string
+
+const (
+	// ActionRead covers every read path and is granted to viewers.
+	ActionRead Action = "read"
+	// ActionWrite covers day-to-day work such as tasks and comments.
+	ActionWrite Action = "write"
+	// ActionManage covers project structure: boards, columns, labels, members.
+	ActionManage Action = "manage"
+	// ActionDelete covers destroying the project itself.
+	ActionDelete Action = "delete"
+)
+
+type ResourceType string
+
+const (
+	ResourceProject ResourceType = "project"
+	ResourceBoard   ResourceType = "board"
+	ResourceColumn  ResourceType = "column"
+	ResourceTask    ResourceType = "task"
+	ResourceLabel   ResourceType = "label"
+	ResourceComment ResourceType = "comment"
+)
+
+type Resource struct {
+	Type ResourceType
+	ID   uuid.UUID
+}
+
+func Project(id uuid.UUID) Resource { return Resource{Type: ResourceProject, ID: id} }
+func Board(id uuid.UUID) Resource   { return Resource{Type: ResourceBoard, ID: id} }
+func Column(id uuid.UUID) Resource  { return Resource{Type: ResourceColumn, ID: id} }
+func Task(id uuid.UUID) Resource    { return Resource{Type: ResourceTask, ID: id} }
+func Label(id uuid.UUID) Resource   { return Resource{Type: ResourceLabel, ID: id} }
+func Comment(id uuid.UUID) Resource { return Resource{Type: ResourceComment, ID: id} }
+
+type Subject struct {
+	UserID uuid.UUID
+}
+
+func User(id uuid.UUID) Subject { return Subject{UserID: id} }
+
+type Policy interface {
+	// Authorize returns nil if subject may perform action on resource,
+	// domain.ErrNotFound if the resource does not exist and
+	// domain.ErrForbidden otherwise.
+	Authorize(ctx context.Context, subject Subject, action Action, resource Resource) error
+	// ProjectOf returns the ID of the project that owns resource.
+	ProjectOf(ctx context.Context, resource Resource) (uuid.UUID, error)
+}
+
+// RequiredRole is the minimum project role needed for an action.
+func RequiredRole(action Action) domain.ProjectRole {
+	switch action {
+	case ActionRead:
+		return domain.ProjectRoleViewer
+	case ActionWrite:
+		return domain.ProjectRoleMember
+	case ActionManage:
+		return domain.ProjectRoleAdmin
+	default:
+		return domain.ProjectRoleOwner
+	}
+}
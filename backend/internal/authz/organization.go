@@ -0,0 +1,27 @@
+package authz
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type organizationKey struct{}
+
+// WithOrganization makes orgID the active organization for ctx. Projects in
+// other organizations are then hidden, even from their members.
+func WithOrganization(ctx context.Context, orgID uuid.UUID) context.Context {
+	return context.WithValue(ctx, organizationKey{}, orgID)
+}
+
+// ActiveOrganization returns the organization ctx is limited to, if any.
+func ActiveOrganization(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(organizationKey{}).(uuid.UUID)
+	return id, ok
+}
+
+// InOrganization reports whether a project in orgID may be accessed with ctx.
+func InOrganization(ctx context.Context, orgID uuid.UUID) bool {
+	active, ok := ActiveOrganization(ctx)
+	return !ok || active == orgID
+}
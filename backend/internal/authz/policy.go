@@ -0,0 +1,141 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/tracing"
+)
+
+// ProjectPolicy grants access based on the subject's project membership role.
+// Comments additionally require authorship for ActionWrite, a project scope
+// on the context (see WithProjectScope) hides every other project, and an
+// active organization (see WithOrganization) hides projects outside it.
+type ProjectPolicy struct {
+	projectRepo domain.ProjectRepository
+	memberRepo  domain.ProjectMemberRepository
+	boardRepo   domain.BoardRepository
+	columnRepo  domain.ColumnRepository
+	taskRepo    domain.TaskRepository
+	labelRepo   domain.LabelRepository
+	commentRepo domain.CommentRepository
+}
+
+func NewProjectPolicy(
+	projectRepo domain.ProjectRepository,
+	memberRepo domain.ProjectMemberRepository,
+	boardRepo domain.BoardRepository,
+	columnRepo domain.ColumnRepository,
+	taskRepo domain.TaskRepository,
+	labelRepo domain.LabelRepository,
+	commentRepo domain.CommentRepository,
+) *ProjectPolicy {
+	return &ProjectPolicy{
+		projectRepo: projectRepo,
+		memberRepo:  memberRepo,
+		boardRepo:   boardRepo,
+		columnRepo:  columnRepo,
+		taskRepo:    taskRepo,
+		labelRepo:   labelRepo,
+		commentRepo: commentRepo,
+	}
+}
+
+func (p *ProjectPolicy) Authorize(ctx context.Context, subject Subject, action Action, resource Resource) error {
+	ctx, span := tracing.Start(ctx, "ProjectPolicy.Authorize")
+	defer span.End()
+
+	var comment *domain.Comment
+	var projectID uuid.UUID
+	var err error
+
+	if resource.Type == ResourceComment {
+		comment, err = p.commentRepo.GetByID(ctx, resource.ID)
+		if err != nil {
+			return err
+		}
+		projectID, err = p.ProjectOf(ctx, Task(comment.TaskID))
+	} else {
+		projectID, err = p.ProjectOf(ctx, resource)
+	}
+	if err != nil {
+		return err
+	}
+	if !InScope(ctx, projectID) {
+		return domain.ErrForbidden
+	}
+	if _, ok := ActiveOrganization(ctx); ok {
+		project, err := p.projectRepo.GetByID(ctx, projectID)
+		if err != nil {
+			return err
+		}
+		if !InOrganization(ctx, project.OrganizationID) {
+			return domain.ErrForbidden
+		}
+	}
+
+	member, err := p.memberRepo.Get(ctx, projectID, subject.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrForbidden
+		}
+		return err
+	}
+	if !member.Role.AtLeast(RequiredRole(action)) {
+		return domain.ErrForbidden
+	}
+	if comment != nil && action == ActionWrite && comment.AuthorID != subject.UserID {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+func (p *ProjectPolicy) ProjectOf(ctx context.Context, resource Resource) (uuid.UUID, error) {
+	ctx, span := tracing.Start(ctx, "ProjectPolicy.ProjectOf")
+	defer span.End()
+
+	switch resource.Type {
+	case ResourceProject:
+		project, err := p.projectRepo.GetByID(ctx, resource.ID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return project.ID, nil
+	case ResourceBoard:
+		board, err := p.boardRepo.GetByID(ctx, resource.ID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return board.ProjectID, nil
+	case ResourceColumn:
+		col, err := p.columnRepo.GetByID(ctx, resource.ID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return p.ProjectOf(ctx, Board(col.BoardID))
+	case ResourceTask:
+		task, err := p.taskRepo.GetByID(ctx, resource.ID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return p.ProjectOf(ctx, Column(task.ColumnID))
+	case ResourceLabel:
+		label, err := p.labelRepo.GetByID(ctx, resource.ID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return label.ProjectID, nil
+	case ResourceComment:
+		comment, err := p.commentRepo.GetByID(ctx, resource.ID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return p.ProjectOf(ctx, Task(comment.TaskID))
+	default:
+		return uuid.Nil, fmt.Errorf("authz: unknown resource type %q", resource.Type)
+	}
+}
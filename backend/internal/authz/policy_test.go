@@ -0,0 +1,269 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+// Fake repositories backed by maps. Only the lookups the policy needs are
+// implemented; everything else is a no-op.
+
+type fakeProjects map[uuid.UUID]*domain.Project
+
+func (f fakeProjects) Create(ctx context.Context, p *domain.Project) error { return nil }
+func (f fakeProjects) GetByID(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
+	if p, ok := f[id]; ok {
+		return p, nil
+	}
+	return nil, domain.ErrNotFound
+}
+func (f fakeProjects) ListByOwner(ctx context.Context, id uuid.UUID) ([]*domain.Project, error) {
+	return nil, nil
+}
+func (f fakeProjects) ListByMember(ctx context.Context, id uuid.UUID) ([]*domain.Project, error) {
+	return nil, nil
+}
+func (f fakeProjects) Update(ctx context.Context, p *domain.Project) error { return nil }
+func (f fakeProjects) Delete(ctx context.Context, id uuid.UUID) error      { return nil }
+
+type fakeMembers map[uuid.UUID]domain.ProjectRole
+
+func (f fakeMembers) Create(ctx context.Context, m *domain.ProjectMember) error { return nil }
+func (f fakeMembers) Get(ctx context.Context, projectID, userID uuid.UUID) (*domain.ProjectMember, error) {
+	if role, ok := f[userID]; ok {
+		return &domain.ProjectMember{ProjectID: projectID, UserID: userID, Role: role}, nil
+	}
+	return nil, domain.ErrNotFound
+}
+func (f fakeMembers) ListByProject(ctx context.Context, id uuid.UUID) ([]*domain.ProjectMember, error) {
+	return nil, nil
+}
+func (f fakeMembers) CountByRole(ctx context.Context, id uuid.UUID, role domain.ProjectRole) (int, error) {
+	return 0, nil
+}
+func (f fakeMembers) Update(ctx context.Context, m *domain.ProjectMember) error { return nil }
+func (f fakeMembers) Delete(ctx context.Context, projectID, userID uuid.UUID) error {
+	return nil
+}
+
+type fakeBoards map[uuid.UUID]*domain.Board
+
+func (f fakeBoards) Create(ctx context.Context, b *domain.Board) error { return nil }
+func (f fakeBoards) GetByID(ctx context.Context, id uuid.UUID) (*domain.Board, error) {
+	if b, ok := f[id]; ok {
+		return b, nil
+	}
+	return nil, domain.ErrNotFound
+}
+func (f fakeBoards) ListByProject(ctx context.Context, id uuid.UUID) ([]*domain.Board, error) {
+	return nil, nil
+}
+func (f fakeBoards) Update(ctx context.Context, b *domain.Board) error { return nil }
+func (f fakeBoards) Delete(ctx context.Context, id uuid.UUID) error    { return nil }
+
+type fakeColumns map[uuid.UUID]*domain.Column
+
+func (f fakeColumns) Create(ctx context.Context, c *domain.Column) error { return nil }
+func (f fakeColumns) GetByID(ctx context.Context, id uuid.UUID) (*domain.Column, error) {
+	if c, ok := f[id]; ok {
+		return c, nil
+	}
+	return nil, domain.ErrNotFound
+}
+func (f fakeColumns) ListByBoard(ctx context.Context, id uuid.UUID) ([]*domain.Column, error) {
+	return nil, nil
+}
+func (f fakeColumns) Update(ctx context.Context, c *domain.Column) error { return nil }
+func (f fakeColumns) Delete(ctx context.Context, id uuid.UUID) error     { return nil }
+
+type fakeTasks map[uuid.UUID]*domain.Task
+
+func (f fakeTasks) Create(ctx context.Context, t *domain.Task) error { return nil }
+func (f fakeTasks) GetByID(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
+	if t, ok := f[id]; ok {
+		return t, nil
+	}
+	return nil, domain.ErrNotFound
+}
+func (f fakeTasks) ListByColumn(ctx context.Context, id uuid.UUID) ([]*domain.Task, error) {
+	return nil, nil
+}
+func (f fakeTasks) ListByBoard(ctx context.Context, id uuid.UUID, filter domain.TaskFilter) ([]*domain.Task, error) {
+	return nil, nil
+}
+func (f fakeTasks) Update(ctx context.Context, t *domain.Task) error { return nil }
+func (f fakeTasks) Delete(ctx context.Context, id uuid.UUID) error   { return nil }
+
+type fakeLabels map[uuid.UUID]*domain.Label
+
+func (f fakeLabels) Create(ctx context.Context, l *domain.Label) error { return nil }
+func (f fakeLabels) GetByID(ctx context.Context, id uuid.UUID) (*domain.Label, error) {
+	if l, ok := f[id]; ok {
+		return l, nil
+	}
+	return nil, domain.ErrNotFound
+}
+func (f fakeLabels) ListByProject(ctx context.Context, id uuid.UUID) ([]*domain.Label, error) {
+	return nil, nil
+}
+func (f fakeLabels) Delete(ctx context.Context, id uuid.UUID) error                 { return nil }
+func (f fakeLabels) AddToTask(ctx context.Context, taskID, labelID uuid.UUID) error { return nil }
+func (f fakeLabels) RemoveFromTask(ctx context.Context, taskID, labelID uuid.UUID) error {
+	return nil
+}
+func (f fakeLabels) ListByTask(ctx context.Context, id uuid.UUID) ([]*domain.Label, error) {
+	return nil, nil
+}
+
+type fakeComments map[uuid.UUID]*domain.Comment
+
+func (f fakeComments) Create(ctx context.Context, c *domain.Comment) error { return nil }
+func (f fakeComments) GetByID(ctx context.Context, id uuid.UUID) (*domain.Comment, error) {
+	if c, ok := f[id]; ok {
+		return c, nil
+	}
+	return nil, domain.ErrNotFound
+}
+func (f fakeComments) ListByTask(ctx context.Context, id uuid.UUID) ([]*domain.Comment, error) {
+	return nil, nil
+}
+func (f fakeComments) Update(ctx context.Context, c *domain.Comment) error { return nil }
+func (f fakeComments) Delete(ctx context.Context, id uuid.UUID) error      { return nil }
+
+type fixture struct {
+	policy  *ProjectPolicy
+	users   map[domain.ProjectRole]uuid.UUID
+	outside uuid.UUID
+	org     uuid.UUID
+
+	project, board, column, task, label, comment uuid.UUID
+}
+
+func newFixture() *fixture {
+	f := &fixture{
+		users:   map[domain.ProjectRole]uuid.UUID{},
+		outside: uuid.New(),
+		org:     uuid.New(),
+		project: uuid.New(),
+		board:   uuid.New(),
+		column:  uuid.New(),
+		task:    uuid.New(),
+		label:   uuid.New(),
+		comment: uuid.New(),
+	}
+	members := fakeMembers{}
+	for _, role := range []domain.ProjectRole{
+		domain.ProjectRoleOwner, domain.ProjectRoleAdmin, domain.ProjectRoleMember, domain.ProjectRoleViewer,
+	} {
+		id := uuid.New()
+		f.users[role] = id
+		members[id] = role
+	}
+
+	f.policy = NewProjectPolicy(
+		fakeProjects{f.project: {ID: f.project, OrganizationID: f.org, OwnerID: f.users[domain.ProjectRoleOwner]}},
+		members,
+		fakeBoards{f.board: {ID: f.board, ProjectID: f.project}},
+		fakeColumns{f.column: {ID: f.column, BoardID: f.board}},
+		fakeTasks{f.task: {ID: f.task, ColumnID: f.column}},
+		fakeLabels{f.label: {ID: f.label, ProjectID: f.project}},
+		fakeComments{f.comment: {ID: f.comment, TaskID: f.task, AuthorID: f.users[domain.ProjectRoleMember]}},
+	)
+	return f
+}
+
+func TestProjectPolicy_CommentAuthorship(t *testing.T) {
+	f := newFixture()
+
+	tests := []struct {
+		name   string
+		user   uuid.UUID
+		action Action
+		want   error
+	}{
+		{"PATCH /comments/{commentID} as author", f.users[domain.ProjectRoleMember], ActionWrite, nil},
+		{"PATCH /comments/{commentID} as project owner", f.users[domain.ProjectRoleOwner], ActionWrite, domain.ErrForbidden},
+		{"PATCH /comments/{commentID} as viewer", f.users[domain.ProjectRoleViewer], ActionWrite, domain.ErrForbidden},
+		{"DELETE /comments/{commentID} as author", f.users[domain.ProjectRoleMember], ActionWrite, nil},
+		{"DELETE /comments/{commentID} as admin", f.users[domain.ProjectRoleAdmin], ActionManage, nil},
+		{"DELETE /comments/{commentID} as outsider", f.outside, ActionWrite, domain.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := f.policy.Authorize(context.Background(), User(tt.user), tt.action, Comment(f.comment))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestProjectPolicy_MissingResource(t *testing.T) {
+	f := newFixture()
+	owner := f.users[domain.ProjectRoleOwner]
+
+	for _, res := range []Resource{
+		Project(uuid.New()), Board(uuid.New()), Column(uuid.New()),
+		Task(uuid.New()), Label(uuid.New()), Comment(uuid.New()),
+	} {
+		t.Run(string(res.Type), func(t *testing.T) {
+			err := f.policy.Authorize(context.Background(), User(owner), ActionRead, res)
+			if !errors.Is(err, domain.ErrNotFound) {
+				t.Errorf("expected ErrNotFound, got %v", err)
+			}
+		})
+	}
+}
+
+func TestProjectPolicy_ProjectOf(t *testing.T) {
+	f := newFixture()
+
+	for _, res := range []Resource{
+		Project(f.project), Board(f.board), Column(f.column),
+		Task(f.task), Label(f.label), Comment(f.comment),
+	} {
+		got, err := f.policy.ProjectOf(context.Background(), res)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", res.Type, err)
+		}
+		if got != f.project {
+			t.Errorf("%s: expected project %s, got %s", res.Type, f.project, got)
+		}
+	}
+}
+
+func TestProjectPolicy_ProjectScope(t *testing.T) {
+	f := newFixture()
+	owner := f.users[domain.ProjectRoleOwner]
+
+	inScope := WithProjectScope(context.Background(), f.project)
+	if err := f.policy.Authorize(inScope, User(owner), ActionManage, Task(f.task)); err != nil {
+		t.Errorf("expected access within scope, got %v", err)
+	}
+
+	otherScope := WithProjectScope(context.Background(), uuid.New())
+	if err := f.policy.Authorize(otherScope, User(owner), ActionRead, Task(f.task)); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("expected ErrForbidden outside scope, got %v", err)
+	}
+}
+
+func TestProjectPolicy_ActiveOrganization(t *testing.T) {
+	f := newFixture()
+	owner := f.users[domain.ProjectRoleOwner]
+
+	inOrg := WithOrganization(context.Background(), f.org)
+	if err := f.policy.Authorize(inOrg, User(owner), ActionRead, Label(f.label)); err != nil {
+		t.Errorf("expected access within the active organization, got %v", err)
+	}
+
+	otherOrg := WithOrganization(context.Background(), uuid.New())
+	if err := f.policy.Authorize(otherOrg, User(owner), ActionRead, Label(f.label)); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("expected ErrForbidden outside the active organization, got %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package authz
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type scopeKey struct{}
+
+// WithProjectScope limits every authorization decision made with ctx to a
+// single project. It is used for personal access tokens created for one
+// project.
+func WithProjectScope(ctx context.Context, projectID uuid.UUID) context.Context {
+	return context.WithValue(ctx, scopeKey{}, projectID)
+}
+
+// ProjectScope returns the project ctx is limited to, if any.
+func ProjectScope(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(scopeKey{}).(uuid.UUID)
+	return id, ok
+}
+
+// InScope reports whether projectID may be accessed with ctx.
+func InScope(ctx context.Context, projectID uuid.UUID) bool {
+	scoped, ok := ProjectScope(ctx)
+	return !ok || scoped == projectID
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -12,29 +13,153 @@ type Config struct {
 	Server   ServerConfig
 	Database DatabaseConfig
 	JWT      JWTConfig
+	Auth     AuthConfig
+	Mail     MailConfig
+	OIDC     OIDCConfig
+	Tracing  TracingConfig
+	Log      LogConfig
 }
 
+// ServerConfig's ShutdownTimeout is how long in-flight requests get to
+// finish after SIGTERM before their connections are closed. With
+// MetricsEnabled, /metrics is served on AdminPort if set, otherwise on Port
+// next to the API.
 type ServerConfig struct {
-	Port         int           `envconfig:"SERVER_PORT" default:"8080"`
-	ReadTimeout  time.Duration `envconfig:"SERVER_READ_TIMEOUT" default:"10s"`
-	WriteTimeout time.Duration `envconfig:"SERVER_WRITE_TIMEOUT" default:"30s"`
-	IdleTimeout  time.Duration `envconfig:"SERVER_IDLE_TIMEOUT" default:"60s"`
-	CORSOrigins  []string      `envconfig:"CORS_ORIGINS" default:"http://localhost:4200"`
+	Port            int           `envconfig:"SERVER_PORT" default:"8080"`
+	AdminPort       int           `envconfig:"SERVER_ADMIN_PORT"`
+	ReadTimeout     time.Duration `envconfig:"SERVER_READ_TIMEOUT" default:"10s"`
+	WriteTimeout    time.Duration `envconfig:"SERVER_WRITE_TIMEOUT" default:"30s"`
+	IdleTimeout     time.Duration `envconfig:"SERVER_IDLE_TIMEOUT" default:"60s"`
+	ShutdownTimeout time.Duration `envconfig:"SERVER_SHUTDOWN_TIMEOUT" default:"30s"`
+	MetricsEnabled  bool          `envconfig:"METRICS_ENABLED" default:"false"`
+	CORSOrigins     []string      `envconfig:"CORS_ORIGINS" default:"http://localhost:4200"`
+	AppURL          string        `envconfig:"APP_URL" default:"http://localhost:4200"`
 }
 
+// Storage selects the repository implementation. "sqlite" keeps everything
+// in the single file at SQLitePath, for single-user and demo deployments.
+// "memory" keeps everything in process and needs no database, which is handy
+// for demos and tests; data is lost on restart.
+const (
+	StoragePostgres = "postgres"
+	StorageSQLite   = "sqlite"
+	StorageMemory   = "memory"
+)
+
 type DatabaseConfig struct {
-	Host     string `envconfig:"DB_HOST" default:"localhost"`
-	Port     int    `envconfig:"DB_PORT" default:"5432"`
-	User     string `envconfig:"DB_USER" default:"postgres"`
-	Password string `envconfig:"DB_PASSWORD" default:"postgres"`
-	Name     string `envconfig:"DB_NAME" default:"project_management"`
-	SSLMode  string `envconfig:"DB_SSLMODE" default:"disable"`
+	Storage    string `envconfig:"STORAGE" default:"postgres"`
+	Host       string `envconfig:"DB_HOST" default:"localhost"`
+	Port       int    `envconfig:"DB_PORT" default:"5432"`
+	User       string `envconfig:"DB_USER" default:"postgres"`
+	Password   string `envconfig:"DB_PASSWORD" default:"postgres"`
+	Name       string `envconfig:"DB_NAME" default:"project_management"`
+	SSLMode    string `envconfig:"DB_SSLMODE" default:"disable"`
+	SQLitePath string `envconfig:"SQLITE_PATH" default:"task-flow.db"`
 }
 
+// JWTConfig signs access tokens with Secret (HS256) unless SigningKeyFile
+// points at an RSA or Ed25519 private key. VerificationKeyFiles lists older
+// keys whose tokens are still accepted while keys are rotated. Access tokens
+// carry Issuer and Audience, and tokens without them are rejected.
 type JWTConfig struct {
-	Secret            string        `envconfig:"JWT_SECRET" required:"true"`
-	AccessExpiration  time.Duration `envconfig:"JWT_ACCESS_EXPIRATION" default:"15m"`
-	RefreshExpiration time.Duration `envconfig:"JWT_REFRESH_EXPIRATION" default:"168h"`
+	Secret               string        `envconfig:"JWT_SECRET" required:"true"`
+	SigningKeyFile       string        `envconfig:"JWT_SIGNING_KEY_FILE"`
+	VerificationKeyFiles []string      `envconfig:"JWT_VERIFICATION_KEY_FILES"`
+	Issuer               string        `envconfig:"JWT_ISSUER" default:"project-management"`
+	Audience             string        `envconfig:"JWT_AUDIENCE" default:"project-management-api"`
+	AccessExpiration     time.Duration `envconfig:"JWT_ACCESS_EXPIRATION" default:"15m"`
+	RefreshExpiration    time.Duration `envconfig:"JWT_REFRESH_EXPIRATION" default:"168h"`
+}
+
+// Email verification modes. In "login" mode unverified users cannot sign in;
+// in "write" mode they can sign in but only make read requests.
+const (
+	EmailVerificationOff   = "off"
+	EmailVerificationLogin = "login"
+	EmailVerificationWrite = "write"
+)
+
+// Failed logins are counted per email address and per client IP. Once a key
+// reaches its limit, each further failure locks it for LoginBackoff, doubling
+// every time up to LoginLockout. Counts start over after LoginFailureWindow
+// without failures, or when the account logs in successfully.
+type AuthConfig struct {
+	InvitationExpiration            time.Duration `envconfig:"INVITATION_EXPIRATION" default:"168h"`
+	PasswordResetExpiration         time.Duration `envconfig:"PASSWORD_RESET_EXPIRATION" default:"1h"`
+	PasswordResetResendInterval     time.Duration `envconfig:"PASSWORD_RESET_RESEND_INTERVAL" default:"1m"`
+	EmailVerification               string        `envconfig:"EMAIL_VERIFICATION" default:"off"`
+	EmailVerificationExpiration     time.Duration `envconfig:"EMAIL_VERIFICATION_EXPIRATION" default:"48h"`
+	EmailVerificationResendInterval time.Duration `envconfig:"EMAIL_VERIFICATION_RESEND_INTERVAL" default:"1m"`
+	TOTPIssuer                      string        `envconfig:"TOTP_ISSUER" default:"Task Flow"`
+	TokenPurgeInterval              time.Duration `envconfig:"TOKEN_PURGE_INTERVAL" default:"1h"`
+	LoginMaxAttempts                int           `envconfig:"LOGIN_MAX_ATTEMPTS" default:"5"`
+	LoginMaxAttemptsPerIP           int           `envconfig:"LOGIN_MAX_ATTEMPTS_PER_IP" default:"20"`
+	LoginBackoff                    time.Duration `envconfig:"LOGIN_BACKOFF" default:"30s"`
+	LoginLockout                    time.Duration `envconfig:"LOGIN_LOCKOUT" default:"15m"`
+	LoginFailureWindow              time.Duration `envconfig:"LOGIN_FAILURE_WINDOW" default:"24h"`
+}
+
+type MailConfig struct {
+	Driver       string `envconfig:"MAIL_DRIVER" default:"outbox"`
+	From         string `envconfig:"MAIL_FROM" default:"Task Flow <noreply@localhost>"`
+	SMTPHost     string `envconfig:"SMTP_HOST" default:"localhost"`
+	SMTPPort     int    `envconfig:"SMTP_PORT" default:"587"`
+	SMTPUsername string `envconfig:"SMTP_USERNAME"`
+	SMTPPassword string `envconfig:"SMTP_PASSWORD"`
+	OutboxDir    string `envconfig:"MAIL_OUTBOX_DIR" default:"outbox"`
+}
+
+// OIDCConfig enables single sign-on when Issuer is set. AllowedDomains, if
+// non-empty, limits SSO to email addresses in those domains.
+type OIDCConfig struct {
+	Issuer         string   `envconfig:"OIDC_ISSUER"`
+	ClientID       string   `envconfig:"OIDC_CLIENT_ID"`
+	ClientSecret   string   `envconfig:"OIDC_CLIENT_SECRET"`
+	RedirectURL    string   `envconfig:"OIDC_REDIRECT_URL" default:"http://localhost:8080/api/v1/auth/oidc/callback"`
+	Scopes         []string `envconfig:"OIDC_SCOPES" default:"openid,email,profile"`
+	AllowedDomains []string `envconfig:"OIDC_ALLOWED_DOMAINS"`
+}
+
+// Tracing exporters. "stdout" and "file" write one JSON span per line and
+// need no collector; "otlp" sends spans over OTLP/HTTP to OTLPEndpoint, or to
+// wherever the standard OTEL_EXPORTER_OTLP_* variables point if it's unset.
+const (
+	TracingNone   = "none"
+	TracingStdout = "stdout"
+	TracingFile   = "file"
+	TracingOTLP   = "otlp"
+)
+
+// TracingConfig's SampleRatio is the fraction of new traces recorded;
+// requests that arrive with a sampled traceparent header are always recorded.
+type TracingConfig struct {
+	Exporter     string  `envconfig:"TRACING_EXPORTER" default:"none"`
+	File         string  `envconfig:"TRACING_FILE" default:"traces.jsonl"`
+	OTLPEndpoint string  `envconfig:"TRACING_OTLP_ENDPOINT"`
+	SampleRatio  float64 `envconfig:"TRACING_SAMPLE_RATIO" default:"1"`
+	ServiceName  string  `envconfig:"TRACING_SERVICE_NAME" default:"task-flow-api"`
+}
+
+func (c TracingConfig) Enabled() bool {
+	return c.Exporter != TracingNone
+}
+
+// Log formats.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// LogConfig's SuccessSampleRatio is the share of successful requests (status
+// below 400) that get an access log line; failed requests are always logged.
+type LogConfig struct {
+	Level              slog.Level `envconfig:"LOG_LEVEL" default:"info"`
+	Format             string     `envconfig:"LOG_FORMAT" default:"text"`
+	SuccessSampleRatio float64    `envconfig:"LOG_SUCCESS_SAMPLE_RATIO" default:"1"`
+}
+
+func (c OIDCConfig) Enabled() bool {
+	return c.Issuer != ""
 }
 
 func (d DatabaseConfig) DSN() string {
@@ -51,12 +176,73 @@ func Load() (*Config, error) {
 	if err := envconfig.Process("", &cfg.Server); err != nil {
 		return nil, fmt.Errorf("server config: %w", err)
 	}
-	if err := envconfig.Process("", &cfg.Database); err != nil {
-		return nil, fmt.Errorf("database config: %w", err)
+	db, err := loadDatabase()
+	if err != nil {
+		return nil, err
 	}
+	cfg.Database = *db
 	if err := envconfig.Process("", &cfg.JWT); err != nil {
 		return nil, fmt.Errorf("jwt config: %w", err)
 	}
+	if err := envconfig.Process("", &cfg.Auth); err != nil {
+		return nil, fmt.Errorf("auth config: %w", err)
+	}
+	switch cfg.Auth.EmailVerification {
+	case EmailVerificationOff, EmailVerificationLogin, EmailVerificationWrite:
+	default:
+		return nil, fmt.Errorf("auth config: EMAIL_VERIFICATION must be off, login, or write, got %q", cfg.Auth.EmailVerification)
+	}
+	if err := envconfig.Process("", &cfg.Mail); err != nil {
+		return nil, fmt.Errorf("mail config: %w", err)
+	}
+	if err := envconfig.Process("", &cfg.OIDC); err != nil {
+		return nil, fmt.Errorf("oidc config: %w", err)
+	}
+	if cfg.OIDC.Enabled() && cfg.OIDC.ClientID == "" {
+		return nil, fmt.Errorf("oidc config: OIDC_CLIENT_ID is required when OIDC_ISSUER is set")
+	}
+	if err := envconfig.Process("", &cfg.Tracing); err != nil {
+		return nil, fmt.Errorf("tracing config: %w", err)
+	}
+	switch cfg.Tracing.Exporter {
+	case TracingNone, TracingStdout, TracingFile, TracingOTLP:
+	default:
+		return nil, fmt.Errorf("tracing config: TRACING_EXPORTER must be none, stdout, file or otlp, got %q", cfg.Tracing.Exporter)
+	}
+	if cfg.Tracing.SampleRatio < 0 || cfg.Tracing.SampleRatio > 1 {
+		return nil, fmt.Errorf("tracing config: TRACING_SAMPLE_RATIO must be between 0 and 1, got %v", cfg.Tracing.SampleRatio)
+	}
+	if err := envconfig.Process("", &cfg.Log); err != nil {
+		return nil, fmt.Errorf("log config: %w", err)
+	}
+	switch cfg.Log.Format {
+	case LogFormatText, LogFormatJSON:
+	default:
+		return nil, fmt.Errorf("log config: LOG_FORMAT must be text or json, got %q", cfg.Log.Format)
+	}
+	if cfg.Log.SuccessSampleRatio < 0 || cfg.Log.SuccessSampleRatio > 1 {
+		return nil, fmt.Errorf("log config: LOG_SUCCESS_SAMPLE_RATIO must be between 0 and 1, got %v", cfg.Log.SuccessSampleRatio)
+	}
+
+	return &cfg, nil
+}
+
+// LoadDatabase loads only the database settings, for tools such as
+// cmd/migrate that don't need the rest of the API's configuration.
+func LoadDatabase() (*DatabaseConfig, error) {
+	_ = godotenv.Load()
+	return loadDatabase()
+}
 
+func loadDatabase() (*DatabaseConfig, error) {
+	var cfg DatabaseConfig
+	if err := envconfig.Process("", &cfg); err != nil {
+		return nil, fmt.Errorf("database config: %w", err)
+	}
+	switch cfg.Storage {
+	case StoragePostgres, StorageSQLite, StorageMemory:
+	default:
+		return nil, fmt.Errorf("database config: STORAGE must be postgres, sqlite or memory, got %q", cfg.Storage)
+	}
 	return &cfg, nil
 }
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEvent records who changed what, and from where. Before and After hold
+// the fields that changed, as JSON objects: creates only have After, deletes
+// only Before. ProjectID is nil for account events such as logins. The log is
+// append-only and outlives the users, projects and entities it mentions.
+type AuditEvent struct {
+	ID         uuid.UUID       `json:"id"`
+	ActorID    uuid.UUID       `json:"actor_id"`
+	ProjectID  *uuid.UUID      `json:"project_id"`
+	EntityType string          `json:"entity_type"`
+	EntityID   uuid.UUID       `json:"entity_id"`
+	Action     string          `json:"action"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	IP         string          `json:"ip"`
+	RequestID  string          `json:"request_id"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// Audited entity types. Account events are recorded against the user.
+// Memberships are recorded with the member's user ID as the entity ID;
+// organization memberships also keep organization_id in Before and After.
+const (
+	AuditEntityProject            = "project"
+	AuditEntityBoard              = "board"
+	AuditEntityColumn             = "column"
+	AuditEntityTask               = "task"
+	AuditEntityComment            = "comment"
+	AuditEntityLabel              = "label"
+	AuditEntityUser               = "user"
+	AuditEntityProjectMember      = "project_member"
+	AuditEntityOrganizationMember = "organization_member"
+	AuditEntityInvitation         = "invitation"
+	AuditEntityAccessToken        = "access_token"
+)
+
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+	AuditActionMove   = "move"
+	// Labels added to or removed from a task are recorded against the task,
+	// with the label's ID in After or Before.
+	AuditActionAddLabel    = "add_label"
+	AuditActionRemoveLabel = "remove_label"
+
+	AuditActionRegister         = "register"
+	AuditActionLogin            = "login"
+	AuditActionLogout           = "logout"
+	AuditActionPasswordChange   = "password_change"
+	AuditActionPasswordReset    = "password_reset"
+	AuditActionTwoFactorEnable  = "two_factor_enable"
+	AuditActionTwoFactorDisable = "two_factor_disable"
+
+	// Admin actions on an account, with the changed role or deactivated_at
+	// in Before and After.
+	AuditActionDeactivate         = "deactivate"
+	AuditActionReactivate         = "reactivate"
+	AuditActionRoleChange         = "role_change"
+	AuditActionForcePasswordReset = "force_password_reset"
+)
+
+// AuditFilter selects audit events. Zero fields match everything; Since is
+// inclusive and Until exclusive.
+type AuditFilter struct {
+	ProjectID  *uuid.UUID
+	ActorID    *uuid.UUID
+	EntityType string
+	EntityID   *uuid.UUID
+	Since      *time.Time
+	Until      *time.Time
+	Limit      int
+	Offset     int
+}
+
+type AuditEventRepository interface {
+	Create(ctx context.Context, event *AuditEvent) error
+	// List returns matching events, newest first.
+	List(ctx context.Context, filter AuditFilter) ([]*AuditEvent, error)
+}
@@ -20,10 +20,28 @@ type Column struct {
 	BoardID   uuid.UUID `json:"board_id"`
 	Name      string    `json:"name"`
 	Position  float64   `json:"position"`
+	Category  string    `json:"category"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// Column categories tell the flow analytics which stage of work a column
+// holds. Columns without a category don't count towards any stage.
+const (
+	ColumnCategoryNone       = ""
+	ColumnCategoryBacklog    = "backlog"
+	ColumnCategoryInProgress = "in_progress"
+	ColumnCategoryDone       = "done"
+)
+
+func ValidColumnCategory(c string) bool {
+	switch c {
+	case ColumnCategoryNone, ColumnCategoryBacklog, ColumnCategoryInProgress, ColumnCategoryDone:
+		return true
+	}
+	return false
+}
+
 type BoardRepository interface {
 	Create(ctx context.Context, board *Board) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Board, error)
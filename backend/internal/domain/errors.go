@@ -1,6 +1,9 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 var (
 	ErrNotFound           = errors.New("not found")
@@ -9,4 +12,30 @@ var (
 	ErrUnauthorized       = errors.New("unauthorized")
 	ErrForbidden          = errors.New("forbidden")
 	ErrValidation         = errors.New("validation error")
+	ErrEmailNotVerified   = errors.New("email not verified")
+	ErrAccountDeactivated = errors.New("account deactivated")
+	ErrTooManyRequests    = errors.New("too many requests")
+	ErrLoginLocked        = errors.New("too many failed login attempts")
 )
+
+// RateLimitError is returned when a caller has to wait before retrying. It
+// matches ErrTooManyRequests with errors.Is, and Err, if set, as well.
+type RateLimitError struct {
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return ErrTooManyRequests.Error()
+}
+
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrTooManyRequests
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FlowFilter narrows a board's flow report. Since and Until bound the
+// completion dates counted in the lead time, cycle time and throughput; they
+// don't apply to the work in progress, which is always the board's current
+// state.
+type FlowFilter struct {
+	Since      *time.Time
+	Until      *time.Time
+	LabelID    *uuid.UUID
+	AssigneeID *uuid.UUID
+}
+
+// FlowReport holds a board's flow metrics, computed from the column
+// transitions of its tasks and the categories of its columns.
+type FlowReport struct {
+	BoardID    uuid.UUID          `json:"board_id"`
+	Since      time.Time          `json:"since"`
+	Until      time.Time          `json:"until"`
+	LeadTime   DurationStats      `json:"lead_time"`
+	CycleTime  DurationStats      `json:"cycle_time"`
+	Throughput []WeeklyThroughput `json:"throughput"`
+	// Completed lists the tasks that reached a done column within the range,
+	// most recent first.
+	Completed []*TaskFlow `json:"completed"`
+	// InProgress is the aging work in progress: tasks currently in an in
+	// progress column, oldest first.
+	InProgress []*TaskFlow `json:"in_progress"`
+}
+
+// DurationStats summarizes a set of durations in hours.
+type DurationStats struct {
+	Count       int     `json:"count"`
+	MeanHours   float64 `json:"mean_hours"`
+	MedianHours float64 `json:"median_hours"`
+	P85Hours    float64 `json:"p85_hours"`
+}
+
+// WeeklyThroughput counts the tasks completed in the week starting on
+// WeekStart, a Monday at midnight UTC.
+type WeeklyThroughput struct {
+	WeekStart time.Time `json:"week_start"`
+	Completed int       `json:"completed"`
+}
+
+// TaskFlow is one task's path through the board. StartedAt is the first time
+// it entered an in progress column and DoneAt the time it last entered a done
+// column; either is nil if that never happened.
+type TaskFlow struct {
+	TaskID         uuid.UUID  `json:"task_id"`
+	Title          string     `json:"title"`
+	ColumnID       uuid.UUID  `json:"column_id"`
+	Category       string     `json:"category"`
+	CreatedAt      time.Time  `json:"created_at"`
+	StartedAt      *time.Time `json:"started_at"`
+	DoneAt         *time.Time `json:"done_at"`
+	LeadTimeHours  *float64   `json:"lead_time_hours,omitempty"`
+	CycleTimeHours *float64   `json:"cycle_time_hours,omitempty"`
+	AgeHours       *float64   `json:"age_hours,omitempty"`
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Invitation struct {
+	ID         uuid.UUID   `json:"id"`
+	ProjectID  uuid.UUID   `json:"project_id"`
+	Email      string      `json:"email"`
+	Role       ProjectRole `json:"role"`
+	TokenHash  string      `json:"-"`
+	InvitedBy  uuid.UUID   `json:"invited_by"`
+	ExpiresAt  time.Time   `json:"expires_at"`
+	AcceptedAt *time.Time  `json:"accepted_at"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+type InvitationRepository interface {
+	Create(ctx context.Context, inv *Invitation) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Invitation, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*Invitation, error)
+	ListPendingByProject(ctx context.Context, projectID uuid.UUID) ([]*Invitation, error)
+	MarkAccepted(ctx context.Context, id uuid.UUID, acceptedAt time.Time) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
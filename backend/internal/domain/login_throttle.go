@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// LoginThrottle counts recent failed logins for one key, such as an email
+// address or a client IP.
+type LoginThrottle struct {
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+}
+
+type LoginThrottleRepository interface {
+	Get(ctx context.Context, key string) (*LoginThrottle, error)
+	// RecordFailure adds a failure for key and returns the updated count. The
+	// count starts over if the previous failure was before windowStart.
+	RecordFailure(ctx context.Context, key string, at, windowStart time.Time) (*LoginThrottle, error)
+	Delete(ctx context.Context, key string) error
+	// DeleteStale removes keys whose last failure was before the given time.
+	DeleteStale(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Organization is the workspace projects belong to. Every user has a
+// personal one, created on demand, whose PersonalOwnerID is that user.
+type Organization struct {
+	ID              uuid.UUID  `json:"id"`
+	Name            string     `json:"name"`
+	PersonalOwnerID *uuid.UUID `json:"personal_owner_id,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+func (o *Organization) Personal() bool {
+	return o.PersonalOwnerID != nil
+}
+
+type OrgRole string
+
+const (
+	OrgRoleOwner  OrgRole = "owner"
+	OrgRoleAdmin  OrgRole = "admin"
+	OrgRoleMember OrgRole = "member"
+)
+
+var orgRoleRank = map[OrgRole]int{
+	OrgRoleMember: 1,
+	OrgRoleAdmin:  2,
+	OrgRoleOwner:  3,
+}
+
+func (r OrgRole) Valid() bool {
+	_, ok := orgRoleRank[r]
+	return ok
+}
+
+// AtLeast reports whether r grants every permission of min.
+func (r OrgRole) AtLeast(min OrgRole) bool {
+	return r.Valid() && orgRoleRank[r] >= orgRoleRank[min]
+}
+
+type OrganizationMember struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	Role           OrgRole   `json:"role"`
+	Email          string    `json:"email,omitempty"`
+	Name           string    `json:"name,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type OrganizationRepository interface {
+	Create(ctx context.Context, org *Organization) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Organization, error)
+	GetPersonal(ctx context.Context, userID uuid.UUID) (*Organization, error)
+	ListByMember(ctx context.Context, userID uuid.UUID) ([]*Organization, error)
+	Update(ctx context.Context, org *Organization) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type OrganizationMemberRepository interface {
+	Create(ctx context.Context, member *OrganizationMember) error
+	Get(ctx context.Context, orgID, userID uuid.UUID) (*OrganizationMember, error)
+	ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*OrganizationMember, error)
+	CountByRole(ctx context.Context, orgID uuid.UUID, role OrgRole) (int, error)
+	Update(ctx context.Context, member *OrganizationMember) error
+	// Delete removes the membership together with the user's memberships
+	// in the organization's projects.
+	Delete(ctx context.Context, orgID, userID uuid.UUID) error
+}
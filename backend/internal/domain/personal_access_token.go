@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TokenScope string
+
+const (
+	// TokenScopeRead allows only safe (GET) requests.
+	TokenScopeRead TokenScope = "read"
+	// TokenScopeWrite allows everything the owning user can do.
+	TokenScopeWrite TokenScope = "write"
+)
+
+func (s TokenScope) Valid() bool {
+	return s == TokenScopeRead || s == TokenScopeWrite
+}
+
+// PersonalAccessToken is a long-lived API credential for scripts and CI. Only
+// the hash of the secret is stored; Prefix identifies the token in listings.
+type PersonalAccessToken struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	TokenHash  string     `json:"-"`
+	Scope      TokenScope `json:"scope"`
+	ProjectID  *uuid.UUID `json:"project_id"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type PersonalAccessTokenRepository interface {
+	Create(ctx context.Context, token *PersonalAccessToken) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*PersonalAccessToken, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*PersonalAccessToken, error)
+	UpdateLastUsed(ctx context.Context, id uuid.UUID, lastUsedAt time.Time) error
+	Delete(ctx context.Context, id, userID uuid.UUID) error
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectRole string
+
+const (
+	ProjectRoleOwner  ProjectRole = "owner"
+	ProjectRoleAdmin  ProjectRole = "admin"
+	ProjectRoleMember ProjectRole = "member"
+	ProjectRoleViewer ProjectRole = "viewer"
+)
+
+var projectRoleRank = map[ProjectRole]int{
+	ProjectRoleViewer: 1,
+	ProjectRoleMember: 2,
+	ProjectRoleAdmin:  3,
+	ProjectRoleOwner:  4,
+}
+
+func (r ProjectRole) Valid() bool {
+	_, ok := projectRoleRank[r]
+	return ok
+}
+
+// AtLeast reports whether r grants every permission of min.
+func (r ProjectRole) AtLeast(min ProjectRole) bool {
+	return r.Valid() && projectRoleRank[r] >= projectRoleRank[min]
+}
+
+type ProjectMember struct {
+	ProjectID uuid.UUID   `json:"project_id"`
+	UserID    uuid.UUID   `json:"user_id"`
+	Role      ProjectRole `json:"role"`
+	Email     string      `json:"email,omitempty"`
+	Name      string      `json:"name,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+type ProjectMemberRepository interface {
+	Create(ctx context.Context, member *ProjectMember) error
+	Get(ctx context.Context, projectID, userID uuid.UUID) (*ProjectMember, error)
+	ListByProject(ctx context.Context, projectID uuid.UUID) ([]*ProjectMember, error)
+	CountByRole(ctx context.Context, projectID uuid.UUID, role ProjectRole) (int, error)
+	Update(ctx context.Context, member *ProjectMember) error
+	Delete(ctx context.Context, projectID, userID uuid.UUID) error
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SecurityEvent records something suspicious that happened to an account,
+// along with the client that triggered it.
+type SecurityEvent struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Type      string
+	UserAgent string
+	IP        string
+	CreatedAt time.Time
+}
+
+const (
+	// SecurityEventRefreshTokenReuse means an already rotated refresh token
+	// was presented, so the token may have been stolen. The session is revoked.
+	SecurityEventRefreshTokenReuse = "refresh_token_reuse"
+)
+
+type SecurityEventRepository interface {
+	Create(ctx context.Context, event *SecurityEvent) error
+}
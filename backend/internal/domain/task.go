@@ -24,6 +24,7 @@ type TaskFilter struct {
 	BoardID    *uuid.UUID
 	Priority   *string
 	AssigneeID *uuid.UUID
+	LabelID    *uuid.UUID
 }
 
 type TaskRepository interface {
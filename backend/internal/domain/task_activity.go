@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Task timeline entry types.
+const (
+	TaskActivityChange  = "change"
+	TaskActivityComment = "comment"
+)
+
+// TaskActivity is one entry in a task's timeline: a change to the task,
+// taken from the audit log, or a comment on it.
+type TaskActivity struct {
+	Type      string    `json:"type"`
+	ActorID   uuid.UUID `json:"actor_id"`
+	CreatedAt time.Time `json:"created_at"`
+	// Action and Changes are set for changes. A create has no Changes.
+	Action  string        `json:"action,omitempty"`
+	Changes []FieldChange `json:"changes,omitempty"`
+	Comment *Comment      `json:"comment,omitempty"`
+}
+
+// FieldChange is a task field's JSON value before and after a change. Old is
+// null for a label added to the task and New is null for one removed.
+type FieldChange struct {
+	Field string          `json:"field"`
+	Old   json.RawMessage `json:"old"`
+	New   json.RawMessage `json:"new"`
+}
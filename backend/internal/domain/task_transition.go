@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskTransition records a task entering a column. FromColumnID is nil for
+// the column the task was created in.
+type TaskTransition struct {
+	ID           uuid.UUID
+	TaskID       uuid.UUID
+	FromColumnID *uuid.UUID
+	ToColumnID   uuid.UUID
+	ActorID      uuid.UUID
+	CreatedAt    time.Time
+}
+
+type TaskTransitionRepository interface {
+	Create(ctx context.Context, transition *TaskTransition) error
+	// ListByBoard returns the transitions of the tasks currently on the
+	// board, oldest first.
+	ListByBoard(ctx context.Context, boardID uuid.UUID) ([]*TaskTransition, error)
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TOTPEnrollment is a user's authenticator app secret. It only protects
+// logins once ConfirmedAt is set, i.e. after the user has proven the app
+// produces valid codes.
+type TOTPEnrollment struct {
+	UserID      uuid.UUID
+	Secret      string
+	ConfirmedAt *time.Time
+	// LastUsedStep is the time step of the last accepted code, so a code
+	// can't be replayed within its validity window.
+	LastUsedStep int64
+	CreatedAt    time.Time
+}
+
+func (e *TOTPEnrollment) Enabled() bool {
+	return e.ConfirmedAt != nil
+}
+
+// TwoFactorChallenge is the server-side record of a login challenge. It lets
+// a challenge be exchanged only once and for a limited number of attempts.
+type TwoFactorChallenge struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Attempts   int
+	ExpiresAt  time.Time
+	ConsumedAt *time.Time
+	CreatedAt  time.Time
+}
+
+type TwoFactorRepository interface {
+	// SaveTOTP creates or replaces the user's unconfirmed enrollment.
+	SaveTOTP(ctx context.Context, enrollment *TOTPEnrollment) error
+	GetTOTP(ctx context.Context, userID uuid.UUID) (*TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, userID uuid.UUID, confirmedAt time.Time) error
+	// UseTOTPStep records step as used; it returns ErrConflict if step is not
+	// newer than the last used one.
+	UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error
+	// Delete removes the enrollment and all recovery codes.
+	Delete(ctx context.Context, userID uuid.UUID) error
+
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string, createdAt time.Time) error
+	// UseRecoveryCode consumes an unused code; it returns ErrNotFound if
+	// there is none with that hash.
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, usedAt time.Time) error
+	CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+
+	CreateChallenge(ctx context.Context, challenge *TwoFactorChallenge) error
+	// UseChallengeAttempt counts one code attempt against the challenge. It
+	// returns ErrNotFound if the challenge doesn't exist, has expired, was
+	// consumed or has already had maxAttempts attempts.
+	UseChallengeAttempt(ctx context.Context, id uuid.UUID, maxAttempts int, at time.Time) error
+	// ConsumeChallenge marks the challenge used up; it returns ErrNotFound if
+	// it already was.
+	ConsumeChallenge(ctx context.Context, id uuid.UUID, at time.Time) error
+	// DeleteExpiredChallenges removes challenges that expired before the
+	// given time.
+	DeleteExpiredChallenges(ctx context.Context, before time.Time) (int64, error)
+}
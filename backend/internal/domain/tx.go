@@ -0,0 +1,10 @@
+package domain
+
+import "context"
+
+// TxManager runs a unit of work. Repository calls made with the context
+// passed to fn share one transaction, which is committed when fn returns nil
+// and rolled back otherwise. Nested calls join the outer transaction.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -8,33 +8,139 @@ import (
 )
 
 type User struct {
-	ID           uuid.UUID `json:"id"`
-	Email        string    `json:"email"`
-	Name         string    `json:"name"`
-	PasswordHash string    `json:"-"`
-	Role         string    `json:"role"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID              uuid.UUID  `json:"id"`
+	Email           string     `json:"email"`
+	Name            string     `json:"name"`
+	PasswordHash    string     `json:"-"`
+	Role            string     `json:"role"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	DeactivatedAt   *time.Time `json:"deactivated_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
+// Global user roles. Project access is governed separately by ProjectRole.
+const (
+	UserRoleMember = "member"
+	UserRoleAdmin  = "admin"
+)
+
+func (u *User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// Active reports whether the user may sign in. Deactivated accounts keep
+// their data but can't log in, refresh or use tokens.
+func (u *User) Active() bool {
+	return u.DeactivatedAt == nil
+}
+
+// ValidUserRole reports whether role is a known global role.
+func ValidUserRole(role string) bool {
+	return role == UserRoleMember || role == UserRoleAdmin
+}
+
+// UserFilter selects users for the admin user list. Query matches email or
+// name, case-insensitively.
+type UserFilter struct {
+	Query  string
+	Limit  int
+	Offset int
+}
+
+// SystemStats are instance-wide counts for administrators.
+type SystemStats struct {
+	Users            int `json:"users"`
+	DeactivatedUsers int `json:"deactivated_users"`
+	Admins           int `json:"admins"`
+	Projects         int `json:"projects"`
+	Boards           int `json:"boards"`
+	Tasks            int `json:"tasks"`
+}
+
+// RefreshToken is one token in a login session. Every refresh rotates the
+// token: the old row is marked rotated and a new one joins the same family.
+// FamilyID identifies the session, and CreatedAt is carried over from the
+// first token so it records when the session began.
 type RefreshToken struct {
+	ID         uuid.UUID
+	FamilyID   uuid.UUID
+	UserID     uuid.UUID
+	TokenHash  string
+	UserAgent  string
+	IP         string
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	RotatedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// Rotated reports whether the token has already been exchanged for a new one.
+func (t *RefreshToken) Rotated() bool {
+	return t.RotatedAt != nil
+}
+
+// UserToken is a single-use, expiring token emailed to a user, such as a
+// password reset link. Only the hash of the token is stored.
+type UserToken struct {
 	ID        uuid.UUID
 	UserID    uuid.UUID
+	Purpose   string
 	TokenHash string
 	ExpiresAt time.Time
+	UsedAt    *time.Time
 	CreatedAt time.Time
 }
 
+const (
+	TokenPurposePasswordReset     = "password_reset"
+	TokenPurposeEmailVerification = "email_verification"
+)
+
 type UserRepository interface {
 	Create(ctx context.Context, user *User) error
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*User, error)
 	Update(ctx context.Context, user *User) error
+	UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string, updatedAt time.Time) error
+	MarkEmailVerified(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error
+	// List returns users matching the filter, oldest first.
+	List(ctx context.Context, filter UserFilter) ([]*User, error)
+	UpdateRole(ctx context.Context, id uuid.UUID, role string, updatedAt time.Time) error
+	// SetDeactivated deactivates the user, or reactivates it when
+	// deactivatedAt is nil.
+	SetDeactivated(ctx context.Context, id uuid.UUID, deactivatedAt *time.Time, updatedAt time.Time) error
+	Stats(ctx context.Context) (*SystemStats, error)
 }
 
 type RefreshTokenRepository interface {
 	Create(ctx context.Context, token *RefreshToken) error
+	// GetByTokenHash also returns rotated tokens so that reuse can be detected.
 	GetByTokenHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	// ListByUser returns the current token of each of the user's unexpired
+	// sessions, most recently used first.
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*RefreshToken, error)
+	// Rotate marks oldID rotated and stores next in the same family. It returns
+	// ErrNotFound if oldID has already been rotated.
+	Rotate(ctx context.Context, oldID uuid.UUID, rotatedAt time.Time, next *RefreshToken) error
+	// DeleteFamily removes every token of one of the user's sessions.
+	DeleteFamily(ctx context.Context, userID, familyID uuid.UUID) error
 	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+	// DeleteOthers removes every session of the user except keepFamilyID.
+	DeleteOthers(ctx context.Context, userID, keepFamilyID uuid.UUID) error
+	// DeleteByTokenHash removes the session the token belongs to.
 	DeleteByTokenHash(ctx context.Context, tokenHash string) error
+	// DeleteExpired removes tokens that expired before the given time and
+	// returns how many were removed.
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+type UserTokenRepository interface {
+	Create(ctx context.Context, token *UserToken) error
+	GetByTokenHash(ctx context.Context, purpose, tokenHash string) (*UserToken, error)
+	// GetLatest returns the most recently issued token for the purpose.
+	GetLatest(ctx context.Context, userID uuid.UUID, purpose string) (*UserToken, error)
+	// MarkUsed consumes the token; it returns ErrNotFound if it was already used.
+	MarkUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+	DeleteByUserID(ctx context.Context, userID uuid.UUID, purpose string) error
 }
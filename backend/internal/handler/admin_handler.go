@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/middleware"
+	"github.com/letyshub/project-management/internal/service"
+)
+
+type AdminHandler struct {
+	adminService *service.AdminService
+}
+
+func NewAdminHandler(adminService *service.AdminService) *AdminHandler {
+	return &AdminHandler{adminService: adminService}
+}
+
+// ListUsers supports ?q= to search by email or name, and ?limit= / ?offset=
+// for paging.
+func (h *AdminHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	filter := domain.UserFilter{Query: r.URL.Query().Get("q")}
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil {
+		filter.Limit = v
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil {
+		filter.Offset = v
+	}
+
+	users, err := h.adminService.ListUsers(r.Context(), filter)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if users == nil {
+		users = []*domain.User{}
+	}
+	writeData(w, http.StatusOK, users)
+}
+
+func (h *AdminHandler) Deactivate(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid user ID"}},
+		})
+		return
+	}
+
+	actorID := middleware.GetUserID(r.Context())
+	if err := h.adminService.Deactivate(r.Context(), actorID, userID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeData(w, http.StatusOK, map[string]string{"message": "deactivated"})
+}
+
+func (h *AdminHandler) Reactivate(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid user ID"}},
+		})
+		return
+	}
+
+	actorID := middleware.GetUserID(r.Context())
+	if err := h.adminService.Reactivate(r.Context(), actorID, userID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeData(w, http.StatusOK, map[string]string{"message": "reactivated"})
+}
+
+func (h *AdminHandler) SetRole(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid user ID"}},
+		})
+		return
+	}
+
+	var input service.SetRoleInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_BODY", Message: "invalid request body"}},
+		})
+		return
+	}
+
+	actorID := middleware.GetUserID(r.Context())
+	user, err := h.adminService.SetRole(r.Context(), actorID, userID, input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeData(w, http.StatusOK, user)
+}
+
+func (h *AdminHandler) ForcePasswordReset(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid user ID"}},
+		})
+		return
+	}
+
+	actorID := middleware.GetUserID(r.Context())
+	if err := h.adminService.ForcePasswordReset(r.Context(), actorID, userID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeData(w, http.StatusOK, map[string]string{"message": "password reset sent"})
+}
+
+func (h *AdminHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.adminService.Stats(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeData(w, http.StatusOK, stats)
+}
@@ -4,6 +4,10 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/middleware"
 	"github.com/letyshub/project-management/internal/service"
 )
 
@@ -26,7 +30,7 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.authService.Register(r.Context(), input)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -42,16 +46,24 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, tokens, err := h.authService.Login(r.Context(), input)
+	result, err := h.authService.Login(r.Context(), input)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
+		return
+	}
+
+	if result.ChallengeToken != "" {
+		writeData(w, http.StatusOK, map[string]interface{}{
+			"two_factor_required": true,
+			"challenge_token":     result.ChallengeToken,
+		})
 		return
 	}
 
 	writeData(w, http.StatusOK, map[string]interface{}{
-		"user":          user,
-		"access_token":  tokens.AccessToken,
-		"refresh_token": tokens.RefreshToken,
+		"user":          result.User,
+		"access_token":  result.Tokens.AccessToken,
+		"refresh_token": result.Tokens.RefreshToken,
 	})
 }
 
@@ -68,7 +80,7 @@ func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
 
 	user, tokens, err := h.authService.RefreshToken(r.Context(), body.RefreshToken)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -91,9 +103,27 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.authService.Logout(r.Context(), body.RefreshToken); err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
 	writeData(w, http.StatusOK, map[string]string{"message": "logged out"})
 }
+
+// Unlock clears a user's failed-login lockout. Admin only.
+func (h *AuthHandler) Unlock(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid user ID"}},
+		})
+		return
+	}
+
+	actorID := middleware.GetUserID(r.Context())
+	if err := h.authService.UnlockLogin(r.Context(), actorID, userID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeData(w, http.StatusOK, map[string]string{"message": "unlocked"})
+}
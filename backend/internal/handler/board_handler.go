@@ -41,7 +41,7 @@ func (h *BoardHandler) Create(w http.ResponseWriter, r *http.Request) {
 	ownerID := middleware.GetUserID(r.Context())
 	board, err := h.boardService.Create(r.Context(), projectID, ownerID, input)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -57,9 +57,10 @@ func (h *BoardHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	boards, err := h.boardService.ListByProject(r.Context(), projectID)
+	userID := middleware.GetUserID(r.Context())
+	boards, err := h.boardService.ListByProject(r.Context(), projectID, userID)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 	writeData(w, http.StatusOK, boards)
@@ -74,9 +75,10 @@ func (h *BoardHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	board, err := h.boardService.GetByID(r.Context(), id)
+	userID := middleware.GetUserID(r.Context())
+	board, err := h.boardService.GetByID(r.Context(), id, userID)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -103,7 +105,7 @@ func (h *BoardHandler) Update(w http.ResponseWriter, r *http.Request) {
 	ownerID := middleware.GetUserID(r.Context())
 	board, err := h.boardService.Update(r.Context(), id, ownerID, input)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -121,7 +123,7 @@ func (h *BoardHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	ownerID := middleware.GetUserID(r.Context())
 	if err := h.boardService.Delete(r.Context(), id, ownerID); err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -150,7 +152,7 @@ func (h *BoardHandler) CreateColumn(w http.ResponseWriter, r *http.Request) {
 	ownerID := middleware.GetUserID(r.Context())
 	col, err := h.boardService.CreateColumn(r.Context(), boardID, ownerID, input)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -166,9 +168,10 @@ func (h *BoardHandler) ListColumns(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	columns, err := h.boardService.ListColumns(r.Context(), boardID)
+	userID := middleware.GetUserID(r.Context())
+	columns, err := h.boardService.ListColumns(r.Context(), boardID, userID)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 	writeData(w, http.StatusOK, columns)
@@ -194,7 +197,7 @@ func (h *BoardHandler) UpdateColumn(w http.ResponseWriter, r *http.Request) {
 	ownerID := middleware.GetUserID(r.Context())
 	col, err := h.boardService.UpdateColumn(r.Context(), colID, ownerID, input)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -212,7 +215,7 @@ func (h *BoardHandler) DeleteColumn(w http.ResponseWriter, r *http.Request) {
 
 	ownerID := middleware.GetUserID(r.Context())
 	if err := h.boardService.DeleteColumn(r.Context(), colID, ownerID); err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
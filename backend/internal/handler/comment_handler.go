@@ -40,7 +40,7 @@ func (h *CommentHandler) Create(w http.ResponseWriter, r *http.Request) {
 	authorID := middleware.GetUserID(r.Context())
 	comment, err := h.commentService.Create(r.Context(), taskID, authorID, input)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -56,9 +56,10 @@ func (h *CommentHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	comments, err := h.commentService.ListByTask(r.Context(), taskID)
+	userID := middleware.GetUserID(r.Context())
+	comments, err := h.commentService.ListByTask(r.Context(), taskID, userID)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 	if comments == nil {
@@ -89,7 +90,7 @@ func (h *CommentHandler) Update(w http.ResponseWriter, r *http.Request) {
 	authorID := middleware.GetUserID(r.Context())
 	comment, err := h.commentService.Update(r.Context(), commentID, authorID, body.Content)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -107,7 +108,7 @@ func (h *CommentHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	authorID := middleware.GetUserID(r.Context())
 	if err := h.commentService.Delete(r.Context(), commentID, authorID); err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/letyshub/project-management/internal/service"
+)
+
+type EmailVerificationHandler struct {
+	verificationService *service.EmailVerificationService
+}
+
+func NewEmailVerificationHandler(verificationService *service.EmailVerificationService) *EmailVerificationHandler {
+	return &EmailVerificationHandler{verificationService: verificationService}
+}
+
+func (h *EmailVerificationHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Token == "" {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_BODY", Message: "token is required"}},
+		})
+		return
+	}
+
+	if err := h.verificationService.Verify(r.Context(), body.Token); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusOK, map[string]string{"message": "email verified"})
+}
+
+func (h *EmailVerificationHandler) Resend(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Email string `json:"email"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Email == "" {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_BODY", Message: "email is required"}},
+		})
+		return
+	}
+
+	if err := h.verificationService.Resend(r.Context(), body.Email); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusOK, map[string]string{
+		"message": "if that address needs verifying, a new link has been sent",
+	})
+}
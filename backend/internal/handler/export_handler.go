@@ -2,13 +2,14 @@ package handler
 
 import (
 	"encoding/csv"
-	"fmt"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
 	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/logging"
+	"github.com/letyshub/project-management/internal/middleware"
 	"github.com/letyshub/project-management/internal/service"
 )
 
@@ -30,15 +31,16 @@ func (h *ExportHandler) TasksCSV(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tasks, err := h.taskService.ListByBoard(r.Context(), boardID, domain.TaskFilter{})
+	userID := middleware.GetUserID(r.Context())
+	tasks, err := h.taskService.ListByBoard(r.Context(), boardID, userID, domain.TaskFilter{})
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
-	columns, err := h.boardService.ListColumns(r.Context(), boardID)
+	columns, err := h.boardService.ListColumns(r.Context(), boardID, userID)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -68,6 +70,6 @@ func (h *ExportHandler) TasksCSV(w http.ResponseWriter, r *http.Request) {
 	cw.Flush()
 
 	if err := cw.Error(); err != nil {
-		fmt.Printf("csv write error: %v\n", err)
+		logging.FromContext(r.Context()).Error("failed to write csv export", "error", err)
 	}
 }
@@ -1,19 +1,62 @@
 package handler
 
 import (
-	"encoding/json"
+	"context"
 	"net/http"
+	"time"
+
+	"github.com/letyshub/project-management/internal/logging"
 )
 
-type HealthHandler struct{}
+// readyTimeout bounds all of /readyz's checks together, so a hung database
+// fails the probe instead of outlasting it.
+const readyTimeout = 2 * time.Second
+
+// HealthCheck is a dependency /readyz reports on. Check returns details to
+// show whether or not it fails, such as connection pool stats.
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) (details any, err error)
+}
+
+type checkResult struct {
+	Status  string `json:"status"`
+	Details any    `json:"details,omitempty"`
+}
 
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+type HealthHandler struct {
+	checks []HealthCheck
 }
 
-func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string]string{
-		"status": "ok",
-	})
+func NewHealthHandler(checks ...HealthCheck) *HealthHandler {
+	return &HealthHandler{checks: checks}
+}
+
+// Live reports that the process is up. It checks no dependencies, so a
+// database outage makes the API unready rather than getting it restarted.
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Ready reports whether the API can serve traffic, with 503 unless every
+// check passes. Check errors are logged rather than returned, since they
+// can name hosts and users.
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readyTimeout)
+	defer cancel()
+
+	status, code := "ok", http.StatusOK
+	results := make(map[string]checkResult, len(h.checks))
+	for _, c := range h.checks {
+		details, err := c.Check(ctx)
+		result := checkResult{Status: "ok", Details: details}
+		if err != nil {
+			logging.FromContext(ctx).Warn("readiness check failed", "check", c.Name, "error", err)
+			result.Status = "error"
+			status, code = "unavailable", http.StatusServiceUnavailable
+		}
+		results[c.Name] = result
+	}
+
+	writeJSON(w, code, map[string]any{"status": status, "checks": results})
 }
@@ -1,22 +1,26 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 )
 
-func TestHealthHandler_Health(t *testing.T) {
-	h := NewHealthHandler()
+func TestHealthHandler_Live(t *testing.T) {
+	h := NewHealthHandler(HealthCheck{Name: "database", Check: func(context.Context) (any, error) {
+		return nil, errors.New("down")
+	}})
 
-	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req := httptest.NewRequest(http.MethodGet, "/livez", nil)
 	rec := httptest.NewRecorder()
 
-	h.Health(rec, req)
+	h.Live(rec, req)
 
 	if rec.Code != http.StatusOK {
-		t.Errorf("expected status 200, got %d", rec.Code)
+		t.Errorf("expected status 200 regardless of checks, got %d", rec.Code)
 	}
 
 	contentType := rec.Header().Get("Content-Type")
@@ -33,3 +37,64 @@ func TestHealthHandler_Health(t *testing.T) {
 		t.Errorf("expected status ok, got %s", body["status"])
 	}
 }
+
+func TestHealthHandler_Ready(t *testing.T) {
+	ok := HealthCheck{Name: "database", Check: func(context.Context) (any, error) {
+		return map[string]int{"idle_conns": 2}, nil
+	}}
+	failing := HealthCheck{Name: "migrations", Check: func(context.Context) (any, error) {
+		return map[string][]string{"pending": {"022_add_things"}}, errors.New("1 pending migration")
+	}}
+
+	tests := []struct {
+		name       string
+		checks     []HealthCheck
+		wantCode   int
+		wantStatus string
+		wantChecks map[string]string
+	}{
+		{"no checks", nil, http.StatusOK, "ok", map[string]string{}},
+		{"all pass", []HealthCheck{ok}, http.StatusOK, "ok", map[string]string{"database": "ok"}},
+		{"one fails", []HealthCheck{ok, failing}, http.StatusServiceUnavailable, "unavailable", map[string]string{"database": "ok", "migrations": "error"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHealthHandler(tt.checks...)
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			rec := httptest.NewRecorder()
+
+			h.Ready(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("expected status %d, got %d", tt.wantCode, rec.Code)
+			}
+
+			var body struct {
+				Status string `json:"status"`
+				Checks map[string]struct {
+					Status  string          `json:"status"`
+					Details json.RawMessage `json:"details"`
+				} `json:"checks"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode response body: %v", err)
+			}
+			if body.Status != tt.wantStatus {
+				t.Errorf("expected status %s, got %s", tt.wantStatus, body.Status)
+			}
+			if len(body.Checks) != len(tt.wantChecks) {
+				t.Fatalf("expected %d checks, got %v", len(tt.wantChecks), body.Checks)
+			}
+			for name, want := range tt.wantChecks {
+				got := body.Checks[name]
+				if got.Status != want {
+					t.Errorf("expected %s to be %s, got %s", name, want, got.Status)
+				}
+				if len(got.Details) == 0 {
+					t.Errorf("expected details for %s", name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/middleware"
+	"github.com/letyshub/project-management/internal/service"
+)
+
+type InvitationHandler struct {
+	invitationService *service.InvitationService
+}
+
+func NewInvitationHandler(invitationService *service.InvitationService) *InvitationHandler {
+	return &InvitationHandler{invitationService: invitationService}
+}
+
+func (h *InvitationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid project ID"}},
+		})
+		return
+	}
+
+	var input service.CreateInvitationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_BODY", Message: "invalid request body"}},
+		})
+		return
+	}
+
+	userID := middleware.GetUserID(r.Context())
+	inv, err := h.invitationService.Create(r.Context(), projectID, userID, input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusCreated, inv)
+}
+
+func (h *InvitationHandler) List(w http.ResponseWriter, r *http.Request) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid project ID"}},
+		})
+		return
+	}
+
+	userID := middleware.GetUserID(r.Context())
+	invitations, err := h.invitationService.ListPending(r.Context(), projectID, userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if invitations == nil {
+		invitations = []*domain.Invitation{}
+	}
+	writeData(w, http.StatusOK, invitations)
+}
+
+func (h *InvitationHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid project ID"}},
+		})
+		return
+	}
+	invitationID, err := uuid.Parse(chi.URLParam(r, "invitationID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid invitation ID"}},
+		})
+		return
+	}
+
+	userID := middleware.GetUserID(r.Context())
+	if err := h.invitationService.Revoke(r.Context(), projectID, invitationID, userID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusOK, map[string]string{"message": "deleted"})
+}
+
+func (h *InvitationHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	preview, err := h.invitationService.Preview(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeData(w, http.StatusOK, preview)
+}
+
+func (h *InvitationHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	member, err := h.invitationService.Accept(r.Context(), chi.URLParam(r, "token"), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeData(w, http.StatusOK, member)
+}
+
+func (h *InvitationHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var input service.RegisterWithInvitationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_BODY", Message: "invalid request body"}},
+		})
+		return
+	}
+
+	user, member, err := h.invitationService.Register(r.Context(), chi.URLParam(r, "token"), input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusCreated, map[string]interface{}{
+		"user":       user,
+		"membership": member,
+	})
+}
@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/letyshub/project-management/internal/jwtkeys"
+)
+
+// JWKSHandler publishes the public keys that verify access tokens, so other
+// services can check tokens without sharing a secret.
+type JWKSHandler struct {
+	keys *jwtkeys.Set
+}
+
+func NewJWKSHandler(keys *jwtkeys.Set) *JWKSHandler {
+	return &JWKSHandler{keys: keys}
+}
+
+func (h *JWKSHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	writeJSON(w, http.StatusOK, h.keys.JWKS())
+}
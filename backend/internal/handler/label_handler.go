@@ -40,7 +40,7 @@ func (h *LabelHandler) Create(w http.ResponseWriter, r *http.Request) {
 	ownerID := middleware.GetUserID(r.Context())
 	label, err := h.labelService.Create(r.Context(), projectID, ownerID, input)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -56,9 +56,10 @@ func (h *LabelHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	labels, err := h.labelService.ListByProject(r.Context(), projectID)
+	userID := middleware.GetUserID(r.Context())
+	labels, err := h.labelService.ListByProject(r.Context(), projectID, userID)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 	if labels == nil {
@@ -78,7 +79,7 @@ func (h *LabelHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	ownerID := middleware.GetUserID(r.Context())
 	if err := h.labelService.Delete(r.Context(), labelID, ownerID); err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -104,8 +105,9 @@ func (h *LabelHandler) AddToTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.labelService.AddToTask(r.Context(), taskID, body.LabelID); err != nil {
-		writeError(w, err)
+	userID := middleware.GetUserID(r.Context())
+	if err := h.labelService.AddToTask(r.Context(), taskID, body.LabelID, userID); err != nil {
+		writeError(w, r, err)
 		return
 	}
 
@@ -128,8 +130,9 @@ func (h *LabelHandler) RemoveFromTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.labelService.RemoveFromTask(r.Context(), taskID, labelID); err != nil {
-		writeError(w, err)
+	userID := middleware.GetUserID(r.Context())
+	if err := h.labelService.RemoveFromTask(r.Context(), taskID, labelID, userID); err != nil {
+		writeError(w, r, err)
 		return
 	}
 
@@ -145,9 +148,10 @@ func (h *LabelHandler) ListByTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	labels, err := h.labelService.ListByTask(r.Context(), taskID)
+	userID := middleware.GetUserID(r.Context())
+	labels, err := h.labelService.ListByTask(r.Context(), taskID, userID)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 	if labels == nil {
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/middleware"
+	"github.com/letyshub/project-management/internal/service"
+)
+
+type OrganizationHandler struct {
+	orgService *service.OrganizationService
+}
+
+func NewOrganizationHandler(orgService *service.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{orgService: orgService}
+}
+
+func (h *OrganizationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input service.CreateOrganizationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_BODY", Message: "invalid request body"}},
+		})
+		return
+	}
+
+	userID := middleware.GetUserID(r.Context())
+	org, err := h.orgService.Create(r.Context(), userID, input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusCreated, org)
+}
+
+func (h *OrganizationHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	orgs, err := h.orgService.ListForUser(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if orgs == nil {
+		orgs = []*domain.Organization{}
+	}
+	writeData(w, http.StatusOK, orgs)
+}
+
+func (h *OrganizationHandler) Get(w http.ResponseWriter, r *http.Request) {
+	orgID, err := uuid.Parse(chi.URLParam(r, "orgID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid organization ID"}},
+		})
+		return
+	}
+
+	userID := middleware.GetUserID(r.Context())
+	org, err := h.orgService.Get(r.Context(), orgID, userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusOK, org)
+}
+
+func (h *OrganizationHandler) Update(w http.ResponseWriter, r *http.Request) {
+	orgID, err := uuid.Parse(chi.URLParam(r, "orgID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid organization ID"}},
+		})
+		return
+	}
+
+	var input service.UpdateOrganizationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_BODY", Message: "invalid request body"}},
+		})
+		return
+	}
+
+	userID := middleware.GetUserID(r.Context())
+	org, err := h.orgService.Update(r.Context(), orgID, userID, input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusOK, org)
+}
+
+func (h *OrganizationHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	orgID, err := uuid.Parse(chi.URLParam(r, "orgID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid organization ID"}},
+		})
+		return
+	}
+
+	userID := middleware.GetUserID(r.Context())
+	if err := h.orgService.Delete(r.Context(), orgID, userID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusOK, map[string]string{"message": "deleted"})
+}
+
+func (h *OrganizationHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	orgID, err := uuid.Parse(chi.URLParam(r, "orgID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid organization ID"}},
+		})
+		return
+	}
+
+	actorID := middleware.GetUserID(r.Context())
+	members, err := h.orgService.ListMembers(r.Context(), orgID, actorID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if members == nil {
+		members = []*domain.OrganizationMember{}
+	}
+	writeData(w, http.StatusOK, members)
+}
+
+func (h *OrganizationHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	orgID, err := uuid.Parse(chi.URLParam(r, "orgID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid organization ID"}},
+		})
+		return
+	}
+
+	var input service.AddOrganizationMemberInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_BODY", Message: "invalid request body"}},
+		})
+		return
+	}
+
+	actorID := middleware.GetUserID(r.Context())
+	member, err := h.orgService.AddMember(r.Context(), orgID, actorID, input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusCreated, member)
+}
+
+func (h *OrganizationHandler) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	orgID, err := uuid.Parse(chi.URLParam(r, "orgID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid organization ID"}},
+		})
+		return
+	}
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid user ID"}},
+		})
+		return
+	}
+
+	var input service.UpdateOrganizationMemberInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_BODY", Message: "invalid request body"}},
+		})
+		return
+	}
+
+	actorID := middleware.GetUserID(r.Context())
+	member, err := h.orgService.UpdateMemberRole(r.Context(), orgID, actorID, userID, input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusOK, member)
+}
+
+func (h *OrganizationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	orgID, err := uuid.Parse(chi.URLParam(r, "orgID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid organization ID"}},
+		})
+		return
+	}
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid user ID"}},
+		})
+		return
+	}
+
+	actorID := middleware.GetUserID(r.Context())
+	if err := h.orgService.RemoveMember(r.Context(), orgID, actorID, userID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusOK, map[string]string{"message": "deleted"})
+}
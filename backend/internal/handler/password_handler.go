@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/letyshub/project-management/internal/middleware"
+	"github.com/letyshub/project-management/internal/service"
+)
+
+type PasswordHandler struct {
+	passwordService *service.PasswordService
+}
+
+func NewPasswordHandler(passwordService *service.PasswordService) *PasswordHandler {
+	return &PasswordHandler{passwordService: passwordService}
+}
+
+func (h *PasswordHandler) Change(w http.ResponseWriter, r *http.Request) {
+	var input service.ChangePasswordInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_BODY", Message: "invalid request body"}},
+		})
+		return
+	}
+
+	userID := middleware.GetUserID(r.Context())
+	sessionID := middleware.GetSessionID(r.Context())
+	if err := h.passwordService.Change(r.Context(), userID, sessionID, input); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusOK, map[string]string{"message": "password changed"})
+}
+
+func (h *PasswordHandler) Forgot(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Email string `json:"email"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Email == "" {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_BODY", Message: "email is required"}},
+		})
+		return
+	}
+
+	if err := h.passwordService.Forgot(r.Context(), body.Email); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusOK, map[string]string{
+		"message": "if an account exists for that email, a reset link has been sent",
+	})
+}
+
+func (h *PasswordHandler) Reset(w http.ResponseWriter, r *http.Request) {
+	var input service.ResetPasswordInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil || input.Token == "" {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_BODY", Message: "token and new_password are required"}},
+		})
+		return
+	}
+
+	if err := h.passwordService.Reset(r.Context(), input); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusOK, map[string]string{"message": "password reset"})
+}
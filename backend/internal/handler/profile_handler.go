@@ -21,7 +21,7 @@ func (h *ProfileHandler) GetMe(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r.Context())
 	user, err := h.userRepo.GetByID(r.Context(), userID)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 	writeData(w, http.StatusOK, user)
@@ -42,7 +42,7 @@ func (h *ProfileHandler) UpdateMe(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.userRepo.GetByID(r.Context(), userID)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -52,7 +52,7 @@ func (h *ProfileHandler) UpdateMe(w http.ResponseWriter, r *http.Request) {
 	user.UpdatedAt = time.Now()
 
 	if err := h.userRepo.Update(r.Context(), user); err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
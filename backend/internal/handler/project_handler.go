@@ -32,7 +32,7 @@ func (h *ProjectHandler) Create(w http.ResponseWriter, r *http.Request) {
 	ownerID := middleware.GetUserID(r.Context())
 	project, err := h.projectService.Create(r.Context(), ownerID, input)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -40,10 +40,10 @@ func (h *ProjectHandler) Create(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *ProjectHandler) List(w http.ResponseWriter, r *http.Request) {
-	ownerID := middleware.GetUserID(r.Context())
-	projects, err := h.projectService.ListByOwner(r.Context(), ownerID)
+	userID := middleware.GetUserID(r.Context())
+	projects, err := h.projectService.ListForUser(r.Context(), userID)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 	if projects == nil {
@@ -61,9 +61,10 @@ func (h *ProjectHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	project, err := h.projectService.GetByID(r.Context(), id)
+	userID := middleware.GetUserID(r.Context())
+	project, err := h.projectService.GetByID(r.Context(), id, userID)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -90,7 +91,7 @@ func (h *ProjectHandler) Update(w http.ResponseWriter, r *http.Request) {
 	ownerID := middleware.GetUserID(r.Context())
 	project, err := h.projectService.Update(r.Context(), id, ownerID, input)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -108,7 +109,7 @@ func (h *ProjectHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	ownerID := middleware.GetUserID(r.Context())
 	if err := h.projectService.Delete(r.Context(), id, ownerID); err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
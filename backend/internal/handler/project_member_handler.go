@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/middleware"
+	"github.com/letyshub/project-management/internal/service"
+)
+
+type ProjectMemberHandler struct {
+	memberService *service.ProjectMemberService
+}
+
+func NewProjectMemberHandler(memberService *service.ProjectMemberService) *ProjectMemberHandler {
+	return &ProjectMemberHandler{memberService: memberService}
+}
+
+func (h *ProjectMemberHandler) Add(w http.ResponseWriter, r *http.Request) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid project ID"}},
+		})
+		return
+	}
+
+	var input service.AddMemberInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_BODY", Message: "invalid request body"}},
+		})
+		return
+	}
+
+	actorID := middleware.GetUserID(r.Context())
+	member, err := h.memberService.Add(r.Context(), projectID, actorID, input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusCreated, member)
+}
+
+func (h *ProjectMemberHandler) List(w http.ResponseWriter, r *http.Request) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid project ID"}},
+		})
+		return
+	}
+
+	actorID := middleware.GetUserID(r.Context())
+	members, err := h.memberService.List(r.Context(), projectID, actorID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if members == nil {
+		members = []*domain.ProjectMember{}
+	}
+	writeData(w, http.StatusOK, members)
+}
+
+func (h *ProjectMemberHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid project ID"}},
+		})
+		return
+	}
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid user ID"}},
+		})
+		return
+	}
+
+	var input service.UpdateMemberInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_BODY", Message: "invalid request body"}},
+		})
+		return
+	}
+
+	actorID := middleware.GetUserID(r.Context())
+	member, err := h.memberService.UpdateRole(r.Context(), projectID, actorID, userID, input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusOK, member)
+}
+
+func (h *ProjectMemberHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	projectID, err := uuid.Parse(chi.URLParam(r, "projectID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid project ID"}},
+		})
+		return
+	}
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid user ID"}},
+		})
+		return
+	}
+
+	actorID := middleware.GetUserID(r.Context())
+	if err := h.memberService.Remove(r.Context(), projectID, actorID, userID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusOK, map[string]string{"message": "deleted"})
+}
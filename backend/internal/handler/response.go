@@ -3,9 +3,12 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/logging"
 )
 
 type Response struct {
@@ -29,7 +32,10 @@ func writeData(w http.ResponseWriter, status int, data interface{}) {
 	writeJSON(w, status, Response{Data: data})
 }
 
-func writeError(w http.ResponseWriter, err error) {
+// writeError maps err to a status and error code. Errors without a mapping
+// become a 500, logged with the request since the client only sees a
+// generic message.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
 	switch {
 	case errors.Is(err, domain.ErrValidation):
 		writeJSON(w, http.StatusBadRequest, Response{
@@ -51,13 +57,41 @@ func writeError(w http.ResponseWriter, err error) {
 		writeJSON(w, http.StatusUnauthorized, Response{
 			Errors: []APIError{{Code: "UNAUTHORIZED", Message: "unauthorized"}},
 		})
+	case errors.Is(err, domain.ErrAccountDeactivated):
+		writeJSON(w, http.StatusForbidden, Response{
+			Errors: []APIError{{Code: "ACCOUNT_DEACTIVATED", Message: "this account has been deactivated"}},
+		})
+	case errors.Is(err, domain.ErrEmailNotVerified):
+		writeJSON(w, http.StatusForbidden, Response{
+			Errors: []APIError{{Code: "EMAIL_NOT_VERIFIED", Message: "verify your email address first"}},
+		})
+	case errors.Is(err, domain.ErrLoginLocked):
+		setRetryAfter(w, err)
+		writeJSON(w, http.StatusTooManyRequests, Response{
+			Errors: []APIError{{Code: "LOGIN_LOCKED", Message: "too many failed login attempts, try again later"}},
+		})
+	case errors.Is(err, domain.ErrTooManyRequests):
+		setRetryAfter(w, err)
+		writeJSON(w, http.StatusTooManyRequests, Response{
+			Errors: []APIError{{Code: "TOO_MANY_REQUESTS", Message: "too many requests, try again later"}},
+		})
 	case errors.Is(err, domain.ErrForbidden):
 		writeJSON(w, http.StatusForbidden, Response{
 			Errors: []APIError{{Code: "FORBIDDEN", Message: "forbidden"}},
 		})
 	default:
+		logging.FromContext(r.Context()).Error("request failed", "error", err)
 		writeJSON(w, http.StatusInternalServerError, Response{
 			Errors: []APIError{{Code: "INTERNAL_ERROR", Message: "internal server error"}},
 		})
 	}
 }
+
+// setRetryAfter sets the Retry-After header, in whole seconds, when err
+// carries a RateLimitError.
+func setRetryAfter(w http.ResponseWriter, err error) {
+	var rl *domain.RateLimitError
+	if errors.As(err, &rl) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(rl.RetryAfter.Seconds()))))
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/letyshub/project-management/internal/domain"
 )
@@ -53,6 +54,18 @@ func TestWriteError(t *testing.T) {
 			expectedStatus: http.StatusForbidden,
 			expectedCode:   "FORBIDDEN",
 		},
+		{
+			name:           "email not verified error",
+			err:            domain.ErrEmailNotVerified,
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "EMAIL_NOT_VERIFIED",
+		},
+		{
+			name:           "too many requests error",
+			err:            domain.ErrTooManyRequests,
+			expectedStatus: http.StatusTooManyRequests,
+			expectedCode:   "TOO_MANY_REQUESTS",
+		},
 		{
 			name:           "unknown error",
 			err:            fmt.Errorf("something went wrong"),
@@ -64,7 +77,7 @@ func TestWriteError(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			rec := httptest.NewRecorder()
-			writeError(rec, tt.err)
+			writeError(rec, httptest.NewRequest(http.MethodGet, "/", nil), tt.err)
 
 			if rec.Code != tt.expectedStatus {
 				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
@@ -86,6 +99,38 @@ func TestWriteError(t *testing.T) {
 	}
 }
 
+func TestWriteError_RetryAfter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeError(rec, httptest.NewRequest(http.MethodGet, "/", nil), fmt.Errorf("resend: %w", &domain.RateLimitError{RetryAfter: 1500 * time.Millisecond}))
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("expected Retry-After 2, got %q", got)
+	}
+}
+
+func TestWriteError_LoginLocked(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeError(rec, httptest.NewRequest(http.MethodGet, "/", nil), &domain.RateLimitError{RetryAfter: 30 * time.Second, Err: domain.ErrLoginLocked})
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("expected Retry-After 30, got %q", got)
+	}
+
+	var resp Response
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].Code != "LOGIN_LOCKED" {
+		t.Errorf("expected LOGIN_LOCKED, got %+v", resp.Errors)
+	}
+}
+
 func TestWriteData(t *testing.T) {
 	rec := httptest.NewRecorder()
 	data := map[string]string{"key": "value"}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/logging"
+	"github.com/letyshub/project-management/internal/service"
+)
+
+const ssoFlowCookie = "oidc_flow"
+
+type SSOHandler struct {
+	ssoService *service.SSOService
+	appURL     string
+	// secure marks the flow cookie Secure; set when the callback is served
+	// over HTTPS.
+	secure bool
+}
+
+func NewSSOHandler(ssoService *service.SSOService, appURL string, secure bool) *SSOHandler {
+	return &SSOHandler{
+		ssoService: ssoService,
+		appURL:     strings.TrimRight(appURL, "/"),
+		secure:     secure,
+	}
+}
+
+// Login redirects the browser to the identity provider.
+func (h *SSOHandler) Login(w http.ResponseWriter, r *http.Request) {
+	authURL, flow, err := h.ssoService.Begin()
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoFlowCookie,
+		Value:    flow,
+		Path:     "/",
+		MaxAge:   600,
+		HttpOnly: true,
+		Secure:   h.secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// Callback completes the login and hands the tokens, or the two-factor
+// challenge, to the frontend in the URL fragment, which browsers never send
+// to servers.
+func (h *SSOHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoFlowCookie,
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   h.secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	q := r.URL.Query()
+	if providerErr := q.Get("error"); providerErr != "" {
+		logging.FromContext(r.Context()).Warn("sso login rejected by provider", "error", providerErr, "description", q.Get("error_description"))
+		h.redirectError(w, r, "sso_failed")
+		return
+	}
+
+	cookie, err := r.Cookie(ssoFlowCookie)
+	if err != nil {
+		h.redirectError(w, r, "sso_failed")
+		return
+	}
+
+	result, err := h.ssoService.Complete(r.Context(), cookie.Value, q.Get("state"), q.Get("code"))
+	if err != nil {
+		code := "sso_failed"
+		if errors.Is(err, domain.ErrForbidden) {
+			code = "sso_forbidden"
+		} else if errors.Is(err, domain.ErrEmailNotVerified) {
+			code = "sso_email_not_verified"
+		} else if !errors.Is(err, domain.ErrUnauthorized) {
+			logging.FromContext(r.Context()).Error("sso login failed", "error", err)
+		}
+		h.redirectError(w, r, code)
+		return
+	}
+
+	fragment := url.Values{}
+	if result.ChallengeToken != "" {
+		fragment.Set("two_factor_required", "true")
+		fragment.Set("challenge_token", result.ChallengeToken)
+	} else {
+		fragment.Set("access_token", result.Tokens.AccessToken)
+		fragment.Set("refresh_token", result.Tokens.RefreshToken)
+	}
+	http.Redirect(w, r, h.appURL+"/auth/sso#"+fragment.Encode(), http.StatusFound)
+}
+
+func (h *SSOHandler) redirectError(w http.ResponseWriter, r *http.Request, code string) {
+	http.Redirect(w, r, h.appURL+"/login?error="+url.QueryEscape(code), http.StatusFound)
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/middleware"
+	"github.com/letyshub/project-management/internal/service"
+)
+
+type TaskActivityHandler struct {
+	activityService *service.TaskActivityService
+}
+
+func NewTaskActivityHandler(activityService *service.TaskActivityService) *TaskActivityHandler {
+	return &TaskActivityHandler{activityService: activityService}
+}
+
+func (h *TaskActivityHandler) Timeline(w http.ResponseWriter, r *http.Request) {
+	taskID, err := uuid.Parse(chi.URLParam(r, "taskID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid task ID"}},
+		})
+		return
+	}
+
+	userID := middleware.GetUserID(r.Context())
+	timeline, err := h.activityService.Timeline(r.Context(), taskID, userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if timeline == nil {
+		timeline = []*domain.TaskActivity{}
+	}
+	writeData(w, http.StatusOK, timeline)
+}
@@ -40,7 +40,7 @@ func (h *TaskHandler) Create(w http.ResponseWriter, r *http.Request) {
 	ownerID := middleware.GetUserID(r.Context())
 	task, err := h.taskService.Create(r.Context(), columnID, ownerID, input)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -72,10 +72,17 @@ func (h *TaskHandler) ListByBoard(w http.ResponseWriter, r *http.Request) {
 			filter.ColumnID = &id
 		}
 	}
+	if v := r.URL.Query().Get("label_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err == nil {
+			filter.LabelID = &id
+		}
+	}
 
-	tasks, err := h.taskService.ListByBoard(r.Context(), boardID, filter)
+	userID := middleware.GetUserID(r.Context())
+	tasks, err := h.taskService.ListByBoard(r.Context(), boardID, userID, filter)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 	if tasks == nil {
@@ -93,9 +100,10 @@ func (h *TaskHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	task, err := h.taskService.GetByID(r.Context(), id)
+	userID := middleware.GetUserID(r.Context())
+	task, err := h.taskService.GetByID(r.Context(), id, userID)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -122,7 +130,7 @@ func (h *TaskHandler) Update(w http.ResponseWriter, r *http.Request) {
 	ownerID := middleware.GetUserID(r.Context())
 	task, err := h.taskService.Update(r.Context(), id, ownerID, input)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -149,7 +157,7 @@ func (h *TaskHandler) Move(w http.ResponseWriter, r *http.Request) {
 	ownerID := middleware.GetUserID(r.Context())
 	task, err := h.taskService.Move(r.Context(), id, ownerID, input)
 	if err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -167,7 +175,7 @@ func (h *TaskHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	ownerID := middleware.GetUserID(r.Context())
 	if err := h.taskService.Delete(r.Context(), id, ownerID); err != nil {
-		writeError(w, err)
+		writeError(w, r, err)
 		return
 	}
 
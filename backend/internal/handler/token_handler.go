@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/middleware"
+	"github.com/letyshub/project-management/internal/service"
+)
+
+type TokenHandler struct {
+	tokenService *service.TokenService
+}
+
+func NewTokenHandler(tokenService *service.TokenService) *TokenHandler {
+	return &TokenHandler{tokenService: tokenService}
+}
+
+func (h *TokenHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input service.CreateTokenInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_BODY", Message: "invalid request body"}},
+		})
+		return
+	}
+
+	userID := middleware.GetUserID(r.Context())
+	token, err := h.tokenService.Create(r.Context(), userID, input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusCreated, token)
+}
+
+func (h *TokenHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	tokens, err := h.tokenService.List(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if tokens == nil {
+		tokens = []*domain.PersonalAccessToken{}
+	}
+	writeData(w, http.StatusOK, tokens)
+}
+
+func (h *TokenHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	tokenID, err := uuid.Parse(chi.URLParam(r, "tokenID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid token ID"}},
+		})
+		return
+	}
+
+	userID := middleware.GetUserID(r.Context())
+	if err := h.tokenService.Revoke(r.Context(), userID, tokenID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusOK, map[string]string{"message": "deleted"})
+}
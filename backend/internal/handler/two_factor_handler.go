@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/middleware"
+	"github.com/letyshub/project-management/internal/service"
+)
+
+type TwoFactorHandler struct {
+	twoFactorService *service.TwoFactorService
+}
+
+func NewTwoFactorHandler(twoFactorService *service.TwoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{twoFactorService: twoFactorService}
+}
+
+type twoFactorCodeBody struct {
+	Code string `json:"code"`
+}
+
+func (h *TwoFactorHandler) Status(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	status, err := h.twoFactorService.Status(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeData(w, http.StatusOK, status)
+}
+
+func (h *TwoFactorHandler) Enroll(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	setup, err := h.twoFactorService.BeginEnrollment(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeData(w, http.StatusCreated, setup)
+}
+
+func (h *TwoFactorHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	body, ok := decodeCode(w, r)
+	if !ok {
+		return
+	}
+
+	userID := middleware.GetUserID(r.Context())
+	codes, err := h.twoFactorService.ConfirmEnrollment(r.Context(), userID, body.Code)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeData(w, http.StatusOK, map[string]interface{}{"recovery_codes": codes})
+}
+
+func (h *TwoFactorHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	body, ok := decodeCode(w, r)
+	if !ok {
+		return
+	}
+
+	userID := middleware.GetUserID(r.Context())
+	if err := h.twoFactorService.Disable(r.Context(), userID, body.Code); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeData(w, http.StatusOK, map[string]string{"message": "two-factor authentication disabled"})
+}
+
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	body, ok := decodeCode(w, r)
+	if !ok {
+		return
+	}
+
+	userID := middleware.GetUserID(r.Context())
+	codes, err := h.twoFactorService.RegenerateRecoveryCodes(r.Context(), userID, body.Code)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeData(w, http.StatusOK, map[string]interface{}{"recovery_codes": codes})
+}
+
+func (h *TwoFactorHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	var input service.CompleteLoginInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil || input.ChallengeToken == "" || input.Code == "" {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_BODY", Message: "challenge_token and code are required"}},
+		})
+		return
+	}
+
+	user, tokens, err := h.twoFactorService.CompleteLogin(r.Context(), input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeData(w, http.StatusOK, map[string]interface{}{
+		"user":          user,
+		"access_token":  tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+	})
+}
+
+func (h *TwoFactorHandler) Reset(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_ID", Message: "invalid user ID"}},
+		})
+		return
+	}
+
+	actorID := middleware.GetUserID(r.Context())
+	if err := h.twoFactorService.Reset(r.Context(), actorID, userID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeData(w, http.StatusOK, map[string]string{"message": "deleted"})
+}
+
+func decodeCode(w http.ResponseWriter, r *http.Request) (twoFactorCodeBody, bool) {
+	var body twoFactorCodeBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Code == "" {
+		writeJSON(w, http.StatusBadRequest, Response{
+			Errors: []APIError{{Code: "INVALID_BODY", Message: "code is required"}},
+		})
+		return body, false
+	}
+	return body, true
+}
@@ -0,0 +1,26 @@
+// Package jobs runs periodic background work inside the API process.
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Every calls fn once per interval until ctx is cancelled. Errors are logged
+// and the job keeps running. It blocks, so start it in its own goroutine.
+func Every(ctx context.Context, name string, interval time.Duration, fn func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := fn(ctx); err != nil {
+				slog.Error("background job failed", "job", name, "error", err)
+			}
+		}
+	}
+}
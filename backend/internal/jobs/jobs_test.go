@@ -0,0 +1,34 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEvery_RunsUntilCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	done := make(chan struct{})
+
+	go func() {
+		Every(ctx, "test", time.Millisecond, func(context.Context) error {
+			if calls.Add(1) == 3 {
+				cancel()
+			}
+			return errors.New("keeps going after errors")
+		})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("job did not stop after cancel")
+	}
+	if calls.Load() < 3 {
+		t.Errorf("expected at least 3 runs, got %d", calls.Load())
+	}
+}
@@ -0,0 +1,281 @@
+// Package jwtkeys holds the keys used to sign and verify the API's JWTs.
+//
+// A Set either signs with a shared HMAC secret (HS256), or with an RSA
+// (RS256) or Ed25519 (EdDSA) private key loaded from a PEM file. Asymmetric
+// tokens carry a kid header, and older public keys can stay in the set so
+// tokens they signed keep verifying while keys are rotated. The public keys
+// are published as a JWKS for other services.
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const minRSABits = 2048
+
+// Key is one asymmetric key. Private is nil for verification-only keys.
+type Key struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Public  crypto.PublicKey
+	Private crypto.Signer
+}
+
+type Set struct {
+	hmacSecret []byte
+	signing    *Key
+	keys       map[string]*Key
+	order      []string
+}
+
+// NewHMAC returns a set that signs and verifies with a shared secret.
+func NewHMAC(secret string) *Set {
+	return &Set{hmacSecret: []byte(secret)}
+}
+
+// NewDerivedHMAC returns an HMAC set whose secret is derived from secret for
+// one purpose. Tokens it signs don't verify against NewHMAC(secret) or any
+// set derived for another purpose, and its key is never in a JWKS.
+func NewDerivedHMAC(secret, purpose string) *Set {
+	return &Set{hmacSecret: DeriveKey(secret, purpose)}
+}
+
+// DeriveKey derives a key from secret for one purpose, for callers that need
+// raw key bytes rather than a signing set.
+func DeriveKey(secret, purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+// New returns a set that signs with signing and also verifies tokens signed
+// by any of the extra keys.
+func New(signing *Key, verification ...*Key) (*Set, error) {
+	if signing.Private == nil {
+		return nil, errors.New("signing key has no private key")
+	}
+	s := &Set{signing: signing, keys: map[string]*Key{}}
+	for _, k := range append([]*Key{signing}, verification...) {
+		if _, ok := s.keys[k.ID]; ok {
+			continue
+		}
+		s.keys[k.ID] = k
+		s.order = append(s.order, k.ID)
+	}
+	return s, nil
+}
+
+// Load reads the signing key and any verification keys from PEM files.
+// Verification files may hold either public or private keys.
+func Load(signingFile string, verificationFiles []string) (*Set, error) {
+	signing, err := LoadKey(signingFile)
+	if err != nil {
+		return nil, err
+	}
+	var verification []*Key
+	for _, path := range verificationFiles {
+		k, err := LoadKey(path)
+		if err != nil {
+			return nil, err
+		}
+		verification = append(verification, k)
+	}
+	return New(signing, verification...)
+}
+
+// LoadKey reads one RSA or Ed25519 key from a PEM file.
+func LoadKey(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading key: %w", err)
+	}
+	k, err := ParseKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return k, nil
+}
+
+// ParseKey parses a PEM encoded RSA or Ed25519 key, private or public. The
+// key ID is the RFC 7638 thumbprint of the public key, so it is stable
+// without any extra configuration.
+func ParseKey(data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	k := &Key{}
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		k.Private, k.Public = key, &key.PublicKey
+	case ed25519.PrivateKey:
+		k.Private, k.Public = key, key.Public()
+	case *rsa.PublicKey, ed25519.PublicKey:
+		k.Public = key
+	default:
+		return nil, fmt.Errorf("unsupported key type %T; use RSA or Ed25519", parsed)
+	}
+
+	switch pub := k.Public.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("RSA key must be at least %d bits", minRSABits)
+		}
+		k.Method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		k.Method = jwt.SigningMethodEdDSA
+	}
+	k.ID = thumbprint(k.Public)
+	return k, nil
+}
+
+// Sign signs claims with the current signing key.
+func (s *Set) Sign(claims jwt.Claims) (string, error) {
+	if s.signing == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.hmacSecret)
+	}
+	token := jwt.NewWithClaims(s.signing.Method, claims)
+	token.Header["kid"] = s.signing.ID
+	return token.SignedString(s.signing.Private)
+}
+
+// Parse verifies a token against the set and decodes it into claims.
+func (s *Set) Parse(tokenString string, claims jwt.Claims, opts ...jwt.ParserOption) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(tokenString, claims, s.keyFunc, append(opts, jwt.WithValidMethods(s.methods()))...)
+}
+
+func (s *Set) keyFunc(token *jwt.Token) (any, error) {
+	if s.signing == nil {
+		return s.hmacSecret, nil
+	}
+	kid, _ := token.Header["kid"].(string)
+	k, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if token.Method.Alg() != k.Method.Alg() {
+		return nil, fmt.Errorf("key %q does not sign %s", kid, token.Method.Alg())
+	}
+	return k.Public, nil
+}
+
+func (s *Set) methods() []string {
+	if s.signing == nil {
+		return []string{jwt.SigningMethodHS256.Alg()}
+	}
+	var methods []string
+	seen := map[string]bool{}
+	for _, k := range s.keys {
+		if alg := k.Method.Alg(); !seen[alg] {
+			seen[alg] = true
+			methods = append(methods, alg)
+		}
+	}
+	return methods
+}
+
+// JWK is the public half of a key in JSON Web Key form.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys of the set, signing key first. It is empty
+// for an HMAC set, whose secret can't be published.
+func (s *Set) JWKS() JWKS {
+	set := JWKS{Keys: []JWK{}}
+	for _, id := range s.order {
+		k := s.keys[id]
+		jwk := publicJWK(k.Public)
+		jwk.Kid = k.ID
+		jwk.Use = "sig"
+		jwk.Alg = k.Method.Alg()
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+func publicJWK(pub crypto.PublicKey) JWK {
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			N:   b64(key.N.Bytes()),
+			E:   b64(big.NewInt(int64(key.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		return JWK{Kty: "OKP", Crv: "Ed25519", X: b64(key)}
+	}
+	return JWK{}
+}
+
+// thumbprint computes the RFC 7638 JWK thumbprint: the hash of the required
+// members in lexicographic order.
+func thumbprint(pub crypto.PublicKey) string {
+	jwk := publicJWK(pub)
+	var members any
+	switch jwk.Kty {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	default:
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X}
+	}
+	data, _ := json.Marshal(members)
+	sum := sha256.Sum256(data)
+	return b64(sum[:])
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,174 @@
+package jwtkeys
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func pemEncode(t *testing.T, typ string, der []byte) []byte {
+	t.Helper()
+	return pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der})
+}
+
+func newEd25519(t *testing.T) *Key {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, _ := x509.MarshalPKCS8PrivateKey(priv)
+	k, err := ParseKey(pemEncode(t, "PRIVATE KEY", der))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func newRSA(t *testing.T) *Key {
+	t.Helper()
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err := ParseKey(pemEncode(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(priv)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func TestParseKey(t *testing.T) {
+	ed := newEd25519(t)
+	if ed.Method != jwt.SigningMethodEdDSA || ed.Private == nil {
+		t.Errorf("unexpected ed25519 key %+v", ed)
+	}
+
+	rs := newRSA(t)
+	if rs.Method != jwt.SigningMethodRS256 || rs.Private == nil {
+		t.Errorf("unexpected rsa key %+v", rs)
+	}
+
+	der, _ := x509.MarshalPKIXPublicKey(rs.Public)
+	pub, err := ParseKey(pemEncode(t, "PUBLIC KEY", der))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub.Private != nil || pub.ID != rs.ID {
+		t.Errorf("expected public key with the same kid, got %+v", pub)
+	}
+
+	small, _ := rsa.GenerateKey(rand.Reader, 1024)
+	if _, err := ParseKey(pemEncode(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(small))); err == nil {
+		t.Error("expected short RSA key to be rejected")
+	}
+	if _, err := ParseKey([]byte("not a key")); err == nil {
+		t.Error("expected garbage to be rejected")
+	}
+}
+
+func TestSet_Rotation(t *testing.T) {
+	oldKey, newKey := newRSA(t), newEd25519(t)
+
+	before, err := New(oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldToken, err := before.Sign(jwt.RegisteredClaims{Subject: "ada"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// After rotation the new key signs and the old one only verifies.
+	oldPublic := &Key{ID: oldKey.ID, Method: oldKey.Method, Public: oldKey.Public}
+	after, err := New(newKey, oldPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newToken, err := after.Sign(jwt.RegisteredClaims{Subject: "grace"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, raw := range []string{oldToken, newToken} {
+		if _, err := after.Parse(raw, &jwt.RegisteredClaims{}); err != nil {
+			t.Errorf("expected token to verify after rotation: %v", err)
+		}
+	}
+	if _, err := before.Parse(newToken, &jwt.RegisteredClaims{}); err == nil {
+		t.Error("expected token from an unknown key to be rejected")
+	}
+
+	jwks := after.JWKS()
+	if len(jwks.Keys) != 2 || jwks.Keys[0].Kid != newKey.ID || jwks.Keys[0].Kty != "OKP" || jwks.Keys[1].Kty != "RSA" {
+		t.Errorf("unexpected jwks %+v", jwks)
+	}
+}
+
+func TestSet_RejectsOtherAlgorithms(t *testing.T) {
+	set, err := New(newEd25519(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hmac, err := NewHMAC("secret").Sign(jwt.RegisteredClaims{Subject: "ada"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := set.Parse(hmac, &jwt.RegisteredClaims{}); err == nil {
+		t.Error("expected HS256 token to be rejected by an asymmetric set")
+	}
+	if len(NewHMAC("secret").JWKS().Keys) != 0 {
+		t.Error("expected HMAC set to publish no keys")
+	}
+}
+
+func TestNewDerivedHMAC(t *testing.T) {
+	token, err := NewDerivedHMAC("secret", "challenge").Sign(jwt.RegisteredClaims{Subject: "ada"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewDerivedHMAC("secret", "challenge").Parse(token, &jwt.RegisteredClaims{}); err != nil {
+		t.Errorf("expected the same derivation to verify, got %v", err)
+	}
+	if _, err := NewHMAC("secret").Parse(token, &jwt.RegisteredClaims{}); err == nil {
+		t.Error("expected the base secret not to verify a derived token")
+	}
+	if _, err := NewDerivedHMAC("secret", "other").Parse(token, &jwt.RegisteredClaims{}); err == nil {
+		t.Error("expected another purpose not to verify a derived token")
+	}
+}
+
+func TestDeriveKey(t *testing.T) {
+	if !bytes.Equal(DeriveKey("secret", "flow"), DeriveKey("secret", "flow")) {
+		t.Error("expected the same derivation to give the same key")
+	}
+	if bytes.Equal(DeriveKey("secret", "flow"), DeriveKey("secret", "challenge")) {
+		t.Error("expected another purpose to give another key")
+	}
+	if bytes.Equal(DeriveKey("secret", "flow"), []byte("secret")) {
+		t.Error("expected the derived key to differ from the secret")
+	}
+}
+
+// Known thumbprint from RFC 7638, section 3.1.
+func TestThumbprint_RFC7638(t *testing.T) {
+	const n = "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw"
+	const want = "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"
+
+	nb, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := &rsa.PublicKey{N: new(big.Int).SetBytes(nb), E: 65537}
+	if got := thumbprint(pub); got != want {
+		t.Errorf("thumbprint = %s, want %s", got, want)
+	}
+}
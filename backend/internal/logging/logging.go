@@ -0,0 +1,68 @@
+// Package logging configures the process's slog output and carries a
+// request-scoped logger through the context, so everything logged while
+// serving a request can be tied back to it.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/letyshub/project-management/internal/config"
+)
+
+// New returns a logger writing cfg.Format to w at cfg.Level.
+func New(w io.Writer, cfg config.LogConfig) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: cfg.Level}
+	if cfg.Format == config.LogFormatJSON {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// scope is shared by everything handling one request. With adds to it in
+// place, so attributes learned deep in the middleware chain, such as the
+// authenticated user, also reach the access log line written on the way out.
+type scope struct {
+	mu     sync.Mutex
+	logger *slog.Logger
+}
+
+type contextKey struct{}
+
+// NewContext starts a logging scope for a request.
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, &scope{logger: logger})
+}
+
+// With adds attributes to the request's logger for the rest of the request.
+// It does nothing outside a scope started by NewContext.
+func With(ctx context.Context, args ...any) {
+	s, ok := ctx.Value(contextKey{}).(*scope)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	s.logger = s.logger.With(args...)
+	s.mu.Unlock()
+}
+
+// FromContext returns the request's logger, with its chi route pattern when
+// routing has matched one, or slog.Default outside a request.
+func FromContext(ctx context.Context) *slog.Logger {
+	s, ok := ctx.Value(contextKey{}).(*scope)
+	if !ok {
+		return slog.Default()
+	}
+	s.mu.Lock()
+	logger := s.logger
+	s.mu.Unlock()
+
+	if rctx := chi.RouteContext(ctx); rctx != nil && rctx.RoutePattern() != "" {
+		logger = logger.With("route", rctx.RoutePattern())
+	}
+	return logger
+}
@@ -0,0 +1,47 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOutboxMailer_Send(t *testing.T) {
+	dir := t.TempDir()
+	m := NewOutboxMailer(dir, "noreply@example.com")
+
+	err := m.Send(context.Background(), Message{
+		To:      "alice@example.com",
+		Subject: "Hello",
+		Body:    "Welcome aboard",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil {
+		t.Fatalf("glob outbox: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected 1 message in outbox, got %d", len(files))
+	}
+
+	raw, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("read message: %v", err)
+	}
+	content := string(raw)
+	for _, want := range []string{
+		"From: noreply@example.com",
+		"To: alice@example.com",
+		"Subject: Hello",
+		"\r\n\r\nWelcome aboard",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected message to contain %q, got:\n%s", want, content)
+		}
+	}
+}
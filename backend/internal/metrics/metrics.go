@@ -0,0 +1,119 @@
+// Package metrics defines the Prometheus metrics the API exports on
+// /metrics. Collectors are package-level and always recorded, which costs
+// next to nothing; METRICS_ENABLED only decides whether they are served.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "taskflow"
+
+// registry holds this process's metrics only, not the global default one
+// that imported libraries may register into.
+var registry = prometheus.NewRegistry()
+
+var factory = promauto.With(registry)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// Register adds collectors for a dependency created at runtime, such as a
+// database pool.
+func Register(cs ...prometheus.Collector) {
+	registry.MustRegister(cs...)
+}
+
+// HTTP requests are labelled by chi route pattern, not path, so IDs in
+// URLs don't multiply the series.
+var (
+	HTTPRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests by method, route pattern and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method, route pattern and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+)
+
+// Login results.
+const (
+	LoginSuccess            = "success"
+	LoginTwoFactorChallenge = "two_factor_challenge"
+	LoginInvalidCredentials = "invalid_credentials"
+	LoginLocked             = "locked"
+	LoginDeactivated        = "deactivated"
+	LoginEmailNotVerified   = "email_not_verified"
+	LoginError              = "error"
+)
+
+// Refresh results.
+const (
+	RefreshRotated  = "rotated"
+	RefreshRejected = "rejected"
+	RefreshReused   = "reused"
+	RefreshError    = "error"
+)
+
+var (
+	Logins = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "logins_total",
+		Help:      "Password login attempts by result.",
+	}, []string{"result"})
+
+	Refreshes = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "refreshes_total",
+		Help:      "Refresh token exchanges by result; reused means a rotated token was presented and its session revoked.",
+	}, []string{"result"})
+)
+
+var (
+	ProjectsCreated = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "projects_created_total",
+		Help:      "Projects created.",
+	})
+
+	TasksCreated = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_created_total",
+		Help:      "Tasks created.",
+	})
+
+	TasksMoved = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_moved_total",
+		Help:      "Tasks moved to another column or position.",
+	})
+
+	CommentsCreated = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "comments_created_total",
+		Help:      "Comments added to tasks.",
+	})
+)
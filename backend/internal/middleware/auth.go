@@ -2,11 +2,15 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/google/uuid"
 
+	"github.com/letyshub/project-management/internal/authz"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/logging"
 	"github.com/letyshub/project-management/internal/service"
 )
 
@@ -15,8 +19,16 @@ type contextKey string
 const UserIDKey contextKey = "user_id"
 const UserEmailKey contextKey = "user_email"
 const UserRoleKey contextKey = "user_role"
+const EmailVerifiedKey contextKey = "email_verified"
+const AccessTokenKey contextKey = "access_token"
+const SessionIDKey contextKey = "session_id"
 
-func Auth(authService *service.AuthService) func(http.Handler) http.Handler {
+// Auth accepts either a JWT access token or a personal access token
+// (prefixed with service.PersonalAccessTokenPrefix) as a Bearer credential.
+// Read-only personal access tokens are limited to safe methods, and
+// project-limited ones carry their project as an authz scope. The user is
+// loaded on every request, so deactivation and role changes apply at once.
+func Auth(authService *service.AuthService, tokenService *service.TokenService) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			header := r.Header.Get("Authorization")
@@ -31,22 +43,113 @@ func Auth(authService *service.AuthService) func(http.Handler) http.Handler {
 				return
 			}
 
-			claims, err := authService.ValidateAccessToken(parts[1])
+			if strings.HasPrefix(parts[1], service.PersonalAccessTokenPrefix) {
+				token, user, err := tokenService.Authenticate(r.Context(), parts[1])
+				if err != nil {
+					writeAuthError(w, err)
+					return
+				}
+				if token.Scope == domain.TokenScopeRead && !isSafeMethod(r.Method) {
+					http.Error(w, `{"errors":[{"code":"FORBIDDEN","message":"token is read-only"}]}`, http.StatusForbidden)
+					return
+				}
+
+				ctx := context.WithValue(r.Context(), UserIDKey, user.ID)
+				logging.With(ctx, "user_id", user.ID)
+				ctx = context.WithValue(ctx, UserEmailKey, user.Email)
+				ctx = context.WithValue(ctx, UserRoleKey, user.Role)
+				ctx = context.WithValue(ctx, EmailVerifiedKey, user.EmailVerified())
+				ctx = context.WithValue(ctx, AccessTokenKey, token)
+				if token.ProjectID != nil {
+					ctx = authz.WithProjectScope(ctx, *token.ProjectID)
+				}
+
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			claims, user, err := authService.Authenticate(r.Context(), parts[1])
 			if err != nil {
-				http.Error(w, `{"errors":[{"code":"UNAUTHORIZED","message":"invalid or expired token"}]}`, http.StatusUnauthorized)
+				writeAuthError(w, err)
 				return
 			}
 
-			ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
-			ctx = context.WithValue(ctx, UserEmailKey, claims.Email)
-			ctx = context.WithValue(ctx, UserRoleKey, claims.Role)
+			ctx := context.WithValue(r.Context(), UserIDKey, user.ID)
+			logging.With(ctx, "user_id", user.ID)
+			ctx = context.WithValue(ctx, UserEmailKey, user.Email)
+			ctx = context.WithValue(ctx, UserRoleKey, user.Role)
+			ctx = context.WithValue(ctx, EmailVerifiedKey, user.EmailVerified())
+			ctx = context.WithValue(ctx, SessionIDKey, claims.SessionID)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+func writeAuthError(w http.ResponseWriter, err error) {
+	if errors.Is(err, domain.ErrAccountDeactivated) {
+		http.Error(w, `{"errors":[{"code":"ACCOUNT_DEACTIVATED","message":"this account has been deactivated"}]}`, http.StatusForbidden)
+		return
+	}
+	http.Error(w, `{"errors":[{"code":"UNAUTHORIZED","message":"invalid or expired token"}]}`, http.StatusUnauthorized)
+}
+
+// RequireRole only lets through users with the given global role. It must
+// run after Auth.
+func RequireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if userRole, _ := r.Context().Value(UserRoleKey).(string); userRole != role {
+				http.Error(w, `{"errors":[{"code":"FORBIDDEN","message":"forbidden"}]}`, http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireVerifiedEmail lets unverified users make read requests only. It
+// must run after Auth.
+func RequireVerifiedEmail(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isSafeMethod(r.Method) {
+			if verified, _ := r.Context().Value(EmailVerifiedKey).(bool); !verified {
+				http.Error(w, `{"errors":[{"code":"EMAIL_NOT_VERIFIED","message":"verify your email address first"}]}`, http.StatusForbidden)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// SessionOnly rejects requests made with a personal access token, so a
+// token can't be used to mint more tokens or change the account password.
+func SessionOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Context().Value(AccessTokenKey) != nil {
+			http.Error(w, `{"errors":[{"code":"FORBIDDEN","message":"not allowed with an access token"}]}`, http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func GetUserID(ctx context.Context) uuid.UUID {
 	id, _ := ctx.Value(UserIDKey).(uuid.UUID)
 	return id
 }
+
+// GetSessionID returns the login session of the request, or uuid.Nil for
+// personal access tokens.
+func GetSessionID(ctx context.Context) uuid.UUID {
+	id, _ := ctx.Value(SessionIDKey).(uuid.UUID)
+	return id
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
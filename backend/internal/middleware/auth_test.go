@@ -0,0 +1,245 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/letyshub/project-management/internal/authz"
+	"github.com/letyshub/project-management/internal/config"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/jwtkeys"
+	"github.com/letyshub/project-management/internal/repository/memory"
+	"github.com/letyshub/project-management/internal/service"
+)
+
+type authFixture struct {
+	router  http.Handler
+	tokens  *service.TokenService
+	users   *memory.UserRepo
+	user    *domain.User
+	project uuid.UUID
+	other   uuid.UUID
+	session string
+}
+
+// newAuthFixture wires Auth, SessionOnly and RequireRole the way the API
+// router does, in front of the real services over an in-memory store.
+func newAuthFixture(t *testing.T) *authFixture {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.NewStore()
+	users := memory.NewUserRepo(store)
+	orgs := memory.NewOrganizationRepo(store)
+	orgMembers := memory.NewOrganizationMemberRepo(store)
+	projects := memory.NewProjectRepo(store)
+	members := memory.NewProjectMemberRepo(store)
+	boards := memory.NewBoardRepo(store)
+	columns := memory.NewColumnRepo(store)
+	tx := memory.NewTxManager(store)
+
+	policy := authz.NewProjectPolicy(projects, members, boards, columns, memory.NewTaskRepo(store), memory.NewLabelRepo(store), memory.NewCommentRepo(store))
+	audit := service.NewAuditService(memory.NewAuditEventRepo(store), tx, policy)
+	authService := service.NewAuthService(
+		users, memory.NewRefreshTokenRepo(store), memory.NewTwoFactorRepo(store), memory.NewSecurityEventRepo(store), memory.NewLoginThrottleRepo(store),
+		nil, audit, jwtkeys.NewHMAC("test"), jwtkeys.NewDerivedHMAC("test", "2fa-challenge"),
+		config.JWTConfig{Secret: "test", Issuer: "test", Audience: "test-api", AccessExpiration: time.Minute, RefreshExpiration: time.Hour},
+		config.AuthConfig{LoginMaxAttempts: 100, LoginMaxAttemptsPerIP: 100},
+	)
+	orgService := service.NewOrganizationService(orgs, orgMembers, users, projects, members, audit, tx)
+	projectService := service.NewProjectService(projects, members, boards, columns, orgService, audit, tx, policy)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	user := &domain.User{ID: uuid.New(), Email: "ada@example.com", Name: "Ada", PasswordHash: string(hash), Role: domain.UserRoleAdmin, CreatedAt: now, UpdatedAt: now}
+	if err := users.Create(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+	f := &authFixture{
+		tokens: service.NewTokenService(memory.NewPersonalAccessTokenRepo(store), users, audit, policy),
+		users:  users,
+		user:   user,
+	}
+	for _, id := range []*uuid.UUID{&f.project, &f.other} {
+		p, err := projectService.Create(ctx, user.ID, service.CreateProjectInput{Name: "Project"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		*id = p.ID
+	}
+	login, err := authService.Login(ctx, service.LoginInput{Email: user.Email, Password: "password123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.session = login.Tokens.AccessToken
+
+	status := func(w http.ResponseWriter, err error) {
+		switch {
+		case err == nil:
+			w.WriteHeader(http.StatusNoContent)
+		case errors.Is(err, domain.ErrForbidden):
+			w.WriteHeader(http.StatusForbidden)
+		case errors.Is(err, domain.ErrNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+	ok := func(w http.ResponseWriter, r *http.Request) { status(w, nil) }
+
+	r := chi.NewRouter()
+	r.Use(Auth(authService, f.tokens))
+	r.Group(func(r chi.Router) {
+		r.Use(SessionOnly)
+		r.Post("/me/password", ok)
+		r.Get("/me/tokens", ok)
+		r.Post("/me/tokens", ok)
+	})
+	r.Post("/projects", func(w http.ResponseWriter, r *http.Request) {
+		_, err := projectService.Create(r.Context(), GetUserID(r.Context()), service.CreateProjectInput{Name: "New"})
+		status(w, err)
+	})
+	r.Get("/projects/{projectID}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := uuid.Parse(chi.URLParam(r, "projectID"))
+		_, err := projectService.GetByID(r.Context(), id, GetUserID(r.Context()))
+		status(w, err)
+	})
+	r.Patch("/projects/{projectID}", ok)
+	r.Delete("/projects/{projectID}", ok)
+	r.Route("/admin", func(r chi.Router) {
+		r.Use(SessionOnly)
+		r.Use(RequireRole(domain.UserRoleAdmin))
+		r.Get("/users", ok)
+	})
+	f.router = r
+	return f
+}
+
+func (f *authFixture) createToken(t *testing.T, input service.CreateTokenInput) *service.CreatedToken {
+	t.Helper()
+	input.Name = "ci"
+	token, err := f.tokens.Create(context.Background(), f.user.ID, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func (f *authFixture) do(method, path, credential string) int {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+credential)
+	rec := httptest.NewRecorder()
+	f.router.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestAuth_ReadOnlyTokenIsLimitedToSafeMethods(t *testing.T) {
+	f := newAuthFixture(t)
+	read := f.createToken(t, service.CreateTokenInput{Scope: domain.TokenScopeRead})
+	write := f.createToken(t, service.CreateTokenInput{Scope: domain.TokenScopeWrite})
+	path := "/projects/" + f.project.String()
+
+	if code := f.do(http.MethodGet, path, read.Token); code != http.StatusNoContent {
+		t.Errorf("GET with a read-only token: expected 204, got %d", code)
+	}
+	for _, method := range []string{http.MethodPost, http.MethodPatch, http.MethodDelete} {
+		target := path
+		if method == http.MethodPost {
+			target = "/projects"
+		}
+		if code := f.do(method, target, read.Token); code != http.StatusForbidden {
+			t.Errorf("%s with a read-only token: expected 403, got %d", method, code)
+		}
+		if code := f.do(method, target, write.Token); code != http.StatusNoContent {
+			t.Errorf("%s with a write token: expected 204, got %d", method, code)
+		}
+	}
+}
+
+func TestAuth_ProjectLimitedTokenStaysInItsProject(t *testing.T) {
+	f := newAuthFixture(t)
+	token := f.createToken(t, service.CreateTokenInput{Scope: domain.TokenScopeWrite, ProjectID: &f.project})
+
+	if code := f.do(http.MethodGet, "/projects/"+f.project.String(), token.Token); code != http.StatusNoContent {
+		t.Errorf("own project: expected 204, got %d", code)
+	}
+	if code := f.do(http.MethodGet, "/projects/"+f.other.String(), token.Token); code != http.StatusForbidden {
+		t.Errorf("other project: expected 403, got %d", code)
+	}
+	if code := f.do(http.MethodPost, "/projects", token.Token); code != http.StatusForbidden {
+		t.Errorf("project creation: expected 403, got %d", code)
+	}
+	if code := f.do(http.MethodGet, "/projects/"+f.other.String(), f.session); code != http.StatusNoContent {
+		t.Errorf("other project with a session: expected 204, got %d", code)
+	}
+}
+
+func TestAuth_RejectsUnusableTokens(t *testing.T) {
+	f := newAuthFixture(t)
+	ctx := context.Background()
+	path := "/projects/" + f.project.String()
+
+	revoked := f.createToken(t, service.CreateTokenInput{})
+	if err := f.tokens.Revoke(ctx, f.user.ID, revoked.ID); err != nil {
+		t.Fatal(err)
+	}
+	if code := f.do(http.MethodGet, path, revoked.Token); code != http.StatusUnauthorized {
+		t.Errorf("revoked token: expected 401, got %d", code)
+	}
+	if code := f.do(http.MethodGet, path, service.PersonalAccessTokenPrefix+"unknown"); code != http.StatusUnauthorized {
+		t.Errorf("unknown token: expected 401, got %d", code)
+	}
+
+	expiresAt := time.Now().Add(50 * time.Millisecond)
+	expiring := f.createToken(t, service.CreateTokenInput{ExpiresAt: &expiresAt})
+	time.Sleep(time.Until(expiresAt) + 10*time.Millisecond)
+	if code := f.do(http.MethodGet, path, expiring.Token); code != http.StatusUnauthorized {
+		t.Errorf("expired token: expected 401, got %d", code)
+	}
+
+	active := f.createToken(t, service.CreateTokenInput{})
+	deactivatedAt := time.Now()
+	if err := f.users.SetDeactivated(ctx, f.user.ID, &deactivatedAt, deactivatedAt); err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("Authorization", "Bearer "+active.Token)
+	rec := httptest.NewRecorder()
+	f.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "ACCOUNT_DEACTIVATED") {
+		t.Errorf("deactivated owner: expected 403 ACCOUNT_DEACTIVATED, got %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestSessionOnly_RejectsAccessTokens(t *testing.T) {
+	f := newAuthFixture(t)
+	token := f.createToken(t, service.CreateTokenInput{Scope: domain.TokenScopeWrite})
+
+	routes := []struct {
+		method, path string
+	}{
+		{http.MethodGet, "/me/tokens"},
+		{http.MethodPost, "/me/tokens"},
+		{http.MethodPost, "/me/password"},
+		{http.MethodGet, "/admin/users"},
+	}
+	for _, rt := range routes {
+		if code := f.do(rt.method, rt.path, token.Token); code != http.StatusForbidden {
+			t.Errorf("%s %s with an access token: expected 403, got %d", rt.method, rt.path, code)
+		}
+		if code := f.do(rt.method, rt.path, f.session); code != http.StatusNoContent {
+			t.Errorf("%s %s with a session: expected 204, got %d", rt.method, rt.path, code)
+		}
+	}
+}
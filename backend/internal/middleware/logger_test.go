@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+
+	"github.com/letyshub/project-management/internal/logging"
+)
+
+// captureLogs sends slog's default logger to a buffer for the test and
+// returns a function that decodes the JSON lines written so far.
+func captureLogs(t *testing.T) func() []map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	return func() []map[string]any {
+		var lines []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			var entry map[string]any
+			if err := json.Unmarshal([]byte(line), &entry); err != nil {
+				t.Fatalf("decode log line %q: %v", line, err)
+			}
+			lines = append(lines, entry)
+		}
+		return lines
+	}
+}
+
+func newLoggedRouter(successSampleRatio float64) http.Handler {
+	r := chi.NewRouter()
+	r.Use(chimiddleware.RequestID)
+	r.Use(Logger(successSampleRatio))
+	r.Use(Recoverer)
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logging.With(r.Context(), "user_id", "u-1")
+			next.ServeHTTP(w, r)
+		})
+	})
+	r.Get("/tasks/{taskID}", func(w http.ResponseWriter, r *http.Request) {
+		logging.FromContext(r.Context()).Info("loading task")
+		w.Write([]byte("hello"))
+	})
+	r.Get("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+	return r
+}
+
+func TestLogger(t *testing.T) {
+	logs := captureLogs(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks/7", nil)
+	req.Header.Set(chimiddleware.RequestIDHeader, "req-7")
+	newLoggedRouter(1).ServeHTTP(httptest.NewRecorder(), req)
+
+	lines := logs()
+	if len(lines) != 2 {
+		t.Fatalf("expected a handler line and an access line, got %v", lines)
+	}
+	for _, entry := range lines {
+		if entry["request_id"] != "req-7" || entry["user_id"] != "u-1" || entry["route"] != "/tasks/{taskID}" {
+			t.Errorf("expected request id, user id and route on %v", entry)
+		}
+	}
+	access := lines[1]
+	if access["msg"] != "request" || access["status"] != float64(http.StatusOK) || access["bytes"] != float64(len("hello")) {
+		t.Errorf("unexpected access line %v", access)
+	}
+}
+
+func TestLogger_SamplesOnlySuccesses(t *testing.T) {
+	logs := captureLogs(t)
+	router := newLoggedRouter(0)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/tasks/7", nil))
+	for _, entry := range logs() {
+		if entry["msg"] == "request" {
+			t.Fatalf("expected successful requests to be sampled out, got %v", entry)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected a panic to become a 500, got %d", rec.Code)
+	}
+	var sawPanic, sawAccess bool
+	for _, entry := range logs() {
+		switch entry["msg"] {
+		case "panic while serving request":
+			sawPanic = entry["panic"] == "boom" && entry["stack"] != ""
+		case "request":
+			sawAccess = entry["status"] == float64(http.StatusInternalServerError)
+		}
+	}
+	if !sawPanic || !sawAccess {
+		t.Errorf("expected the panic and the failed request to be logged, got %v", logs())
+	}
+}
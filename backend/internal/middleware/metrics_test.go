@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/letyshub/project-management/internal/metrics"
+)
+
+func TestMetrics_LabelsByRoutePattern(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(Metrics)
+	r.Route("/api/v1", func(r chi.Router) {
+		r.Get("/tasks/{taskID}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+	})
+
+	for _, path := range []string{"/api/v1/tasks/1", "/api/v1/tasks/2", "/nowhere"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	tests := []struct {
+		route, status string
+		want          float64
+	}{
+		{"/api/v1/tasks/{taskID}", "204", 2},
+		{"unmatched", "404", 1},
+	}
+	for _, tt := range tests {
+		got := testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues(http.MethodGet, tt.route, tt.status))
+		if got != tt.want {
+			t.Errorf("expected %v requests for %s %s, got %v", tt.want, tt.route, tt.status, got)
+		}
+	}
+}
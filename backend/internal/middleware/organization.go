@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/authz"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/service"
+)
+
+// OrganizationHeader selects the active organization for a request.
+const OrganizationHeader = "X-Organization-ID"
+
+// Organization sets the active organization from the {orgID} path parameter
+// or, failing that, the X-Organization-ID header, after checking the user
+// belongs to it. Requests that name neither are left unscoped. It must run
+// after Auth.
+func Organization(orgService *service.OrganizationService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			raw := chi.URLParam(r, "orgID")
+			if raw == "" {
+				raw = r.Header.Get(OrganizationHeader)
+			}
+			if raw == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			orgID, err := uuid.Parse(raw)
+			if err != nil {
+				http.Error(w, `{"errors":[{"code":"INVALID_ID","message":"invalid organization ID"}]}`, http.StatusBadRequest)
+				return
+			}
+			if _, err := orgService.Authorize(r.Context(), orgID, GetUserID(r.Context()), domain.OrgRoleMember); err != nil {
+				switch {
+				case errors.Is(err, domain.ErrNotFound):
+					http.Error(w, `{"errors":[{"code":"NOT_FOUND","message":"organization not found"}]}`, http.StatusNotFound)
+				case errors.Is(err, domain.ErrForbidden):
+					http.Error(w, `{"errors":[{"code":"FORBIDDEN","message":"not a member of this organization"}]}`, http.StatusForbidden)
+				default:
+					http.Error(w, `{"errors":[{"code":"INTERNAL_ERROR","message":"internal server error"}]}`, http.StatusInternalServerError)
+				}
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(authz.WithOrganization(r.Context(), orgID)))
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+	"runtime/debug"
+
+	"github.com/letyshub/project-management/internal/logging"
+)
+
+// Recoverer turns a panic in a handler into a 500. Unlike chi's Recoverer,
+// it logs the panic and stack through the request's logger, so it carries
+// the request ID and keeps the configured log format.
+func Recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rvr := recover()
+			if rvr == nil {
+				return
+			}
+			if rvr == http.ErrAbortHandler {
+				// Deliberate abort; let net/http close the connection quietly.
+				panic(rvr)
+			}
+			logging.FromContext(r.Context()).Error("panic while serving request", "panic", rvr, "stack", string(debug.Stack()))
+			http.Error(w, `{"errors":[{"code":"INTERNAL_ERROR","message":"internal server error"}]}`, http.StatusInternalServerError)
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
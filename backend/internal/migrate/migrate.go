@@ -1,68 +1,321 @@
+// Package migrate applies and rolls back the SQL schema migrations. A
+// migration is a pair of NNN_name.up.sql and NNN_name.down.sql files; each
+// runs in its own transaction together with its schema_migrations record.
 package migrate
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log/slog"
-	"os"
-	"path/filepath"
-	"sort"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
 
-	"github.com/jackc/pgx/v5/pgxpool"
+var (
+	// ErrModified means an applied migration's up file has changed since it
+	// was applied. Add a new migration instead of editing an old one.
+	ErrModified = errors.New("migration was edited after it was applied")
+	// ErrMissing means the database records a migration this binary doesn't
+	// have, so it can't be rolled back.
+	ErrMissing = errors.New("applied migration not found")
 )
 
-func Run(ctx context.Context, pool *pgxpool.Pool, migrationsDir string) error {
-	// Create tracking table
-	if _, err := pool.Exec(ctx, `
-		CREATE TABLE IF NOT EXISTS schema_migrations (
-			filename VARCHAR(255) PRIMARY KEY,
-			applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
-		)
-	`); err != nil {
-		return fmt.Errorf("create schema_migrations table: %w", err)
-	}
+type Migration struct {
+	Name string // file name without the .up.sql suffix, e.g. 001_create_users
+	Up   string
+	Down string
+}
+
+// key is what schema_migrations records, kept as the up file's name so
+// databases migrated before checksums were tracked still match.
+func (m Migration) key() string {
+	return m.Name + ".up.sql"
+}
 
-	// Find migration files
-	files, err := filepath.Glob(filepath.Join(migrationsDir, "*.up.sql"))
+// Checksum covers the up file only, so a broken down file can still be
+// fixed after the migration was applied.
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.Up))
+	return hex.EncodeToString(sum[:])
+}
+
+// Load reads the migrations in the top level of fsys, sorted by name.
+func Load(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
 	if err != nil {
-		return fmt.Errorf("glob migrations: %w", err)
+		return nil, fmt.Errorf("glob migrations: %w", err)
 	}
-	sort.Strings(files)
 
+	byName := map[string]*Migration{}
+	hasUp := map[string]bool{}
 	for _, f := range files {
-		name := filepath.Base(f)
-
-		// Check if already applied
-		var exists bool
-		err := pool.QueryRow(ctx,
-			"SELECT EXISTS(SELECT 1 FROM schema_migrations WHERE filename = $1)", name,
-		).Scan(&exists)
+		name, up := strings.CutSuffix(f, ".up.sql")
+		if !up {
+			var down bool
+			if name, down = strings.CutSuffix(f, ".down.sql"); !down {
+				return nil, fmt.Errorf("migration %s: name must end in .up.sql or .down.sql", f)
+			}
+		}
+		data, err := fs.ReadFile(fsys, f)
 		if err != nil {
-			return fmt.Errorf("check migration %s: %w", name, err)
+			return nil, fmt.Errorf("read migration %s: %w", f, err)
 		}
-		if exists {
-			continue
+
+		m := byName[name]
+		if m == nil {
+			m = &Migration{Name: name}
+			byName[name] = m
+		}
+		if up {
+			m.Up = string(data)
+			hasUp[name] = true
+		} else {
+			m.Down = string(data)
 		}
+	}
 
-		// Read and execute
-		sql, err := os.ReadFile(f)
-		if err != nil {
-			return fmt.Errorf("read migration %s: %w", name, err)
+	migrations := make([]Migration, 0, len(byName))
+	for _, m := range byName {
+		if !hasUp[m.Name] {
+			return nil, fmt.Errorf("migration %s has a down file but no up file", m.Name)
 		}
+		migrations = append(migrations, *m)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return migrations, nil
+}
+
+type State string
+
+const (
+	StatePending  State = "pending"
+	StateApplied  State = "applied"
+	StateModified State = "modified"
+	StateMissing  State = "missing"
+)
 
-		if _, err := pool.Exec(ctx, string(sql)); err != nil {
-			return fmt.Errorf("execute migration %s: %w", name, err)
+type Status struct {
+	Name      string
+	State     State
+	AppliedAt *time.Time
+}
+
+// record is a schema_migrations row. Checksum is empty for rows written
+// before checksums were tracked; Up fills it in.
+type record struct {
+	appliedAt time.Time
+	checksum  string
+}
+
+// driver is the database-specific half of a Migrator.
+type driver interface {
+	// acquire returns a session holding the migration lock, with the
+	// schema_migrations table created.
+	acquire(ctx context.Context) (session, error)
+	// applied reads schema_migrations without the lock.
+	applied(ctx context.Context) (map[string]record, error)
+}
+
+type session interface {
+	applied(ctx context.Context) (map[string]record, error)
+	// apply runs sql and records key in one transaction.
+	apply(ctx context.Context, key, sql, checksum string) error
+	// revert runs sql and deletes key's record in one transaction.
+	revert(ctx context.Context, key, sql string) error
+	setChecksum(ctx context.Context, key, checksum string) error
+	// release gives up the migration lock.
+	release()
+}
+
+// Migrator applies one set of migrations to one database.
+type Migrator struct {
+	driver     driver
+	migrations []Migration
+}
+
+func newMigrator(d driver, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{driver: d, migrations: migrations}, nil
+}
+
+// Up applies every pending migration in order.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.locked(ctx, func(s session, applied map[string]record) error {
+		if err := m.verify(applied, ""); err != nil {
+			return err
+		}
+		for _, mig := range m.migrations {
+			rec, ok := applied[mig.key()]
+			if ok {
+				if rec.checksum == "" {
+					if err := s.setChecksum(ctx, mig.key(), mig.Checksum()); err != nil {
+						return fmt.Errorf("record checksum of %s: %w", mig.Name, err)
+					}
+				}
+				continue
+			}
+			if err := s.apply(ctx, mig.key(), mig.Up, mig.Checksum()); err != nil {
+				return fmt.Errorf("apply migration %s: %w", mig.Name, err)
+			}
+			slog.Info("applied migration", "migration", mig.Name)
 		}
+		return nil
+	})
+}
 
-		// Record it
-		if _, err := pool.Exec(ctx,
-			"INSERT INTO schema_migrations (filename) VALUES ($1)", name,
-		); err != nil {
-			return fmt.Errorf("record migration %s: %w", name, err)
+// Down rolls back the n most recently applied migrations.
+func (m *Migrator) Down(ctx context.Context, n int) error {
+	if n < 1 {
+		return fmt.Errorf("down: count must be at least 1, got %d", n)
+	}
+	return m.locked(ctx, func(s session, applied map[string]record) error {
+		if err := m.verify(applied, ""); err != nil {
+			return err
 		}
+		for _, key := range lastApplied(applied, n) {
+			if err := m.revert(ctx, s, key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
 
-		slog.Info("applied migration", "file", name)
+// Redo rolls back the most recently applied migration and applies it again.
+// That migration is exempt from the checksum check, since redoing it after
+// an edit is the point.
+func (m *Migrator) Redo(ctx context.Context) error {
+	return m.locked(ctx, func(s session, applied map[string]record) error {
+		last := lastApplied(applied, 1)
+		if len(last) == 0 {
+			return errors.New("redo: no migrations have been applied")
+		}
+		if err := m.verify(applied, last[0]); err != nil {
+			return err
+		}
+		if err := m.revert(ctx, s, last[0]); err != nil {
+			return err
+		}
+		mig, _ := m.find(last[0])
+		if err := s.apply(ctx, mig.key(), mig.Up, mig.Checksum()); err != nil {
+			return fmt.Errorf("apply migration %s: %w", mig.Name, err)
+		}
+		slog.Info("applied migration", "migration", mig.Name)
+		return nil
+	})
+}
+
+// Status lists every known migration in order, followed by applied ones
+// this binary doesn't have.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var out []Status
+	err := m.locked(ctx, func(_ session, applied map[string]record) error {
+		for _, mig := range m.migrations {
+			rec, ok := applied[mig.key()]
+			if !ok {
+				out = append(out, Status{Name: mig.Name, State: StatePending})
+				continue
+			}
+			state := StateApplied
+			if rec.checksum != "" && rec.checksum != mig.Checksum() {
+				state = StateModified
+			}
+			out = append(out, Status{Name: mig.Name, State: state, AppliedAt: &rec.appliedAt})
+			delete(applied, mig.key())
+		}
+		for _, key := range slices.Sorted(maps.Keys(applied)) {
+			rec := applied[key]
+			out = append(out, Status{Name: strings.TrimSuffix(key, ".up.sql"), State: StateMissing, AppliedAt: &rec.appliedAt})
+		}
+		return nil
+	})
+	return out, err
+}
+
+// Pending returns the names of the migrations not applied yet. It doesn't
+// take the migration lock, so a health check never waits behind a run.
+func (m *Migrator) Pending(ctx context.Context) ([]string, error) {
+	applied, err := m.driver.applied(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list applied migrations: %w", err)
+	}
+	var pending []string
+	for _, mig := range m.migrations {
+		if _, ok := applied[mig.key()]; !ok {
+			pending = append(pending, mig.Name)
+		}
 	}
+	return pending, nil
+}
 
+func (m *Migrator) locked(ctx context.Context, fn func(s session, applied map[string]record) error) error {
+	s, err := m.driver.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.release()
+
+	applied, err := s.applied(ctx)
+	if err != nil {
+		return fmt.Errorf("list applied migrations: %w", err)
+	}
+	return fn(s, applied)
+}
+
+// verify fails if an applied migration other than skip was edited.
+// Migrations this binary doesn't have are allowed, so an older replica can
+// still start against a schema a newer one has migrated.
+func (m *Migrator) verify(applied map[string]record, skip string) error {
+	for _, mig := range m.migrations {
+		rec, ok := applied[mig.key()]
+		if !ok || rec.checksum == "" || mig.key() == skip {
+			continue
+		}
+		if rec.checksum != mig.Checksum() {
+			return fmt.Errorf("%w: %s", ErrModified, mig.Name)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) revert(ctx context.Context, s session, key string) error {
+	mig, ok := m.find(key)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrMissing, strings.TrimSuffix(key, ".up.sql"))
+	}
+	if strings.TrimSpace(mig.Down) == "" {
+		return fmt.Errorf("migration %s has no down file", mig.Name)
+	}
+	if err := s.revert(ctx, key, mig.Down); err != nil {
+		return fmt.Errorf("revert migration %s: %w", mig.Name, err)
+	}
+	slog.Info("reverted migration", "migration", mig.Name)
 	return nil
 }
+
+func (m *Migrator) find(key string) (Migration, bool) {
+	for _, mig := range m.migrations {
+		if mig.key() == key {
+			return mig, true
+		}
+	}
+	return Migration{}, false
+}
+
+// lastApplied returns up to n applied keys, latest first.
+func lastApplied(applied map[string]record, n int) []string {
+	keys := slices.Sorted(maps.Keys(applied))
+	slices.Reverse(keys)
+	return keys[:min(n, len(keys))]
+}
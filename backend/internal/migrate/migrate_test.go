@@ -0,0 +1,265 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/letyshub/project-management/internal/repository/sqlite"
+)
+
+var ctx = context.Background()
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"001_create_widgets.up.sql":    {Data: []byte("CREATE TABLE widgets (id INTEGER PRIMARY KEY);")},
+		"001_create_widgets.down.sql":  {Data: []byte("DROP TABLE widgets;")},
+		"002_add_widget_name.up.sql":   {Data: []byte("ALTER TABLE widgets ADD COLUMN name TEXT;")},
+		"002_add_widget_name.down.sql": {Data: []byte("ALTER TABLE widgets DROP COLUMN name;")},
+		"003_create_gadgets.up.sql":    {Data: []byte("CREATE TABLE gadgets (id INTEGER PRIMARY KEY);")},
+		"003_create_gadgets.down.sql":  {Data: []byte("DROP TABLE gadgets;")},
+	}
+}
+
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func newSQLite(t *testing.T, db *sql.DB, fsys fstest.MapFS) *Migrator {
+	t.Helper()
+	m, err := NewSQLite(db, fsys)
+	if err != nil {
+		t.Fatalf("NewSQLite: %v", err)
+	}
+	return m
+}
+
+func wantStates(t *testing.T, m *Migrator, want ...State) {
+	t.Helper()
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if len(statuses) != len(want) {
+		t.Fatalf("expected %d migrations, got %+v", len(want), statuses)
+	}
+	for i, s := range statuses {
+		if s.State != want[i] {
+			t.Fatalf("expected %s to be %s, got %s", s.Name, want[i], s.State)
+		}
+		if (s.AppliedAt != nil) != (s.State != StatePending) {
+			t.Fatalf("%s is %s with applied at %v", s.Name, s.State, s.AppliedAt)
+		}
+	}
+}
+
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+	var exists bool
+	if err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = ?)", name).Scan(&exists); err != nil {
+		t.Fatalf("inspect schema: %v", err)
+	}
+	return exists
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load(testFS())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(migrations) != 3 || migrations[0].Name != "001_create_widgets" || migrations[2].Name != "003_create_gadgets" {
+		t.Fatalf("unexpected migrations: %+v", migrations)
+	}
+	if migrations[1].Down != "ALTER TABLE widgets DROP COLUMN name;" {
+		t.Fatalf("down file not loaded: %q", migrations[1].Down)
+	}
+
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"down without up", fstest.MapFS{"001_a.down.sql": {Data: []byte("SELECT 1;")}}},
+		{"unknown suffix", fstest.MapFS{"001_a.sql": {Data: []byte("SELECT 1;")}}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Load(tc.fsys); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestUpAndDown(t *testing.T) {
+	db := openDB(t)
+	m := newSQLite(t, db, testFS())
+
+	wantStates(t, m, StatePending, StatePending, StatePending)
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	wantStates(t, m, StateApplied, StateApplied, StateApplied)
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("second Up: %v", err)
+	}
+
+	if err := m.Down(ctx, 2); err != nil {
+		t.Fatalf("Down: %v", err)
+	}
+	wantStates(t, m, StateApplied, StatePending, StatePending)
+	if tableExists(t, db, "gadgets") || !tableExists(t, db, "widgets") {
+		t.Fatal("Down 2 should drop gadgets and keep widgets")
+	}
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		t.Fatalf("Pending: %v", err)
+	}
+	if fmt.Sprint(pending) != "[002_add_widget_name 003_create_gadgets]" {
+		t.Fatalf("unexpected pending migrations %v", pending)
+	}
+
+	if err := m.Down(ctx, 5); err != nil {
+		t.Fatalf("Down past the first migration: %v", err)
+	}
+	wantStates(t, m, StatePending, StatePending, StatePending)
+	if err := m.Down(ctx, 0); err == nil {
+		t.Fatal("expected Down(0) to fail")
+	}
+}
+
+func TestFailedMigrationRollsBack(t *testing.T) {
+	db := openDB(t)
+	fsys := testFS()
+	fsys["002_add_widget_name.up.sql"] = &fstest.MapFile{Data: []byte("ALTER TABLE widgets ADD COLUMN name TEXT; SELECT * FROM nowhere;")}
+	m := newSQLite(t, db, fsys)
+
+	if err := m.Up(ctx); err == nil {
+		t.Fatal("expected Up to fail")
+	}
+	wantStates(t, m, StateApplied, StatePending, StatePending)
+
+	var columns int
+	if err := db.QueryRow("SELECT COUNT(*) FROM pragma_table_info('widgets') WHERE name = 'name'").Scan(&columns); err != nil {
+		t.Fatalf("inspect widgets: %v", err)
+	}
+	if columns != 0 {
+		t.Fatal("the failed migration's first statement was not rolled back")
+	}
+}
+
+func TestChecksums(t *testing.T) {
+	db := openDB(t)
+	if err := newSQLite(t, db, testFS()).Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	edited := testFS()
+	edited["001_create_widgets.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE widgets (id INTEGER PRIMARY KEY, size INTEGER);")}
+	m := newSQLite(t, db, edited)
+	wantStates(t, m, StateModified, StateApplied, StateApplied)
+	if err := m.Up(ctx); !errors.Is(err, ErrModified) {
+		t.Fatalf("expected ErrModified from Up, got %v", err)
+	}
+	if err := m.Down(ctx, 1); !errors.Is(err, ErrModified) {
+		t.Fatalf("expected ErrModified from Down, got %v", err)
+	}
+
+	// Rows recorded before checksums were tracked are trusted and filled in.
+	if _, err := db.Exec("UPDATE schema_migrations SET checksum = NULL"); err != nil {
+		t.Fatalf("clear checksums: %v", err)
+	}
+	wantStates(t, m, StateApplied, StateApplied, StateApplied)
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("Up after clearing checksums: %v", err)
+	}
+	if err := newSQLite(t, db, testFS()).Up(ctx); !errors.Is(err, ErrModified) {
+		t.Fatalf("expected ErrModified once checksums are recorded, got %v", err)
+	}
+}
+
+func TestRedo(t *testing.T) {
+	db := openDB(t)
+	if err := newSQLite(t, db, testFS()).Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	// Redo is how an edited latest migration gets reapplied.
+	edited := testFS()
+	edited["003_create_gadgets.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE gadgets (id INTEGER PRIMARY KEY, size INTEGER);")}
+	m := newSQLite(t, db, edited)
+	wantStates(t, m, StateApplied, StateApplied, StateModified)
+	if err := m.Redo(ctx); err != nil {
+		t.Fatalf("Redo: %v", err)
+	}
+	wantStates(t, m, StateApplied, StateApplied, StateApplied)
+	if _, err := db.Exec("INSERT INTO gadgets (id, size) VALUES (1, 2)"); err != nil {
+		t.Fatalf("redone migration not applied: %v", err)
+	}
+
+	if err := m.Down(ctx, 3); err != nil {
+		t.Fatalf("Down: %v", err)
+	}
+	if err := m.Redo(ctx); err == nil {
+		t.Fatal("expected Redo with nothing applied to fail")
+	}
+}
+
+func TestMissingMigration(t *testing.T) {
+	db := openDB(t)
+	if err := newSQLite(t, db, testFS()).Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	// An older binary still starts against a newer schema, but can't roll
+	// back what it doesn't know.
+	older := testFS()
+	delete(older, "003_create_gadgets.up.sql")
+	delete(older, "003_create_gadgets.down.sql")
+	m := newSQLite(t, db, older)
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	wantStates(t, m, StateApplied, StateApplied, StateMissing)
+	if err := m.Down(ctx, 1); !errors.Is(err, ErrMissing) {
+		t.Fatalf("expected ErrMissing, got %v", err)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"001_a.up.sql", "001_a.down.sql", "009_b.up.sql", "009_b.down.sql", "migrations.go"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	up, down, err := Create(dir, "Add widget Sizes!")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if filepath.Base(up) != "010_add_widget_sizes.up.sql" || filepath.Base(down) != "010_add_widget_sizes.down.sql" {
+		t.Fatalf("unexpected files %s, %s", up, down)
+	}
+	migrations, err := Load(os.DirFS(dir))
+	if err != nil {
+		t.Fatalf("Load after Create: %v", err)
+	}
+	if len(migrations) != 3 {
+		t.Fatalf("expected 3 migrations, got %d", len(migrations))
+	}
+
+	if _, _, err := Create(dir, "!!"); err == nil {
+		t.Fatal("expected an error for a name without letters or digits")
+	}
+}
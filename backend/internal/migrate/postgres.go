@@ -0,0 +1,145 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// lockKey names the advisory lock every migrator takes, so replicas starting
+// together migrate one at a time. The value is arbitrary but must not change.
+const lockKey int64 = 0x7461736b666c6f77
+
+// NewPostgres returns a Migrator for the migrations in fsys.
+func NewPostgres(pool *pgxpool.Pool, fsys fs.FS) (*Migrator, error) {
+	return newMigrator(postgresDriver{pool: pool}, fsys)
+}
+
+type postgresDriver struct {
+	pool *pgxpool.Pool
+}
+
+// acquire holds the advisory lock on one connection, which every statement
+// of the session then uses: the lock belongs to that connection.
+func (d postgresDriver) acquire(ctx context.Context) (session, error) {
+	conn, err := d.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire connection: %w", err)
+	}
+
+	var locked bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", lockKey).Scan(&locked); err != nil {
+		conn.Release()
+		return nil, fmt.Errorf("take migration lock: %w", err)
+	}
+	if !locked {
+		slog.Info("waiting for another migration run to finish")
+		if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+			conn.Release()
+			return nil, fmt.Errorf("take migration lock: %w", err)
+		}
+	}
+	s := &postgresSession{conn: conn}
+
+	if _, err := conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			filename VARCHAR(255) PRIMARY KEY,
+			applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+		);
+		ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS checksum VARCHAR(64)
+	`); err != nil {
+		s.release()
+		return nil, fmt.Errorf("create schema_migrations table: %w", err)
+	}
+	return s, nil
+}
+
+func (d postgresDriver) applied(ctx context.Context) (map[string]record, error) {
+	return postgresApplied(ctx, d.pool)
+}
+
+type postgresSession struct {
+	conn *pgxpool.Conn
+}
+
+func (s *postgresSession) applied(ctx context.Context) (map[string]record, error) {
+	return postgresApplied(ctx, s.conn)
+}
+
+func postgresApplied(ctx context.Context, q interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}) (map[string]record, error) {
+	rows, err := q.Query(ctx, "SELECT filename, applied_at, COALESCE(checksum, '') FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[string]record{}
+	for rows.Next() {
+		var key string
+		var rec record
+		if err := rows.Scan(&key, &rec.appliedAt, &rec.checksum); err != nil {
+			return nil, err
+		}
+		applied[key] = rec
+	}
+	return applied, rows.Err()
+}
+
+func (s *postgresSession) apply(ctx context.Context, key, sql, checksum string) error {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, sql); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx,
+		"INSERT INTO schema_migrations (filename, checksum) VALUES ($1, $2)", key, checksum,
+	); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (s *postgresSession) revert(ctx context.Context, key, sql string) error {
+	tx, err := s.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, sql); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE filename = $1", key); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (s *postgresSession) setChecksum(ctx context.Context, key, checksum string) error {
+	_, err := s.conn.Exec(ctx, "UPDATE schema_migrations SET checksum = $2 WHERE filename = $1", key, checksum)
+	return err
+}
+
+func (s *postgresSession) release() {
+	// Unlock even if the run's context was cancelled; a connection returned
+	// to the pool still holding the lock would block every later run.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := s.conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", lockKey); err != nil {
+		slog.Error("failed to release migration lock", "error", err)
+		s.conn.Hijack().Close(ctx)
+		return
+	}
+	s.conn.Release()
+}
@@ -0,0 +1,111 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"time"
+)
+
+// NewSQLite returns a Migrator for the migrations in fsys. SQLite has no
+// advisory locks, but each migration's transaction takes the database's
+// write lock and a SQLite file is served by one process, so runs don't
+// interleave.
+func NewSQLite(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	return newMigrator(sqliteDriver{db: db}, fsys)
+}
+
+type sqliteDriver struct {
+	db *sql.DB
+}
+
+func (d sqliteDriver) acquire(ctx context.Context) (session, error) {
+	if _, err := d.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			filename TEXT PRIMARY KEY,
+			applied_at TIMESTAMP NOT NULL,
+			checksum TEXT
+		)
+	`); err != nil {
+		return nil, fmt.Errorf("create schema_migrations table: %w", err)
+	}
+
+	// Databases created before checksums were tracked lack the column, and
+	// SQLite has no ADD COLUMN IF NOT EXISTS.
+	var hasChecksum bool
+	if err := d.db.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM pragma_table_info('schema_migrations') WHERE name = 'checksum')",
+	).Scan(&hasChecksum); err != nil {
+		return nil, fmt.Errorf("inspect schema_migrations table: %w", err)
+	}
+	if !hasChecksum {
+		if _, err := d.db.ExecContext(ctx, "ALTER TABLE schema_migrations ADD COLUMN checksum TEXT"); err != nil {
+			return nil, fmt.Errorf("add schema_migrations checksum: %w", err)
+		}
+	}
+	return sqliteSession(d), nil
+}
+
+func (d sqliteDriver) applied(ctx context.Context) (map[string]record, error) {
+	return sqliteSession(d).applied(ctx)
+}
+
+type sqliteSession struct {
+	db *sql.DB
+}
+
+func (s sqliteSession) applied(ctx context.Context) (map[string]record, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT filename, applied_at, COALESCE(checksum, '') FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[string]record{}
+	for rows.Next() {
+		var key string
+		var rec record
+		if err := rows.Scan(&key, &rec.appliedAt, &rec.checksum); err != nil {
+			return nil, err
+		}
+		applied[key] = rec
+	}
+	return applied, rows.Err()
+}
+
+func (s sqliteSession) apply(ctx context.Context, key, query, checksum string) error {
+	return s.inTx(ctx, query,
+		"INSERT INTO schema_migrations (filename, applied_at, checksum) VALUES (?, ?, ?)",
+		key, time.Now().UTC(), checksum,
+	)
+}
+
+func (s sqliteSession) revert(ctx context.Context, key, query string) error {
+	return s.inTx(ctx, query, "DELETE FROM schema_migrations WHERE filename = ?", key)
+}
+
+// inTx runs a migration file and the statement that records it in one
+// transaction.
+func (s sqliteSession) inTx(ctx context.Context, query, record string, args ...any) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s sqliteSession) setChecksum(ctx context.Context, key, checksum string) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE schema_migrations SET checksum = ? WHERE filename = ?", checksum, key)
+	return err
+}
+
+func (sqliteSession) release() {}
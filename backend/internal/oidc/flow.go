@@ -0,0 +1,90 @@
+package oidc
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrInvalidFlow = errors.New("oidc: invalid or expired login state")
+
+// Flow is the per-login secret state: the CSRF state parameter, the ID token
+// nonce and the PKCE verifier. It round-trips through the browser in a signed
+// cookie so the server stays stateless.
+type Flow struct {
+	State     string    `json:"s"`
+	Nonce     string    `json:"n"`
+	Verifier  string    `json:"v"`
+	ExpiresAt time.Time `json:"e"`
+}
+
+// NewFlow starts a login that must complete within ttl.
+func NewFlow(ttl time.Duration) (*Flow, error) {
+	state, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	return &Flow{State: state, Nonce: nonce, Verifier: verifier, ExpiresAt: time.Now().Add(ttl)}, nil
+}
+
+// Seal encodes the flow and signs it with secret.
+func (f *Flow) Seal(secret []byte) (string, error) {
+	payload, err := json.Marshal(f)
+	if err != nil {
+		return "", err
+	}
+	body := base64.RawURLEncoding.EncodeToString(payload)
+	return body + "." + sign(secret, body), nil
+}
+
+// OpenFlow verifies and decodes a sealed flow.
+func OpenFlow(secret []byte, sealed string) (*Flow, error) {
+	body, sig, ok := strings.Cut(sealed, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(sign(secret, body))) {
+		return nil, ErrInvalidFlow
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return nil, ErrInvalidFlow
+	}
+	var f Flow
+	if err := json.Unmarshal(payload, &f); err != nil {
+		return nil, ErrInvalidFlow
+	}
+	if time.Now().After(f.ExpiresAt) {
+		return nil, ErrInvalidFlow
+	}
+	return &f, nil
+}
+
+func challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func sign(secret []byte, body string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(body))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,133 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/letyshub/project-management/internal/oidc"
+	"github.com/letyshub/project-management/internal/oidc/oidctest"
+)
+
+func newProvider(t *testing.T) (*oidc.Provider, *oidctest.Issuer) {
+	t.Helper()
+	iss := oidctest.NewIssuer(t, "client", "secret")
+	p, err := oidc.Discover(context.Background(), iss.Client(), oidc.Config{
+		Issuer:       iss.URL,
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "http://app/callback",
+	})
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+	return p, iss
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	p, iss := newProvider(t)
+	flow, err := oidc.NewFlow(time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code, state, err := iss.Authorize(p.AuthCodeURL(flow), jwt.MapClaims{
+		"sub": "user-1", "email": "ada@example.com", "email_verified": true, "name": "Ada",
+	})
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	if state != flow.State {
+		t.Fatalf("state not passed through: %q", state)
+	}
+
+	tok, err := p.Exchange(context.Background(), flow, code)
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+	if tok.Subject != "user-1" || tok.Email != "ada@example.com" || !tok.EmailVerified || tok.Name != "Ada" {
+		t.Errorf("unexpected token: %+v", tok)
+	}
+	if tok.Issuer != iss.URL {
+		t.Errorf("expected issuer %q, got %q", iss.URL, tok.Issuer)
+	}
+}
+
+func TestProvider_ExchangeWrongVerifier(t *testing.T) {
+	p, iss := newProvider(t)
+	flow, _ := oidc.NewFlow(time.Minute)
+	code, _, err := iss.Authorize(p.AuthCodeURL(flow), jwt.MapClaims{"sub": "user-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flow.Verifier = "something-else"
+	if _, err := p.Exchange(context.Background(), flow, code); err == nil {
+		t.Fatal("expected exchange to fail with the wrong PKCE verifier")
+	}
+}
+
+func TestProvider_Verify(t *testing.T) {
+	p, iss := newProvider(t)
+
+	tests := []struct {
+		name    string
+		claims  jwt.MapClaims
+		nonce   string
+		wantErr bool
+	}{
+		{name: "valid", claims: jwt.MapClaims{"sub": "u", "nonce": "n"}, nonce: "n"},
+		{name: "nonce mismatch", claims: jwt.MapClaims{"sub": "u", "nonce": "other"}, nonce: "n", wantErr: true},
+		{name: "wrong audience", claims: jwt.MapClaims{"sub": "u", "nonce": "n", "aud": "someone-else"}, nonce: "n", wantErr: true},
+		{name: "wrong issuer", claims: jwt.MapClaims{"sub": "u", "nonce": "n", "iss": "https://evil"}, nonce: "n", wantErr: true},
+		{name: "expired", claims: jwt.MapClaims{"sub": "u", "nonce": "n", "exp": time.Now().Add(-time.Hour).Unix()}, nonce: "n", wantErr: true},
+		{name: "missing subject", claims: jwt.MapClaims{"nonce": "n"}, nonce: "n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := iss.SignIDToken(tt.claims)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = p.Verify(context.Background(), raw, tt.nonce)
+			if tt.wantErr {
+				if !errors.Is(err, oidc.ErrInvalidToken) {
+					t.Errorf("expected ErrInvalidToken, got %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestFlow_SealOpen(t *testing.T) {
+	secret := []byte("secret")
+	flow, _ := oidc.NewFlow(time.Minute)
+	sealed, err := flow.Seal(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := oidc.OpenFlow(secret, sealed)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if got.State != flow.State || got.Nonce != flow.Nonce || got.Verifier != flow.Verifier || !got.ExpiresAt.Equal(flow.ExpiresAt) {
+		t.Errorf("round trip mismatch: %+v != %+v", got, flow)
+	}
+
+	if _, err := oidc.OpenFlow([]byte("other"), sealed); !errors.Is(err, oidc.ErrInvalidFlow) {
+		t.Errorf("expected ErrInvalidFlow for wrong secret, got %v", err)
+	}
+
+	expired := &oidc.Flow{State: "s", ExpiresAt: time.Now().Add(-time.Second)}
+	sealed, _ = expired.Seal(secret)
+	if _, err := oidc.OpenFlow(secret, sealed); !errors.Is(err, oidc.ErrInvalidFlow) {
+		t.Errorf("expected ErrInvalidFlow for expired flow, got %v", err)
+	}
+}
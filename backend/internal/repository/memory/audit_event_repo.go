@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type AuditEventRepo struct {
+	store *Store
+}
+
+func NewAuditEventRepo(store *Store) *AuditEventRepo {
+	return &AuditEventRepo{store: store}
+}
+
+func (r *AuditEventRepo) Create(ctx context.Context, event *domain.AuditEvent) error {
+	defer r.store.lock(ctx)()
+
+	if _, ok := r.store.data.auditEvents[event.ID]; ok {
+		return domain.ErrConflict
+	}
+	r.store.data.auditEvents[event.ID] = *event
+	return nil
+}
+
+func (r *AuditEventRepo) List(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEvent, error) {
+	defer r.store.lock(ctx)()
+
+	events := collect(r.store.data.auditEvents,
+		func(e domain.AuditEvent) bool {
+			switch {
+			case filter.ProjectID != nil && (e.ProjectID == nil || *e.ProjectID != *filter.ProjectID):
+				return false
+			case filter.ActorID != nil && e.ActorID != *filter.ActorID:
+				return false
+			case filter.EntityType != "" && e.EntityType != filter.EntityType:
+				return false
+			case filter.EntityID != nil && e.EntityID != *filter.EntityID:
+				return false
+			case filter.Since != nil && e.CreatedAt.Before(*filter.Since):
+				return false
+			case filter.Until != nil && !e.CreatedAt.Before(*filter.Until):
+				return false
+			}
+			return true
+		},
+		func(a, b *domain.AuditEvent) int {
+			if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+				return c
+			}
+			return compareIDs(a.ID, b.ID)
+		},
+	)
+
+	if filter.Offset >= len(events) {
+		return nil, nil
+	}
+	events = events[filter.Offset:]
+	if filter.Limit < len(events) {
+		events = events[:filter.Limit]
+	}
+	return events, nil
+}
@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type BoardRepo struct {
+	store *Store
+}
+
+func NewBoardRepo(store *Store) *BoardRepo {
+	return &BoardRepo{store: store}
+}
+
+func (r *BoardRepo) Create(ctx context.Context, board *domain.Board) error {
+	defer r.store.lock(ctx)()
+
+	if _, ok := r.store.data.boards[board.ID]; ok {
+		return domain.ErrConflict
+	}
+	r.store.data.boards[board.ID] = *board
+	return nil
+}
+
+func (r *BoardRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Board, error) {
+	defer r.store.lock(ctx)()
+
+	b, ok := r.store.data.boards[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &b, nil
+}
+
+func (r *BoardRepo) ListByProject(ctx context.Context, projectID uuid.UUID) ([]*domain.Board, error) {
+	defer r.store.lock(ctx)()
+
+	return collect(r.store.data.boards,
+		func(b domain.Board) bool { return b.ProjectID == projectID },
+		func(a, b *domain.Board) int {
+			if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+				return c
+			}
+			return compareIDs(a.ID, b.ID)
+		},
+	), nil
+}
+
+func (r *BoardRepo) Update(ctx context.Context, board *domain.Board) error {
+	defer r.store.lock(ctx)()
+
+	b, ok := r.store.data.boards[board.ID]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	b.Name = board.Name
+	b.UpdatedAt = board.UpdatedAt
+	r.store.data.boards[board.ID] = b
+	return nil
+}
+
+func (r *BoardRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	defer r.store.lock(ctx)()
+
+	if _, ok := r.store.data.boards[id]; !ok {
+		return domain.ErrNotFound
+	}
+	r.store.data.deleteBoard(id)
+	return nil
+}
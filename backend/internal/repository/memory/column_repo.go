@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type ColumnRepo struct {
+	store *Store
+}
+
+func NewColumnRepo(store *Store) *ColumnRepo {
+	return &ColumnRepo{store: store}
+}
+
+func (r *ColumnRepo) Create(ctx context.Context, col *domain.Column) error {
+	defer r.store.lock(ctx)()
+
+	if _, ok := r.store.data.columns[col.ID]; ok {
+		return domain.ErrConflict
+	}
+	r.store.data.columns[col.ID] = *col
+	return nil
+}
+
+func (r *ColumnRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Column, error) {
+	defer r.store.lock(ctx)()
+
+	c, ok := r.store.data.columns[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &c, nil
+}
+
+func (r *ColumnRepo) ListByBoard(ctx context.Context, boardID uuid.UUID) ([]*domain.Column, error) {
+	defer r.store.lock(ctx)()
+
+	return collect(r.store.data.columns,
+		func(c domain.Column) bool { return c.BoardID == boardID },
+		func(a, b *domain.Column) int {
+			if c := cmp.Compare(a.Position, b.Position); c != 0 {
+				return c
+			}
+			return compareIDs(a.ID, b.ID)
+		},
+	), nil
+}
+
+func (r *ColumnRepo) Update(ctx context.Context, col *domain.Column) error {
+	defer r.store.lock(ctx)()
+
+	c, ok := r.store.data.columns[col.ID]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	c.Name = col.Name
+	c.Position = col.Position
+	c.Category = col.Category
+	c.UpdatedAt = col.UpdatedAt
+	r.store.data.columns[col.ID] = c
+	return nil
+}
+
+func (r *ColumnRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	defer r.store.lock(ctx)()
+
+	if _, ok := r.store.data.columns[id]; !ok {
+		return domain.ErrNotFound
+	}
+	r.store.data.deleteColumn(id)
+	return nil
+}
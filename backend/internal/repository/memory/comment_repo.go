@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type CommentRepo struct {
+	store *Store
+}
+
+func NewCommentRepo(store *Store) *CommentRepo {
+	return &CommentRepo{store: store}
+}
+
+func (r *CommentRepo) Create(ctx context.Context, comment *domain.Comment) error {
+	defer r.store.lock(ctx)()
+
+	if _, ok := r.store.data.comments[comment.ID]; ok {
+		return domain.ErrConflict
+	}
+	r.store.data.comments[comment.ID] = *comment
+	return nil
+}
+
+func (r *CommentRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Comment, error) {
+	defer r.store.lock(ctx)()
+
+	c, ok := r.store.data.comments[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &c, nil
+}
+
+func (r *CommentRepo) ListByTask(ctx context.Context, taskID uuid.UUID) ([]*domain.Comment, error) {
+	defer r.store.lock(ctx)()
+
+	return collect(r.store.data.comments,
+		func(c domain.Comment) bool { return c.TaskID == taskID },
+		func(a, b *domain.Comment) int {
+			if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+				return c
+			}
+			return compareIDs(a.ID, b.ID)
+		},
+	), nil
+}
+
+func (r *CommentRepo) Update(ctx context.Context, comment *domain.Comment) error {
+	defer r.store.lock(ctx)()
+
+	c, ok := r.store.data.comments[comment.ID]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	c.Content = comment.Content
+	c.UpdatedAt = comment.UpdatedAt
+	r.store.data.comments[comment.ID] = c
+	return nil
+}
+
+func (r *CommentRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	defer r.store.lock(ctx)()
+
+	if _, ok := r.store.data.comments[id]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(r.store.data.comments, id)
+	return nil
+}
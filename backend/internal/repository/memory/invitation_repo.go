@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type InvitationRepo struct {
+	store *Store
+}
+
+func NewInvitationRepo(store *Store) *InvitationRepo {
+	return &InvitationRepo{store: store}
+}
+
+func (r *InvitationRepo) Create(ctx context.Context, inv *domain.Invitation) error {
+	defer r.store.lock(ctx)()
+	invitations := r.store.data.invitations
+
+	if _, ok := invitations[inv.ID]; ok {
+		return domain.ErrConflict
+	}
+	for _, i := range invitations {
+		if i.TokenHash == inv.TokenHash {
+			return domain.ErrConflict
+		}
+	}
+	invitations[inv.ID] = *inv
+	return nil
+}
+
+func (r *InvitationRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Invitation, error) {
+	defer r.store.lock(ctx)()
+
+	inv, ok := r.store.data.invitations[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &inv, nil
+}
+
+func (r *InvitationRepo) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error) {
+	defer r.store.lock(ctx)()
+
+	for _, inv := range r.store.data.invitations {
+		if inv.TokenHash == tokenHash {
+			return &inv, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *InvitationRepo) ListPendingByProject(ctx context.Context, projectID uuid.UUID) ([]*domain.Invitation, error) {
+	defer r.store.lock(ctx)()
+
+	now := time.Now()
+	return collect(r.store.data.invitations,
+		func(inv domain.Invitation) bool {
+			return inv.ProjectID == projectID && inv.AcceptedAt == nil && inv.ExpiresAt.After(now)
+		},
+		func(a, b *domain.Invitation) int {
+			if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+				return c
+			}
+			return compareIDs(a.ID, b.ID)
+		},
+	), nil
+}
+
+func (r *InvitationRepo) MarkAccepted(ctx context.Context, id uuid.UUID, acceptedAt time.Time) error {
+	defer r.store.lock(ctx)()
+
+	inv, ok := r.store.data.invitations[id]
+	if !ok || inv.AcceptedAt != nil {
+		return domain.ErrNotFound
+	}
+	inv.AcceptedAt = &acceptedAt
+	r.store.data.invitations[id] = inv
+	return nil
+}
+
+func (r *InvitationRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	defer r.store.lock(ctx)()
+
+	if _, ok := r.store.data.invitations[id]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(r.store.data.invitations, id)
+	return nil
+}
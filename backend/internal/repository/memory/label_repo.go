@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type LabelRepo struct {
+	store *Store
+}
+
+func NewLabelRepo(store *Store) *LabelRepo {
+	return &LabelRepo{store: store}
+}
+
+func (r *LabelRepo) Create(ctx context.Context, label *domain.Label) error {
+	defer r.store.lock(ctx)()
+
+	if _, ok := r.store.data.labels[label.ID]; ok {
+		return domain.ErrConflict
+	}
+	r.store.data.labels[label.ID] = *label
+	return nil
+}
+
+func (r *LabelRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Label, error) {
+	defer r.store.lock(ctx)()
+
+	l, ok := r.store.data.labels[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &l, nil
+}
+
+func (r *LabelRepo) ListByProject(ctx context.Context, projectID uuid.UUID) ([]*domain.Label, error) {
+	defer r.store.lock(ctx)()
+
+	return collect(r.store.data.labels,
+		func(l domain.Label) bool { return l.ProjectID == projectID },
+		byLabelName,
+	), nil
+}
+
+func (r *LabelRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	defer r.store.lock(ctx)()
+
+	if _, ok := r.store.data.labels[id]; !ok {
+		return domain.ErrNotFound
+	}
+	r.store.data.deleteLabel(id)
+	return nil
+}
+
+func (r *LabelRepo) AddToTask(ctx context.Context, taskID, labelID uuid.UUID) error {
+	defer r.store.lock(ctx)()
+
+	r.store.data.taskLabels[pair{taskID, labelID}] = struct{}{}
+	return nil
+}
+
+func (r *LabelRepo) RemoveFromTask(ctx context.Context, taskID, labelID uuid.UUID) error {
+	defer r.store.lock(ctx)()
+
+	delete(r.store.data.taskLabels, pair{taskID, labelID})
+	return nil
+}
+
+func (r *LabelRepo) ListByTask(ctx context.Context, taskID uuid.UUID) ([]*domain.Label, error) {
+	defer r.store.lock(ctx)()
+	data := r.store.data
+
+	return collect(data.labels,
+		func(l domain.Label) bool {
+			_, ok := data.taskLabels[pair{taskID, l.ID}]
+			return ok
+		},
+		byLabelName,
+	), nil
+}
+
+func byLabelName(a, b *domain.Label) int {
+	if c := strings.Compare(a.Name, b.Name); c != 0 {
+		return c
+	}
+	return compareIDs(a.ID, b.ID)
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type LoginThrottleRepo struct {
+	store *Store
+}
+
+func NewLoginThrottleRepo(store *Store) *LoginThrottleRepo {
+	return &LoginThrottleRepo{store: store}
+}
+
+func (r *LoginThrottleRepo) Get(ctx context.Context, key string) (*domain.LoginThrottle, error) {
+	defer r.store.lock(ctx)()
+
+	t, ok := r.store.data.throttles[key]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &t, nil
+}
+
+// RecordFailure counts a failure at at. A key whose last failure is older
+// than windowStart starts over at one.
+func (r *LoginThrottleRepo) RecordFailure(ctx context.Context, key string, at, windowStart time.Time) (*domain.LoginThrottle, error) {
+	defer r.store.lock(ctx)()
+
+	t, ok := r.store.data.throttles[key]
+	if !ok || t.LastFailureAt.Before(windowStart) {
+		t = domain.LoginThrottle{Key: key}
+	}
+	t.Failures++
+	t.LastFailureAt = at
+	r.store.data.throttles[key] = t
+	return &t, nil
+}
+
+func (r *LoginThrottleRepo) Delete(ctx context.Context, key string) error {
+	defer r.store.lock(ctx)()
+
+	delete(r.store.data.throttles, key)
+	return nil
+}
+
+func (r *LoginThrottleRepo) DeleteStale(ctx context.Context, before time.Time) (int64, error) {
+	defer r.store.lock(ctx)()
+
+	var n int64
+	for key, t := range r.store.data.throttles {
+		if t.LastFailureAt.Before(before) {
+			delete(r.store.data.throttles, key)
+			n++
+		}
+	}
+	return n, nil
+}
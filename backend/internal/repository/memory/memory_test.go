@@ -0,0 +1,31 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/letyshub/project-management/internal/repository/repotest"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		store := NewStore()
+		return repotest.Repositories{
+			Users:          NewUserRepo(store),
+			RefreshTokens:  NewRefreshTokenRepo(store),
+			AccessTokens:   NewPersonalAccessTokenRepo(store),
+			Organizations:  NewOrganizationRepo(store),
+			OrgMembers:     NewOrganizationMemberRepo(store),
+			Projects:       NewProjectRepo(store),
+			ProjectMembers: NewProjectMemberRepo(store),
+			Boards:         NewBoardRepo(store),
+			Columns:        NewColumnRepo(store),
+			Tasks:          NewTaskRepo(store),
+			Comments:       NewCommentRepo(store),
+			Labels:         NewLabelRepo(store),
+			AuditEvents:    NewAuditEventRepo(store),
+			Transitions:    NewTaskTransitionRepo(store),
+			TwoFactor:      NewTwoFactorRepo(store),
+			Tx:             NewTxManager(store),
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type OrganizationMemberRepo struct {
+	store *Store
+}
+
+func NewOrganizationMemberRepo(store *Store) *OrganizationMemberRepo {
+	return &OrganizationMemberRepo{store: store}
+}
+
+func (r *OrganizationMemberRepo) Create(ctx context.Context, member *domain.OrganizationMember) error {
+	defer r.store.lock(ctx)()
+
+	key := pair{member.OrganizationID, member.UserID}
+	if _, ok := r.store.data.orgMembers[key]; ok {
+		return domain.ErrConflict
+	}
+	m := *member
+	m.Email, m.Name = "", ""
+	r.store.data.orgMembers[key] = m
+	return nil
+}
+
+func (r *OrganizationMemberRepo) Get(ctx context.Context, orgID, userID uuid.UUID) (*domain.OrganizationMember, error) {
+	defer r.store.lock(ctx)()
+
+	m, ok := r.store.data.orgMembers[pair{orgID, userID}]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	r.withUser(&m)
+	return &m, nil
+}
+
+func (r *OrganizationMemberRepo) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.OrganizationMember, error) {
+	defer r.store.lock(ctx)()
+
+	members := collect(r.store.data.orgMembers,
+		func(m domain.OrganizationMember) bool { return m.OrganizationID == orgID },
+		func(a, b *domain.OrganizationMember) int {
+			if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+				return c
+			}
+			return compareIDs(a.UserID, b.UserID)
+		},
+	)
+	for _, m := range members {
+		r.withUser(m)
+	}
+	return members, nil
+}
+
+func (r *OrganizationMemberRepo) CountByRole(ctx context.Context, orgID uuid.UUID, role domain.OrgRole) (int, error) {
+	defer r.store.lock(ctx)()
+
+	n := 0
+	for _, m := range r.store.data.orgMembers {
+		if m.OrganizationID == orgID && m.Role == role {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *OrganizationMemberRepo) Update(ctx context.Context, member *domain.OrganizationMember) error {
+	defer r.store.lock(ctx)()
+
+	key := pair{member.OrganizationID, member.UserID}
+	m, ok := r.store.data.orgMembers[key]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	m.Role = member.Role
+	m.UpdatedAt = member.UpdatedAt
+	r.store.data.orgMembers[key] = m
+	return nil
+}
+
+// Delete also removes the user from every project in the organization.
+func (r *OrganizationMemberRepo) Delete(ctx context.Context, orgID, userID uuid.UUID) error {
+	defer r.store.lock(ctx)()
+	data := r.store.data
+
+	key := pair{orgID, userID}
+	if _, ok := data.orgMembers[key]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(data.orgMembers, key)
+
+	for k := range data.projectMembers {
+		if k[1] == userID && data.projects[k[0]].OrganizationID == orgID {
+			delete(data.projectMembers, k)
+		}
+	}
+	return nil
+}
+
+func (r *OrganizationMemberRepo) withUser(m *domain.OrganizationMember) {
+	u := r.store.data.users[m.UserID]
+	m.Email, m.Name = u.Email, u.Name
+}
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type OrganizationRepo struct {
+	store *Store
+}
+
+func NewOrganizationRepo(store *Store) *OrganizationRepo {
+	return &OrganizationRepo{store: store}
+}
+
+func (r *OrganizationRepo) Create(ctx context.Context, org *domain.Organization) error {
+	defer r.store.lock(ctx)()
+	orgs := r.store.data.organizations
+
+	if _, ok := orgs[org.ID]; ok {
+		return domain.ErrConflict
+	}
+	if org.PersonalOwnerID != nil {
+		for _, o := range orgs {
+			if o.PersonalOwnerID != nil && *o.PersonalOwnerID == *org.PersonalOwnerID {
+				return domain.ErrConflict
+			}
+		}
+	}
+	orgs[org.ID] = *org
+	return nil
+}
+
+func (r *OrganizationRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	defer r.store.lock(ctx)()
+
+	o, ok := r.store.data.organizations[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &o, nil
+}
+
+func (r *OrganizationRepo) GetPersonal(ctx context.Context, userID uuid.UUID) (*domain.Organization, error) {
+	defer r.store.lock(ctx)()
+
+	for _, o := range r.store.data.organizations {
+		if o.PersonalOwnerID != nil && *o.PersonalOwnerID == userID {
+			return &o, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *OrganizationRepo) ListByMember(ctx context.Context, userID uuid.UUID) ([]*domain.Organization, error) {
+	defer r.store.lock(ctx)()
+	data := r.store.data
+
+	personal := func(o *domain.Organization) bool {
+		return o.PersonalOwnerID != nil && *o.PersonalOwnerID == userID
+	}
+	return collect(data.organizations,
+		func(o domain.Organization) bool {
+			_, ok := data.orgMembers[pair{o.ID, userID}]
+			return ok
+		},
+		func(a, b *domain.Organization) int {
+			if pa, pb := personal(a), personal(b); pa != pb {
+				if pa {
+					return -1
+				}
+				return 1
+			}
+			if c := strings.Compare(a.Name, b.Name); c != 0 {
+				return c
+			}
+			return compareIDs(a.ID, b.ID)
+		},
+	), nil
+}
+
+func (r *OrganizationRepo) Update(ctx context.Context, org *domain.Organization) error {
+	defer r.store.lock(ctx)()
+
+	o, ok := r.store.data.organizations[org.ID]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	o.Name = org.Name
+	o.UpdatedAt = org.UpdatedAt
+	r.store.data.organizations[org.ID] = o
+	return nil
+}
+
+func (r *OrganizationRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	defer r.store.lock(ctx)()
+
+	if _, ok := r.store.data.organizations[id]; !ok {
+		return domain.ErrNotFound
+	}
+	r.store.data.deleteOrganization(id)
+	return nil
+}
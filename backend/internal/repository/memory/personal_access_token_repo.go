@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type PersonalAccessTokenRepo struct {
+	store *Store
+}
+
+func NewPersonalAccessTokenRepo(store *Store) *PersonalAccessTokenRepo {
+	return &PersonalAccessTokenRepo{store: store}
+}
+
+func (r *PersonalAccessTokenRepo) Create(ctx context.Context, t *domain.PersonalAccessToken) error {
+	defer r.store.lock(ctx)()
+	tokens := r.store.data.accessTokens
+
+	if _, ok := tokens[t.ID]; ok {
+		return domain.ErrConflict
+	}
+	for _, existing := range tokens {
+		if existing.TokenHash == t.TokenHash {
+			return domain.ErrConflict
+		}
+	}
+	tokens[t.ID] = *t
+	return nil
+}
+
+func (r *PersonalAccessTokenRepo) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.PersonalAccessToken, error) {
+	defer r.store.lock(ctx)()
+
+	for _, t := range r.store.data.accessTokens {
+		if t.TokenHash == tokenHash {
+			return &t, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *PersonalAccessTokenRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.PersonalAccessToken, error) {
+	defer r.store.lock(ctx)()
+
+	return collect(r.store.data.accessTokens,
+		func(t domain.PersonalAccessToken) bool { return t.UserID == userID },
+		func(a, b *domain.PersonalAccessToken) int {
+			if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+				return c
+			}
+			return compareIDs(a.ID, b.ID)
+		},
+	), nil
+}
+
+func (r *PersonalAccessTokenRepo) UpdateLastUsed(ctx context.Context, id uuid.UUID, lastUsedAt time.Time) error {
+	defer r.store.lock(ctx)()
+
+	if t, ok := r.store.data.accessTokens[id]; ok {
+		t.LastUsedAt = &lastUsedAt
+		r.store.data.accessTokens[id] = t
+	}
+	return nil
+}
+
+func (r *PersonalAccessTokenRepo) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	defer r.store.lock(ctx)()
+
+	t, ok := r.store.data.accessTokens[id]
+	if !ok || t.UserID != userID {
+		return domain.ErrNotFound
+	}
+	delete(r.store.data.accessTokens, id)
+	return nil
+}
+
+func (r *PersonalAccessTokenRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	defer r.store.lock(ctx)()
+
+	for id, t := range r.store.data.accessTokens {
+		if t.UserID == userID {
+			delete(r.store.data.accessTokens, id)
+		}
+	}
+	return nil
+}
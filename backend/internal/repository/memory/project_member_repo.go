@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type ProjectMemberRepo struct {
+	store *Store
+}
+
+func NewProjectMemberRepo(store *Store) *ProjectMemberRepo {
+	return &ProjectMemberRepo{store: store}
+}
+
+func (r *ProjectMemberRepo) Create(ctx context.Context, member *domain.ProjectMember) error {
+	defer r.store.lock(ctx)()
+
+	key := pair{member.ProjectID, member.UserID}
+	if _, ok := r.store.data.projectMembers[key]; ok {
+		return domain.ErrConflict
+	}
+	m := *member
+	m.Email, m.Name = "", ""
+	r.store.data.projectMembers[key] = m
+	return nil
+}
+
+func (r *ProjectMemberRepo) Get(ctx context.Context, projectID, userID uuid.UUID) (*domain.ProjectMember, error) {
+	defer r.store.lock(ctx)()
+
+	m, ok := r.store.data.projectMembers[pair{projectID, userID}]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	r.withUser(&m)
+	return &m, nil
+}
+
+func (r *ProjectMemberRepo) ListByProject(ctx context.Context, projectID uuid.UUID) ([]*domain.ProjectMember, error) {
+	defer r.store.lock(ctx)()
+
+	members := collect(r.store.data.projectMembers,
+		func(m domain.ProjectMember) bool { return m.ProjectID == projectID },
+		func(a, b *domain.ProjectMember) int {
+			if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+				return c
+			}
+			return compareIDs(a.UserID, b.UserID)
+		},
+	)
+	for _, m := range members {
+		r.withUser(m)
+	}
+	return members, nil
+}
+
+func (r *ProjectMemberRepo) CountByRole(ctx context.Context, projectID uuid.UUID, role domain.ProjectRole) (int, error) {
+	defer r.store.lock(ctx)()
+
+	n := 0
+	for _, m := range r.store.data.projectMembers {
+		if m.ProjectID == projectID && m.Role == role {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *ProjectMemberRepo) Update(ctx context.Context, member *domain.ProjectMember) error {
+	defer r.store.lock(ctx)()
+
+	key := pair{member.ProjectID, member.UserID}
+	m, ok := r.store.data.projectMembers[key]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	m.Role = member.Role
+	m.UpdatedAt = member.UpdatedAt
+	r.store.data.projectMembers[key] = m
+	return nil
+}
+
+func (r *ProjectMemberRepo) Delete(ctx context.Context, projectID, userID uuid.UUID) error {
+	defer r.store.lock(ctx)()
+
+	key := pair{projectID, userID}
+	if _, ok := r.store.data.projectMembers[key]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(r.store.data.projectMembers, key)
+	return nil
+}
+
+func (r *ProjectMemberRepo) withUser(m *domain.ProjectMember) {
+	u := r.store.data.users[m.UserID]
+	m.Email, m.Name = u.Email, u.Name
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type ProjectRepo struct {
+	store *Store
+}
+
+func NewProjectRepo(store *Store) *ProjectRepo {
+	return &ProjectRepo{store: store}
+}
+
+func (r *ProjectRepo) Create(ctx context.Context, project *domain.Project) error {
+	defer r.store.lock(ctx)()
+
+	if _, ok := r.store.data.projects[project.ID]; ok {
+		return domain.ErrConflict
+	}
+	r.store.data.projects[project.ID] = *project
+	return nil
+}
+
+func (r *ProjectRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
+	defer r.store.lock(ctx)()
+
+	p, ok := r.store.data.projects[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &p, nil
+}
+
+func (r *ProjectRepo) ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*domain.Project, error) {
+	defer r.store.lock(ctx)()
+
+	return collect(r.store.data.projects,
+		func(p domain.Project) bool { return p.OwnerID == ownerID },
+		newestProjectFirst,
+	), nil
+}
+
+func (r *ProjectRepo) ListByMember(ctx context.Context, userID uuid.UUID) ([]*domain.Project, error) {
+	defer r.store.lock(ctx)()
+	data := r.store.data
+
+	return collect(data.projects,
+		func(p domain.Project) bool {
+			_, ok := data.projectMembers[pair{p.ID, userID}]
+			return ok
+		},
+		newestProjectFirst,
+	), nil
+}
+
+func (r *ProjectRepo) Update(ctx context.Context, project *domain.Project) error {
+	defer r.store.lock(ctx)()
+
+	p, ok := r.store.data.projects[project.ID]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	p.Name = project.Name
+	p.Description = project.Description
+	p.UpdatedAt = project.UpdatedAt
+	r.store.data.projects[project.ID] = p
+	return nil
+}
+
+func (r *ProjectRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	defer r.store.lock(ctx)()
+
+	if _, ok := r.store.data.projects[id]; !ok {
+		return domain.ErrNotFound
+	}
+	r.store.data.deleteProject(id)
+	return nil
+}
+
+func newestProjectFirst(a, b *domain.Project) int {
+	if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+		return c
+	}
+	return compareIDs(a.ID, b.ID)
+}
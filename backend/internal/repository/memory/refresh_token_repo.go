@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type RefreshTokenRepo struct {
+	store *Store
+}
+
+func NewRefreshTokenRepo(store *Store) *RefreshTokenRepo {
+	return &RefreshTokenRepo{store: store}
+}
+
+func (r *RefreshTokenRepo) Create(ctx context.Context, token *domain.RefreshToken) error {
+	defer r.store.lock(ctx)()
+	return r.insert(token)
+}
+
+func (r *RefreshTokenRepo) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	defer r.store.lock(ctx)()
+
+	for _, t := range r.store.data.refreshTokens {
+		if t.TokenHash == tokenHash {
+			return &t, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *RefreshTokenRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.RefreshToken, error) {
+	defer r.store.lock(ctx)()
+
+	now := time.Now()
+	return collect(r.store.data.refreshTokens,
+		func(t domain.RefreshToken) bool {
+			return t.UserID == userID && t.RotatedAt == nil && t.ExpiresAt.After(now)
+		},
+		func(a, b *domain.RefreshToken) int {
+			if c := b.LastUsedAt.Compare(a.LastUsedAt); c != 0 {
+				return c
+			}
+			return compareIDs(a.ID, b.ID)
+		},
+	), nil
+}
+
+func (r *RefreshTokenRepo) Rotate(ctx context.Context, oldID uuid.UUID, rotatedAt time.Time, next *domain.RefreshToken) error {
+	defer r.store.lock(ctx)()
+	tokens := r.store.data.refreshTokens
+
+	old, ok := tokens[oldID]
+	if !ok || old.RotatedAt != nil {
+		return domain.ErrNotFound
+	}
+	if _, ok := tokens[next.ID]; ok {
+		return domain.ErrConflict
+	}
+	old.RotatedAt = &rotatedAt
+	tokens[oldID] = old
+	return r.insert(next)
+}
+
+func (r *RefreshTokenRepo) DeleteFamily(ctx context.Context, userID, familyID uuid.UUID) error {
+	defer r.store.lock(ctx)()
+
+	n := r.deleteWhere(func(t domain.RefreshToken) bool {
+		return t.FamilyID == familyID && t.UserID == userID
+	})
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	defer r.store.lock(ctx)()
+
+	r.deleteWhere(func(t domain.RefreshToken) bool { return t.UserID == userID })
+	return nil
+}
+
+func (r *RefreshTokenRepo) DeleteOthers(ctx context.Context, userID, keepFamilyID uuid.UUID) error {
+	defer r.store.lock(ctx)()
+
+	r.deleteWhere(func(t domain.RefreshToken) bool {
+		return t.UserID == userID && t.FamilyID != keepFamilyID
+	})
+	return nil
+}
+
+func (r *RefreshTokenRepo) DeleteByTokenHash(ctx context.Context, tokenHash string) error {
+	defer r.store.lock(ctx)()
+
+	families := map[uuid.UUID]bool{}
+	for _, t := range r.store.data.refreshTokens {
+		if t.TokenHash == tokenHash {
+			families[t.FamilyID] = true
+		}
+	}
+	r.deleteWhere(func(t domain.RefreshToken) bool { return families[t.FamilyID] })
+	return nil
+}
+
+func (r *RefreshTokenRepo) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	defer r.store.lock(ctx)()
+
+	return r.deleteWhere(func(t domain.RefreshToken) bool { return t.ExpiresAt.Before(before) }), nil
+}
+
+func (r *RefreshTokenRepo) insert(token *domain.RefreshToken) error {
+	if _, ok := r.store.data.refreshTokens[token.ID]; ok {
+		return domain.ErrConflict
+	}
+	r.store.data.refreshTokens[token.ID] = *token
+	return nil
+}
+
+func (r *RefreshTokenRepo) deleteWhere(match func(t domain.RefreshToken) bool) int64 {
+	var n int64
+	for id, t := range r.store.data.refreshTokens {
+		if match(t) {
+			delete(r.store.data.refreshTokens, id)
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,25 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type SecurityEventRepo struct {
+	store *Store
+}
+
+func NewSecurityEventRepo(store *Store) *SecurityEventRepo {
+	return &SecurityEventRepo{store: store}
+}
+
+func (r *SecurityEventRepo) Create(ctx context.Context, event *domain.SecurityEvent) error {
+	defer r.store.lock(ctx)()
+
+	if _, ok := r.store.data.securityEvents[event.ID]; ok {
+		return domain.ErrConflict
+	}
+	r.store.data.securityEvents[event.ID] = *event
+	return nil
+}
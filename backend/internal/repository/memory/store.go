@@ -0,0 +1,249 @@
+// Package memory implements the domain repositories on top of in-process
+// maps. It backs STORAGE=memory and the repository conformance tests, and
+// mirrors the postgres package's semantics, including its cascading deletes.
+package memory
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+// pair keys the join tables: (project, user), (organization, user) and
+// (task, label).
+type pair [2]uuid.UUID
+
+type recoveryCode struct {
+	UserID   uuid.UUID
+	CodeHash string
+	Used     bool
+}
+
+type tables struct {
+	users          map[uuid.UUID]domain.User
+	refreshTokens  map[uuid.UUID]domain.RefreshToken
+	organizations  map[uuid.UUID]domain.Organization
+	orgMembers     map[pair]domain.OrganizationMember
+	projects       map[uuid.UUID]domain.Project
+	projectMembers map[pair]domain.ProjectMember
+	boards         map[uuid.UUID]domain.Board
+	columns        map[uuid.UUID]domain.Column
+	tasks          map[uuid.UUID]domain.Task
+	comments       map[uuid.UUID]domain.Comment
+	labels         map[uuid.UUID]domain.Label
+	taskLabels     map[pair]struct{}
+	invitations    map[uuid.UUID]domain.Invitation
+	userTokens     map[uuid.UUID]domain.UserToken
+	accessTokens   map[uuid.UUID]domain.PersonalAccessToken
+	identities     map[uuid.UUID]domain.UserIdentity
+	totp           map[uuid.UUID]domain.TOTPEnrollment
+	recoveryCodes  map[uuid.UUID]recoveryCode
+	challenges     map[uuid.UUID]domain.TwoFactorChallenge
+	throttles      map[string]domain.LoginThrottle
+	securityEvents map[uuid.UUID]domain.SecurityEvent
+	auditEvents    map[uuid.UUID]domain.AuditEvent
+	transitions    map[uuid.UUID]domain.TaskTransition
+}
+
+func newTables() *tables {
+	return &tables{
+		users:          map[uuid.UUID]domain.User{},
+		refreshTokens:  map[uuid.UUID]domain.RefreshToken{},
+		organizations:  map[uuid.UUID]domain.Organization{},
+		orgMembers:     map[pair]domain.OrganizationMember{},
+		projects:       map[uuid.UUID]domain.Project{},
+		projectMembers: map[pair]domain.ProjectMember{},
+		boards:         map[uuid.UUID]domain.Board{},
+		columns:        map[uuid.UUID]domain.Column{},
+		tasks:          map[uuid.UUID]domain.Task{},
+		comments:       map[uuid.UUID]domain.Comment{},
+		labels:         map[uuid.UUID]domain.Label{},
+		taskLabels:     map[pair]struct{}{},
+		invitations:    map[uuid.UUID]domain.Invitation{},
+		userTokens:     map[uuid.UUID]domain.UserToken{},
+		accessTokens:   map[uuid.UUID]domain.PersonalAccessToken{},
+		identities:     map[uuid.UUID]domain.UserIdentity{},
+		totp:           map[uuid.UUID]domain.TOTPEnrollment{},
+		recoveryCodes:  map[uuid.UUID]recoveryCode{},
+		challenges:     map[uuid.UUID]domain.TwoFactorChallenge{},
+		throttles:      map[string]domain.LoginThrottle{},
+		securityEvents: map[uuid.UUID]domain.SecurityEvent{},
+		auditEvents:    map[uuid.UUID]domain.AuditEvent{},
+		transitions:    map[uuid.UUID]domain.TaskTransition{},
+	}
+}
+
+// clone copies every table. Rows are stored by value and pointer fields are
+// only ever replaced, never written through, so a shallow copy is enough for
+// a rollback snapshot.
+func (t *tables) clone() *tables {
+	return &tables{
+		users:          maps.Clone(t.users),
+		refreshTokens:  maps.Clone(t.refreshTokens),
+		organizations:  maps.Clone(t.organizations),
+		orgMembers:     maps.Clone(t.orgMembers),
+		projects:       maps.Clone(t.projects),
+		projectMembers: maps.Clone(t.projectMembers),
+		boards:         maps.Clone(t.boards),
+		columns:        maps.Clone(t.columns),
+		tasks:          maps.Clone(t.tasks),
+		comments:       maps.Clone(t.comments),
+		labels:         maps.Clone(t.labels),
+		taskLabels:     maps.Clone(t.taskLabels),
+		invitations:    maps.Clone(t.invitations),
+		userTokens:     maps.Clone(t.userTokens),
+		accessTokens:   maps.Clone(t.accessTokens),
+		identities:     maps.Clone(t.identities),
+		totp:           maps.Clone(t.totp),
+		recoveryCodes:  maps.Clone(t.recoveryCodes),
+		challenges:     maps.Clone(t.challenges),
+		throttles:      maps.Clone(t.throttles),
+		securityEvents: maps.Clone(t.securityEvents),
+		auditEvents:    maps.Clone(t.auditEvents),
+		transitions:    maps.Clone(t.transitions),
+	}
+}
+
+// The delete helpers below follow the ON DELETE CASCADE chain of the
+// postgres schema.
+
+func (t *tables) deleteOrganization(id uuid.UUID) {
+	delete(t.organizations, id)
+	for k := range t.orgMembers {
+		if k[0] == id {
+			delete(t.orgMembers, k)
+		}
+	}
+	for pid, p := range t.projects {
+		if p.OrganizationID == id {
+			t.deleteProject(pid)
+		}
+	}
+}
+
+func (t *tables) deleteProject(id uuid.UUID) {
+	delete(t.projects, id)
+	for k := range t.projectMembers {
+		if k[0] == id {
+			delete(t.projectMembers, k)
+		}
+	}
+	for bid, b := range t.boards {
+		if b.ProjectID == id {
+			t.deleteBoard(bid)
+		}
+	}
+	for lid, l := range t.labels {
+		if l.ProjectID == id {
+			t.deleteLabel(lid)
+		}
+	}
+	for iid, inv := range t.invitations {
+		if inv.ProjectID == id {
+			delete(t.invitations, iid)
+		}
+	}
+	for tid, tok := range t.accessTokens {
+		if tok.ProjectID != nil && *tok.ProjectID == id {
+			delete(t.accessTokens, tid)
+		}
+	}
+}
+
+func (t *tables) deleteBoard(id uuid.UUID) {
+	delete(t.boards, id)
+	for cid, c := range t.columns {
+		if c.BoardID == id {
+			t.deleteColumn(cid)
+		}
+	}
+}
+
+func (t *tables) deleteColumn(id uuid.UUID) {
+	delete(t.columns, id)
+	for tid, task := range t.tasks {
+		if task.ColumnID == id {
+			t.deleteTask(tid)
+		}
+	}
+}
+
+func (t *tables) deleteTask(id uuid.UUID) {
+	delete(t.tasks, id)
+	for cid, c := range t.comments {
+		if c.TaskID == id {
+			delete(t.comments, cid)
+		}
+	}
+	for k := range t.taskLabels {
+		if k[0] == id {
+			delete(t.taskLabels, k)
+		}
+	}
+	for tid, tr := range t.transitions {
+		if tr.TaskID == id {
+			delete(t.transitions, tid)
+		}
+	}
+}
+
+func (t *tables) deleteLabel(id uuid.UUID) {
+	delete(t.labels, id)
+	for k := range t.taskLabels {
+		if k[1] == id {
+			delete(t.taskLabels, k)
+		}
+	}
+}
+
+// collect returns copies of the rows that match keep, sorted with cmp.
+// Callers break ties on the row ID so results don't depend on map order.
+func collect[K comparable, V any](m map[K]V, keep func(V) bool, cmp func(a, b *V) int) []*V {
+	var out []*V
+	for _, v := range m {
+		if keep(v) {
+			out = append(out, &v)
+		}
+	}
+	slices.SortFunc(out, cmp)
+	return out
+}
+
+func compareIDs(a, b uuid.UUID) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+type txKey struct{}
+
+// Store holds every table. A single mutex serializes access; a unit of work
+// holds it for its whole duration, so repositories called with its context
+// skip locking.
+type Store struct {
+	mu   sync.Mutex
+	data *tables
+}
+
+func NewStore() *Store {
+	return &Store{data: newTables()}
+}
+
+func (s *Store) inTx(ctx context.Context) bool {
+	st, _ := ctx.Value(txKey{}).(*Store)
+	return st == s
+}
+
+// lock acquires the store for a single repository call and returns the
+// function that releases it.
+func (s *Store) lock(ctx context.Context) func() {
+	if s.inTx(ctx) {
+		return func() {}
+	}
+	s.mu.Lock()
+	return s.mu.Unlock
+}
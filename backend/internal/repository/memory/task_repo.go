@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type TaskRepo struct {
+	store *Store
+}
+
+func NewTaskRepo(store *Store) *TaskRepo {
+	return &TaskRepo{store: store}
+}
+
+func (r *TaskRepo) Create(ctx context.Context, task *domain.Task) error {
+	defer r.store.lock(ctx)()
+
+	if _, ok := r.store.data.tasks[task.ID]; ok {
+		return domain.ErrConflict
+	}
+	r.store.data.tasks[task.ID] = *task
+	return nil
+}
+
+func (r *TaskRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
+	defer r.store.lock(ctx)()
+
+	t, ok := r.store.data.tasks[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &t, nil
+}
+
+func (r *TaskRepo) ListByColumn(ctx context.Context, columnID uuid.UUID) ([]*domain.Task, error) {
+	defer r.store.lock(ctx)()
+
+	return collect(r.store.data.tasks,
+		func(t domain.Task) bool { return t.ColumnID == columnID },
+		byTaskPosition,
+	), nil
+}
+
+// ListByBoard ignores filter.BoardID, like the postgres implementation.
+func (r *TaskRepo) ListByBoard(ctx context.Context, boardID uuid.UUID, filter domain.TaskFilter) ([]*domain.Task, error) {
+	defer r.store.lock(ctx)()
+	data := r.store.data
+
+	return collect(data.tasks,
+		func(t domain.Task) bool {
+			col, ok := data.columns[t.ColumnID]
+			switch {
+			case !ok || col.BoardID != boardID:
+				return false
+			case filter.ColumnID != nil && t.ColumnID != *filter.ColumnID:
+				return false
+			case filter.Priority != nil && t.Priority != *filter.Priority:
+				return false
+			case filter.AssigneeID != nil && (t.AssigneeID == nil || *t.AssigneeID != *filter.AssigneeID):
+				return false
+			}
+			if filter.LabelID != nil {
+				if _, ok := data.taskLabels[pair{t.ID, *filter.LabelID}]; !ok {
+					return false
+				}
+			}
+			return true
+		},
+		byTaskPosition,
+	), nil
+}
+
+func (r *TaskRepo) Update(ctx context.Context, task *domain.Task) error {
+	defer r.store.lock(ctx)()
+
+	t, ok := r.store.data.tasks[task.ID]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	t.ColumnID = task.ColumnID
+	t.Title = task.Title
+	t.Description = task.Description
+	t.Priority = task.Priority
+	t.AssigneeID = task.AssigneeID
+	t.Position = task.Position
+	t.UpdatedAt = task.UpdatedAt
+	r.store.data.tasks[task.ID] = t
+	return nil
+}
+
+func (r *TaskRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	defer r.store.lock(ctx)()
+
+	if _, ok := r.store.data.tasks[id]; !ok {
+		return domain.ErrNotFound
+	}
+	r.store.data.deleteTask(id)
+	return nil
+}
+
+func byTaskPosition(a, b *domain.Task) int {
+	if c := cmp.Compare(a.Position, b.Position); c != 0 {
+		return c
+	}
+	return compareIDs(a.ID, b.ID)
+}
@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type TaskTransitionRepo struct {
+	store *Store
+}
+
+func NewTaskTransitionRepo(store *Store) *TaskTransitionRepo {
+	return &TaskTransitionRepo{store: store}
+}
+
+func (r *TaskTransitionRepo) Create(ctx context.Context, transition *domain.TaskTransition) error {
+	defer r.store.lock(ctx)()
+
+	if _, ok := r.store.data.transitions[transition.ID]; ok {
+		return domain.ErrConflict
+	}
+	r.store.data.transitions[transition.ID] = *transition
+	return nil
+}
+
+func (r *TaskTransitionRepo) ListByBoard(ctx context.Context, boardID uuid.UUID) ([]*domain.TaskTransition, error) {
+	defer r.store.lock(ctx)()
+
+	data := r.store.data
+	return collect(data.transitions,
+		func(tr domain.TaskTransition) bool {
+			task, ok := data.tasks[tr.TaskID]
+			return ok && data.columns[task.ColumnID].BoardID == boardID
+		},
+		func(a, b *domain.TaskTransition) int {
+			if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+				return c
+			}
+			return compareIDs(a.ID, b.ID)
+		},
+	), nil
+}
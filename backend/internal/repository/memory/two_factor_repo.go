@@ -0,0 +1,172 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type TwoFactorRepo struct {
+	store *Store
+}
+
+func NewTwoFactorRepo(store *Store) *TwoFactorRepo {
+	return &TwoFactorRepo{store: store}
+}
+
+// SaveTOTP starts a new, unconfirmed enrollment, replacing any existing one.
+func (r *TwoFactorRepo) SaveTOTP(ctx context.Context, e *domain.TOTPEnrollment) error {
+	defer r.store.lock(ctx)()
+
+	r.store.data.totp[e.UserID] = domain.TOTPEnrollment{
+		UserID:    e.UserID,
+		Secret:    e.Secret,
+		CreatedAt: e.CreatedAt,
+	}
+	return nil
+}
+
+func (r *TwoFactorRepo) GetTOTP(ctx context.Context, userID uuid.UUID) (*domain.TOTPEnrollment, error) {
+	defer r.store.lock(ctx)()
+
+	e, ok := r.store.data.totp[userID]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &e, nil
+}
+
+func (r *TwoFactorRepo) ConfirmTOTP(ctx context.Context, userID uuid.UUID, confirmedAt time.Time) error {
+	defer r.store.lock(ctx)()
+
+	e, ok := r.store.data.totp[userID]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	e.ConfirmedAt = &confirmedAt
+	r.store.data.totp[userID] = e
+	return nil
+}
+
+func (r *TwoFactorRepo) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	defer r.store.lock(ctx)()
+
+	e, ok := r.store.data.totp[userID]
+	if !ok || e.LastUsedStep >= step {
+		return domain.ErrConflict
+	}
+	e.LastUsedStep = step
+	r.store.data.totp[userID] = e
+	return nil
+}
+
+func (r *TwoFactorRepo) Delete(ctx context.Context, userID uuid.UUID) error {
+	defer r.store.lock(ctx)()
+	data := r.store.data
+
+	for id, c := range data.recoveryCodes {
+		if c.UserID == userID {
+			delete(data.recoveryCodes, id)
+		}
+	}
+	if _, ok := data.totp[userID]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(data.totp, userID)
+	return nil
+}
+
+func (r *TwoFactorRepo) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string, createdAt time.Time) error {
+	defer r.store.lock(ctx)()
+	codes := r.store.data.recoveryCodes
+
+	for id, c := range codes {
+		if c.UserID == userID {
+			delete(codes, id)
+		}
+	}
+	for _, hash := range codeHashes {
+		codes[uuid.New()] = recoveryCode{UserID: userID, CodeHash: hash}
+	}
+	return nil
+}
+
+func (r *TwoFactorRepo) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, usedAt time.Time) error {
+	defer r.store.lock(ctx)()
+	codes := r.store.data.recoveryCodes
+
+	found := false
+	for id, c := range codes {
+		if c.UserID == userID && c.CodeHash == codeHash && !c.Used {
+			c.Used = true
+			codes[id] = c
+			found = true
+		}
+	}
+	if !found {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *TwoFactorRepo) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	defer r.store.lock(ctx)()
+
+	n := 0
+	for _, c := range r.store.data.recoveryCodes {
+		if c.UserID == userID && !c.Used {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *TwoFactorRepo) CreateChallenge(ctx context.Context, c *domain.TwoFactorChallenge) error {
+	defer r.store.lock(ctx)()
+
+	if _, ok := r.store.data.challenges[c.ID]; ok {
+		return domain.ErrConflict
+	}
+	r.store.data.challenges[c.ID] = *c
+	return nil
+}
+
+func (r *TwoFactorRepo) UseChallengeAttempt(ctx context.Context, id uuid.UUID, maxAttempts int, at time.Time) error {
+	defer r.store.lock(ctx)()
+
+	c, ok := r.store.data.challenges[id]
+	if !ok || c.ConsumedAt != nil || !c.ExpiresAt.After(at) || c.Attempts >= maxAttempts {
+		return domain.ErrNotFound
+	}
+	c.Attempts++
+	r.store.data.challenges[id] = c
+	return nil
+}
+
+func (r *TwoFactorRepo) ConsumeChallenge(ctx context.Context, id uuid.UUID, at time.Time) error {
+	defer r.store.lock(ctx)()
+
+	c, ok := r.store.data.challenges[id]
+	if !ok || c.ConsumedAt != nil {
+		return domain.ErrNotFound
+	}
+	c.ConsumedAt = &at
+	r.store.data.challenges[id] = c
+	return nil
+}
+
+func (r *TwoFactorRepo) DeleteExpiredChallenges(ctx context.Context, before time.Time) (int64, error) {
+	defer r.store.lock(ctx)()
+
+	var n int64
+	for id, c := range r.store.data.challenges {
+		if c.ExpiresAt.Before(before) {
+			delete(r.store.data.challenges, id)
+			n++
+		}
+	}
+	return n, nil
+}
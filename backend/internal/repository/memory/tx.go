@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+)
+
+// TxManager implements domain.TxManager. A unit of work holds the store lock
+// until it finishes and restores a snapshot of every table if fn fails.
+type TxManager struct {
+	store *Store
+}
+
+func NewTxManager(store *Store) *TxManager {
+	return &TxManager{store: store}
+}
+
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if m.store.inTx(ctx) {
+		return fn(ctx)
+	}
+
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	snapshot := m.store.data.clone()
+	committed := false
+	defer func() {
+		if !committed {
+			m.store.data = snapshot
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, m.store)); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type UserIdentityRepo struct {
+	store *Store
+}
+
+func NewUserIdentityRepo(store *Store) *UserIdentityRepo {
+	return &UserIdentityRepo{store: store}
+}
+
+func (r *UserIdentityRepo) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	defer r.store.lock(ctx)()
+	identities := r.store.data.identities
+
+	if _, ok := identities[identity.ID]; ok {
+		return domain.ErrConflict
+	}
+	for _, i := range identities {
+		if i.Issuer == identity.Issuer && i.Subject == identity.Subject {
+			return domain.ErrConflict
+		}
+	}
+	identities[identity.ID] = *identity
+	return nil
+}
+
+func (r *UserIdentityRepo) GetBySubject(ctx context.Context, issuer, subject string) (*domain.UserIdentity, error) {
+	defer r.store.lock(ctx)()
+
+	for _, i := range r.store.data.identities {
+		if i.Issuer == issuer && i.Subject == subject {
+			return &i, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
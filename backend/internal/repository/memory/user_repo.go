@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type UserRepo struct {
+	store *Store
+}
+
+func NewUserRepo(store *Store) *UserRepo {
+	return &UserRepo{store: store}
+}
+
+func (r *UserRepo) Create(ctx context.Context, user *domain.User) error {
+	defer r.store.lock(ctx)()
+	users := r.store.data.users
+
+	if _, ok := users[user.ID]; ok {
+		return domain.ErrConflict
+	}
+	for _, u := range users {
+		if u.Email == user.Email {
+			return domain.ErrConflict
+		}
+	}
+	users[user.ID] = *user
+	return nil
+}
+
+func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	defer r.store.lock(ctx)()
+
+	for _, u := range r.store.data.users {
+		if u.Email == email {
+			return &u, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *UserRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	defer r.store.lock(ctx)()
+
+	u, ok := r.store.data.users[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &u, nil
+}
+
+func (r *UserRepo) Update(ctx context.Context, user *domain.User) error {
+	defer r.store.lock(ctx)()
+
+	if u, ok := r.store.data.users[user.ID]; ok {
+		u.Name = user.Name
+		u.UpdatedAt = user.UpdatedAt
+		r.store.data.users[user.ID] = u
+	}
+	return nil
+}
+
+func (r *UserRepo) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string, updatedAt time.Time) error {
+	return r.update(ctx, id, func(u *domain.User) {
+		u.PasswordHash = passwordHash
+		u.UpdatedAt = updatedAt
+	})
+}
+
+func (r *UserRepo) MarkEmailVerified(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error {
+	return r.update(ctx, id, func(u *domain.User) {
+		u.EmailVerifiedAt = &verifiedAt
+		u.UpdatedAt = verifiedAt
+	})
+}
+
+func (r *UserRepo) List(ctx context.Context, filter domain.UserFilter) ([]*domain.User, error) {
+	defer r.store.lock(ctx)()
+
+	q := strings.ToLower(filter.Query)
+	users := collect(r.store.data.users,
+		func(u domain.User) bool {
+			return q == "" ||
+				strings.Contains(strings.ToLower(u.Email), q) ||
+				strings.Contains(strings.ToLower(u.Name), q)
+		},
+		func(a, b *domain.User) int {
+			if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+				return c
+			}
+			return compareIDs(a.ID, b.ID)
+		},
+	)
+
+	if filter.Offset >= len(users) {
+		return nil, nil
+	}
+	users = users[filter.Offset:]
+	if filter.Limit < len(users) {
+		users = users[:filter.Limit]
+	}
+	if len(users) == 0 {
+		return nil, nil
+	}
+	return users, nil
+}
+
+func (r *UserRepo) UpdateRole(ctx context.Context, id uuid.UUID, role string, updatedAt time.Time) error {
+	return r.update(ctx, id, func(u *domain.User) {
+		u.Role = role
+		u.UpdatedAt = updatedAt
+	})
+}
+
+func (r *UserRepo) SetDeactivated(ctx context.Context, id uuid.UUID, deactivatedAt *time.Time, updatedAt time.Time) error {
+	return r.update(ctx, id, func(u *domain.User) {
+		u.DeactivatedAt = deactivatedAt
+		u.UpdatedAt = updatedAt
+	})
+}
+
+func (r *UserRepo) Stats(ctx context.Context) (*domain.SystemStats, error) {
+	defer r.store.lock(ctx)()
+	data := r.store.data
+
+	stats := &domain.SystemStats{
+		Users:    len(data.users),
+		Projects: len(data.projects),
+		Boards:   len(data.boards),
+		Tasks:    len(data.tasks),
+	}
+	for _, u := range data.users {
+		if u.DeactivatedAt != nil {
+			stats.DeactivatedUsers++
+		}
+		if u.Role == domain.UserRoleAdmin {
+			stats.Admins++
+		}
+	}
+	return stats, nil
+}
+
+func (r *UserRepo) update(ctx context.Context, id uuid.UUID, fn func(u *domain.User)) error {
+	defer r.store.lock(ctx)()
+
+	u, ok := r.store.data.users[id]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	fn(&u)
+	r.store.data.users[id] = u
+	return nil
+}
@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type UserTokenRepo struct {
+	store *Store
+}
+
+func NewUserTokenRepo(store *Store) *UserTokenRepo {
+	return &UserTokenRepo{store: store}
+}
+
+func (r *UserTokenRepo) Create(ctx context.Context, token *domain.UserToken) error {
+	defer r.store.lock(ctx)()
+	tokens := r.store.data.userTokens
+
+	if _, ok := tokens[token.ID]; ok {
+		return domain.ErrConflict
+	}
+	for _, t := range tokens {
+		if t.TokenHash == token.TokenHash {
+			return domain.ErrConflict
+		}
+	}
+	tokens[token.ID] = *token
+	return nil
+}
+
+func (r *UserTokenRepo) GetByTokenHash(ctx context.Context, purpose, tokenHash string) (*domain.UserToken, error) {
+	defer r.store.lock(ctx)()
+
+	for _, t := range r.store.data.userTokens {
+		if t.Purpose == purpose && t.TokenHash == tokenHash {
+			return &t, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *UserTokenRepo) GetLatest(ctx context.Context, userID uuid.UUID, purpose string) (*domain.UserToken, error) {
+	defer r.store.lock(ctx)()
+
+	tokens := collect(r.store.data.userTokens,
+		func(t domain.UserToken) bool { return t.UserID == userID && t.Purpose == purpose },
+		func(a, b *domain.UserToken) int {
+			if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+				return c
+			}
+			return compareIDs(a.ID, b.ID)
+		},
+	)
+	if len(tokens) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return tokens[0], nil
+}
+
+func (r *UserTokenRepo) MarkUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	defer r.store.lock(ctx)()
+
+	t, ok := r.store.data.userTokens[id]
+	if !ok || t.UsedAt != nil {
+		return domain.ErrNotFound
+	}
+	t.UsedAt = &usedAt
+	r.store.data.userTokens[id] = t
+	return nil
+}
+
+func (r *UserTokenRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID, purpose string) error {
+	defer r.store.lock(ctx)()
+
+	for id, t := range r.store.data.userTokens {
+		if t.UserID == userID && t.Purpose == purpose {
+			delete(r.store.data.userTokens, id)
+		}
+	}
+	return nil
+}
@@ -24,7 +24,7 @@ func (r *BoardRepo) Create(ctx context.Context, board *domain.Board) error {
 		INSERT INTO boards (id, project_id, name, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5)`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
 		board.ID, board.ProjectID, board.Name, board.CreatedAt, board.UpdatedAt,
 	)
 	return err
@@ -36,7 +36,7 @@ func (r *BoardRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Board, e
 		FROM boards WHERE id = $1`
 
 	b := &domain.Board{}
-	err := r.pool.QueryRow(ctx, query, id).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(
 		&b.ID, &b.ProjectID, &b.Name, &b.CreatedAt, &b.UpdatedAt,
 	)
 	if err != nil {
@@ -54,7 +54,7 @@ func (r *BoardRepo) ListByProject(ctx context.Context, projectID uuid.UUID) ([]*
 		FROM boards WHERE project_id = $1
 		ORDER BY created_at ASC`
 
-	rows, err := r.pool.Query(ctx, query, projectID)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, projectID)
 	if err != nil {
 		return nil, err
 	}
@@ -74,7 +74,7 @@ func (r *BoardRepo) ListByProject(ctx context.Context, projectID uuid.UUID) ([]*
 func (r *BoardRepo) Update(ctx context.Context, board *domain.Board) error {
 	query := `UPDATE boards SET name = $1, updated_at = $2 WHERE id = $3`
 
-	tag, err := r.pool.Exec(ctx, query, board.Name, board.UpdatedAt, board.ID)
+	tag, err := conn(ctx, r.pool).Exec(ctx, query, board.Name, board.UpdatedAt, board.ID)
 	if err != nil {
 		return err
 	}
@@ -85,7 +85,7 @@ func (r *BoardRepo) Update(ctx context.Context, board *domain.Board) error {
 }
 
 func (r *BoardRepo) Delete(ctx context.Context, id uuid.UUID) error {
-	tag, err := r.pool.Exec(ctx, `DELETE FROM boards WHERE id = $1`, id)
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM boards WHERE id = $1`, id)
 	if err != nil {
 		return err
 	}
@@ -21,23 +21,23 @@ func NewColumnRepo(pool *pgxpool.Pool) *ColumnRepo {
 
 func (r *ColumnRepo) Create(ctx context.Context, col *domain.Column) error {
 	query := `
-		INSERT INTO columns (id, board_id, name, position, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)`
+		INSERT INTO columns (id, board_id, name, position, category, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
 
-	_, err := r.pool.Exec(ctx, query,
-		col.ID, col.BoardID, col.Name, col.Position, col.CreatedAt, col.UpdatedAt,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		col.ID, col.BoardID, col.Name, col.Position, col.Category, col.CreatedAt, col.UpdatedAt,
 	)
 	return err
 }
 
 func (r *ColumnRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Column, error) {
 	query := `
-		SELECT id, board_id, name, position, created_at, updated_at
+		SELECT id, board_id, name, position, category, created_at, updated_at
 		FROM columns WHERE id = $1`
 
 	col := &domain.Column{}
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&col.ID, &col.BoardID, &col.Name, &col.Position, &col.CreatedAt, &col.UpdatedAt,
+	err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(
+		&col.ID, &col.BoardID, &col.Name, &col.Position, &col.Category, &col.CreatedAt, &col.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -50,11 +50,11 @@ func (r *ColumnRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Column,
 
 func (r *ColumnRepo) ListByBoard(ctx context.Context, boardID uuid.UUID) ([]*domain.Column, error) {
 	query := `
-		SELECT id, board_id, name, position, created_at, updated_at
+		SELECT id, board_id, name, position, category, created_at, updated_at
 		FROM columns WHERE board_id = $1
 		ORDER BY position ASC`
 
-	rows, err := r.pool.Query(ctx, query, boardID)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, boardID)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +63,7 @@ func (r *ColumnRepo) ListByBoard(ctx context.Context, boardID uuid.UUID) ([]*dom
 	var columns []*domain.Column
 	for rows.Next() {
 		col := &domain.Column{}
-		if err := rows.Scan(&col.ID, &col.BoardID, &col.Name, &col.Position, &col.CreatedAt, &col.UpdatedAt); err != nil {
+		if err := rows.Scan(&col.ID, &col.BoardID, &col.Name, &col.Position, &col.Category, &col.CreatedAt, &col.UpdatedAt); err != nil {
 			return nil, err
 		}
 		columns = append(columns, col)
@@ -73,10 +73,10 @@ func (r *ColumnRepo) ListByBoard(ctx context.Context, boardID uuid.UUID) ([]*dom
 
 func (r *ColumnRepo) Update(ctx context.Context, col *domain.Column) error {
 	query := `
-		UPDATE columns SET name = $1, position = $2, updated_at = $3
-		WHERE id = $4`
+		UPDATE columns SET name = $1, position = $2, category = $3, updated_at = $4
+		WHERE id = $5`
 
-	tag, err := r.pool.Exec(ctx, query, col.Name, col.Position, col.UpdatedAt, col.ID)
+	tag, err := conn(ctx, r.pool).Exec(ctx, query, col.Name, col.Position, col.Category, col.UpdatedAt, col.ID)
 	if err != nil {
 		return err
 	}
@@ -87,7 +87,7 @@ func (r *ColumnRepo) Update(ctx context.Context, col *domain.Column) error {
 }
 
 func (r *ColumnRepo) Delete(ctx context.Context, id uuid.UUID) error {
-	tag, err := r.pool.Exec(ctx, `DELETE FROM columns WHERE id = $1`, id)
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM columns WHERE id = $1`, id)
 	if err != nil {
 		return err
 	}
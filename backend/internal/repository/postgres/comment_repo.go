@@ -23,7 +23,7 @@ func (r *CommentRepo) Create(ctx context.Context, comment *domain.Comment) error
 	query := `
 		INSERT INTO comments (id, task_id, author_id, content, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6)`
-	_, err := r.pool.Exec(ctx, query,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
 		comment.ID, comment.TaskID, comment.AuthorID,
 		comment.Content, comment.CreatedAt, comment.UpdatedAt,
 	)
@@ -35,7 +35,7 @@ func (r *CommentRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Commen
 		SELECT id, task_id, author_id, content, created_at, updated_at
 		FROM comments WHERE id = $1`
 	c := &domain.Comment{}
-	err := r.pool.QueryRow(ctx, query, id).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(
 		&c.ID, &c.TaskID, &c.AuthorID, &c.Content, &c.CreatedAt, &c.UpdatedAt,
 	)
 	if err != nil {
@@ -52,7 +52,7 @@ func (r *CommentRepo) ListByTask(ctx context.Context, taskID uuid.UUID) ([]*doma
 		SELECT id, task_id, author_id, content, created_at, updated_at
 		FROM comments WHERE task_id = $1
 		ORDER BY created_at ASC`
-	rows, err := r.pool.Query(ctx, query, taskID)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, taskID)
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +71,7 @@ func (r *CommentRepo) ListByTask(ctx context.Context, taskID uuid.UUID) ([]*doma
 
 func (r *CommentRepo) Update(ctx context.Context, comment *domain.Comment) error {
 	query := `UPDATE comments SET content = $1, updated_at = $2 WHERE id = $3`
-	tag, err := r.pool.Exec(ctx, query, comment.Content, comment.UpdatedAt, comment.ID)
+	tag, err := conn(ctx, r.pool).Exec(ctx, query, comment.Content, comment.UpdatedAt, comment.ID)
 	if err != nil {
 		return err
 	}
@@ -82,7 +82,7 @@ func (r *CommentRepo) Update(ctx context.Context, comment *domain.Comment) error
 }
 
 func (r *CommentRepo) Delete(ctx context.Context, id uuid.UUID) error {
-	tag, err := r.pool.Exec(ctx, `DELETE FROM comments WHERE id = $1`, id)
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM comments WHERE id = $1`, id)
 	if err != nil {
 		return err
 	}
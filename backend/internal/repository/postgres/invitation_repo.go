@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type InvitationRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewInvitationRepo(pool *pgxpool.Pool) *InvitationRepo {
+	return &InvitationRepo{pool: pool}
+}
+
+func (r *InvitationRepo) Create(ctx context.Context, inv *domain.Invitation) error {
+	query := `
+		INSERT INTO project_invitations (id, project_id, email, role, token_hash, invited_by, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		inv.ID, inv.ProjectID, inv.Email, inv.Role, inv.TokenHash,
+		inv.InvitedBy, inv.ExpiresAt, inv.CreatedAt,
+	)
+	return err
+}
+
+func (r *InvitationRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Invitation, error) {
+	query := `
+		SELECT id, project_id, email, role, token_hash, invited_by, expires_at, accepted_at, created_at
+		FROM project_invitations WHERE id = $1`
+
+	return r.queryOne(ctx, query, id)
+}
+
+func (r *InvitationRepo) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error) {
+	query := `
+		SELECT id, project_id, email, role, token_hash, invited_by, expires_at, accepted_at, created_at
+		FROM project_invitations WHERE token_hash = $1`
+
+	return r.queryOne(ctx, query, tokenHash)
+}
+
+func (r *InvitationRepo) ListPendingByProject(ctx context.Context, projectID uuid.UUID) ([]*domain.Invitation, error) {
+	query := `
+		SELECT id, project_id, email, role, token_hash, invited_by, expires_at, accepted_at, created_at
+		FROM project_invitations
+		WHERE project_id = $1 AND accepted_at IS NULL AND expires_at > NOW()
+		ORDER BY created_at DESC`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations []*domain.Invitation
+	for rows.Next() {
+		inv := &domain.Invitation{}
+		if err := rows.Scan(
+			&inv.ID, &inv.ProjectID, &inv.Email, &inv.Role, &inv.TokenHash,
+			&inv.InvitedBy, &inv.ExpiresAt, &inv.AcceptedAt, &inv.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, inv)
+	}
+	return invitations, rows.Err()
+}
+
+func (r *InvitationRepo) MarkAccepted(ctx context.Context, id uuid.UUID, acceptedAt time.Time) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx,
+		`UPDATE project_invitations SET accepted_at = $1 WHERE id = $2 AND accepted_at IS NULL`, acceptedAt, id,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *InvitationRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM project_invitations WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *InvitationRepo) queryOne(ctx context.Context, query string, arg interface{}) (*domain.Invitation, error) {
+	inv := &domain.Invitation{}
+	err := conn(ctx, r.pool).QueryRow(ctx, query, arg).Scan(
+		&inv.ID, &inv.ProjectID, &inv.Email, &inv.Role, &inv.TokenHash,
+		&inv.InvitedBy, &inv.ExpiresAt, &inv.AcceptedAt, &inv.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return inv, nil
+}
@@ -23,7 +23,7 @@ func (r *LabelRepo) Create(ctx context.Context, label *domain.Label) error {
 	query := `
 		INSERT INTO labels (id, project_id, name, color, created_at)
 		VALUES ($1, $2, $3, $4, $5)`
-	_, err := r.pool.Exec(ctx, query,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
 		label.ID, label.ProjectID, label.Name, label.Color, label.CreatedAt,
 	)
 	return err
@@ -32,7 +32,7 @@ func (r *LabelRepo) Create(ctx context.Context, label *domain.Label) error {
 func (r *LabelRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Label, error) {
 	query := `SELECT id, project_id, name, color, created_at FROM labels WHERE id = $1`
 	l := &domain.Label{}
-	err := r.pool.QueryRow(ctx, query, id).Scan(&l.ID, &l.ProjectID, &l.Name, &l.Color, &l.CreatedAt)
+	err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(&l.ID, &l.ProjectID, &l.Name, &l.Color, &l.CreatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -44,7 +44,7 @@ func (r *LabelRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Label, e
 
 func (r *LabelRepo) ListByProject(ctx context.Context, projectID uuid.UUID) ([]*domain.Label, error) {
 	query := `SELECT id, project_id, name, color, created_at FROM labels WHERE project_id = $1 ORDER BY name ASC`
-	rows, err := r.pool.Query(ctx, query, projectID)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, projectID)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +62,7 @@ func (r *LabelRepo) ListByProject(ctx context.Context, projectID uuid.UUID) ([]*
 }
 
 func (r *LabelRepo) Delete(ctx context.Context, id uuid.UUID) error {
-	tag, err := r.pool.Exec(ctx, `DELETE FROM labels WHERE id = $1`, id)
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM labels WHERE id = $1`, id)
 	if err != nil {
 		return err
 	}
@@ -74,12 +74,12 @@ func (r *LabelRepo) Delete(ctx context.Context, id uuid.UUID) error {
 
 func (r *LabelRepo) AddToTask(ctx context.Context, taskID, labelID uuid.UUID) error {
 	query := `INSERT INTO task_labels (task_id, label_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
-	_, err := r.pool.Exec(ctx, query, taskID, labelID)
+	_, err := conn(ctx, r.pool).Exec(ctx, query, taskID, labelID)
 	return err
 }
 
 func (r *LabelRepo) RemoveFromTask(ctx context.Context, taskID, labelID uuid.UUID) error {
-	_, err := r.pool.Exec(ctx, `DELETE FROM task_labels WHERE task_id = $1 AND label_id = $2`, taskID, labelID)
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM task_labels WHERE task_id = $1 AND label_id = $2`, taskID, labelID)
 	return err
 }
 
@@ -90,7 +90,7 @@ func (r *LabelRepo) ListByTask(ctx context.Context, taskID uuid.UUID) ([]*domain
 		JOIN task_labels tl ON tl.label_id = l.id
 		WHERE tl.task_id = $1
 		ORDER BY l.name ASC`
-	rows, err := r.pool.Query(ctx, query, taskID)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, taskID)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type OrganizationRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewOrganizationRepo(pool *pgxpool.Pool) *OrganizationRepo {
+	return &OrganizationRepo{pool: pool}
+}
+
+func (r *OrganizationRepo) Create(ctx context.Context, org *domain.Organization) error {
+	query := `
+		INSERT INTO organizations (id, name, personal_owner_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		org.ID, org.Name, org.PersonalOwnerID, org.CreatedAt, org.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *OrganizationRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	query := `
+		SELECT id, name, personal_owner_id, created_at, updated_at
+		FROM organizations WHERE id = $1`
+
+	o := &domain.Organization{}
+	err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(
+		&o.ID, &o.Name, &o.PersonalOwnerID, &o.CreatedAt, &o.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return o, nil
+}
+
+func (r *OrganizationRepo) GetPersonal(ctx context.Context, userID uuid.UUID) (*domain.Organization, error) {
+	query := `
+		SELECT id, name, personal_owner_id, created_at, updated_at
+		FROM organizations WHERE personal_owner_id = $1`
+
+	o := &domain.Organization{}
+	err := conn(ctx, r.pool).QueryRow(ctx, query, userID).Scan(
+		&o.ID, &o.Name, &o.PersonalOwnerID, &o.CreatedAt, &o.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return o, nil
+}
+
+func (r *OrganizationRepo) ListByMember(ctx context.Context, userID uuid.UUID) ([]*domain.Organization, error) {
+	query := `
+		SELECT o.id, o.name, o.personal_owner_id, o.created_at, o.updated_at
+		FROM organizations o
+		JOIN organization_members om ON om.organization_id = o.id
+		WHERE om.user_id = $1
+		ORDER BY COALESCE(o.personal_owner_id = $1, FALSE) DESC, o.name ASC`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orgs []*domain.Organization
+	for rows.Next() {
+		o := &domain.Organization{}
+		if err := rows.Scan(&o.ID, &o.Name, &o.PersonalOwnerID, &o.CreatedAt, &o.UpdatedAt); err != nil {
+			return nil, err
+		}
+		orgs = append(orgs, o)
+	}
+	return orgs, rows.Err()
+}
+
+func (r *OrganizationRepo) Update(ctx context.Context, org *domain.Organization) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx,
+		`UPDATE organizations SET name = $1, updated_at = $2 WHERE id = $3`,
+		org.Name, org.UpdatedAt, org.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *OrganizationRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM organizations WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/letyshub/project-management/internal/migrate"
+	"github.com/letyshub/project-management/internal/repository/repotest"
+	"github.com/letyshub/project-management/migrations"
+)
+
+// TestConformance needs a disposable database: it migrates the database in
+// TEST_DATABASE_URL and truncates every table before each test.
+func TestConformance(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	m, err := migrate.NewPostgres(pool, migrations.Postgres)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		if _, err := pool.Exec(ctx, `TRUNCATE users, organizations, login_throttles, audit_events CASCADE`); err != nil {
+			t.Fatalf("truncate: %v", err)
+		}
+		return repotest.Repositories{
+			Users:          NewUserRepo(pool),
+			RefreshTokens:  NewRefreshTokenRepo(pool),
+			AccessTokens:   NewPersonalAccessTokenRepo(pool),
+			Organizations:  NewOrganizationRepo(pool),
+			OrgMembers:     NewOrganizationMemberRepo(pool),
+			Projects:       NewProjectRepo(pool),
+			ProjectMembers: NewProjectMemberRepo(pool),
+			Boards:         NewBoardRepo(pool),
+			Columns:        NewColumnRepo(pool),
+			Tasks:          NewTaskRepo(pool),
+			Comments:       NewCommentRepo(pool),
+			Labels:         NewLabelRepo(pool),
+			AuditEvents:    NewAuditEventRepo(pool),
+			Transitions:    NewTaskTransitionRepo(pool),
+			TwoFactor:      NewTwoFactorRepo(pool),
+			Tx:             NewTxManager(pool),
+		}
+	})
+}
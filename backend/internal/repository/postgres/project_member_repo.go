@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type ProjectMemberRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewProjectMemberRepo(pool *pgxpool.Pool) *ProjectMemberRepo {
+	return &ProjectMemberRepo{pool: pool}
+}
+
+func (r *ProjectMemberRepo) Create(ctx context.Context, member *domain.ProjectMember) error {
+	query := `
+		INSERT INTO project_members (project_id, user_id, role, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		member.ProjectID, member.UserID, member.Role, member.CreatedAt, member.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *ProjectMemberRepo) Get(ctx context.Context, projectID, userID uuid.UUID) (*domain.ProjectMember, error) {
+	query := `
+		SELECT pm.project_id, pm.user_id, pm.role, u.email, u.name, pm.created_at, pm.updated_at
+		FROM project_members pm
+		JOIN users u ON u.id = pm.user_id
+		WHERE pm.project_id = $1 AND pm.user_id = $2`
+
+	m := &domain.ProjectMember{}
+	err := conn(ctx, r.pool).QueryRow(ctx, query, projectID, userID).Scan(
+		&m.ProjectID, &m.UserID, &m.Role, &m.Email, &m.Name, &m.CreatedAt, &m.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+func (r *ProjectMemberRepo) ListByProject(ctx context.Context, projectID uuid.UUID) ([]*domain.ProjectMember, error) {
+	query := `
+		SELECT pm.project_id, pm.user_id, pm.role, u.email, u.name, pm.created_at, pm.updated_at
+		FROM project_members pm
+		JOIN users u ON u.id = pm.user_id
+		WHERE pm.project_id = $1
+		ORDER BY pm.created_at ASC`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*domain.ProjectMember
+	for rows.Next() {
+		m := &domain.ProjectMember{}
+		if err := rows.Scan(&m.ProjectID, &m.UserID, &m.Role, &m.Email, &m.Name, &m.CreatedAt, &m.UpdatedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+func (r *ProjectMemberRepo) CountByRole(ctx context.Context, projectID uuid.UUID, role domain.ProjectRole) (int, error) {
+	var count int
+	err := conn(ctx, r.pool).QueryRow(ctx,
+		`SELECT COUNT(*) FROM project_members WHERE project_id = $1 AND role = $2`, projectID, role,
+	).Scan(&count)
+	return count, err
+}
+
+func (r *ProjectMemberRepo) Update(ctx context.Context, member *domain.ProjectMember) error {
+	query := `
+		UPDATE project_members SET role = $1, updated_at = $2
+		WHERE project_id = $3 AND user_id = $4`
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, query, member.Role, member.UpdatedAt, member.ProjectID, member.UserID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ProjectMemberRepo) Delete(ctx context.Context, projectID, userID uuid.UUID) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx,
+		`DELETE FROM project_members WHERE project_id = $1 AND user_id = $2`, projectID, userID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -21,11 +21,11 @@ func NewProjectRepo(pool *pgxpool.Pool) *ProjectRepo {
 
 func (r *ProjectRepo) Create(ctx context.Context, project *domain.Project) error {
 	query := `
-		INSERT INTO projects (id, name, description, owner_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)`
+		INSERT INTO projects (id, organization_id, name, description, owner_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
 
-	_, err := r.pool.Exec(ctx, query,
-		project.ID, project.Name, project.Description, project.OwnerID,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		project.ID, project.OrganizationID, project.Name, project.Description, project.OwnerID,
 		project.CreatedAt, project.UpdatedAt,
 	)
 	return err
@@ -33,12 +33,12 @@ func (r *ProjectRepo) Create(ctx context.Context, project *domain.Project) error
 
 func (r *ProjectRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
 	query := `
-		SELECT id, name, description, owner_id, created_at, updated_at
+		SELECT id, organization_id, name, description, owner_id, created_at, updated_at
 		FROM projects WHERE id = $1`
 
 	p := &domain.Project{}
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&p.ID, &p.Name, &p.Description, &p.OwnerID, &p.CreatedAt, &p.UpdatedAt,
+	err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(
+		&p.ID, &p.OrganizationID, &p.Name, &p.Description, &p.OwnerID, &p.CreatedAt, &p.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -51,11 +51,11 @@ func (r *ProjectRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Projec
 
 func (r *ProjectRepo) ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*domain.Project, error) {
 	query := `
-		SELECT id, name, description, owner_id, created_at, updated_at
+		SELECT id, organization_id, name, description, owner_id, created_at, updated_at
 		FROM projects WHERE owner_id = $1
 		ORDER BY created_at DESC`
 
-	rows, err := r.pool.Query(ctx, query, ownerID)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, ownerID)
 	if err != nil {
 		return nil, err
 	}
@@ -64,7 +64,32 @@ func (r *ProjectRepo) ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*do
 	var projects []*domain.Project
 	for rows.Next() {
 		p := &domain.Project{}
-		if err := rows.Scan(&p.ID, &p.Name, &p.Description, &p.OwnerID, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		if err := rows.Scan(&p.ID, &p.OrganizationID, &p.Name, &p.Description, &p.OwnerID, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		projects = append(projects, p)
+	}
+	return projects, rows.Err()
+}
+
+func (r *ProjectRepo) ListByMember(ctx context.Context, userID uuid.UUID) ([]*domain.Project, error) {
+	query := `
+		SELECT p.id, p.organization_id, p.name, p.description, p.owner_id, p.created_at, p.updated_at
+		FROM projects p
+		JOIN project_members pm ON pm.project_id = p.id
+		WHERE pm.user_id = $1
+		ORDER BY p.created_at DESC`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []*domain.Project
+	for rows.Next() {
+		p := &domain.Project{}
+		if err := rows.Scan(&p.ID, &p.OrganizationID, &p.Name, &p.Description, &p.OwnerID, &p.CreatedAt, &p.UpdatedAt); err != nil {
 			return nil, err
 		}
 		projects = append(projects, p)
@@ -77,7 +102,7 @@ func (r *ProjectRepo) Update(ctx context.Context, project *domain.Project) error
 		UPDATE projects SET name = $1, description = $2, updated_at = $3
 		WHERE id = $4`
 
-	tag, err := r.pool.Exec(ctx, query,
+	tag, err := conn(ctx, r.pool).Exec(ctx, query,
 		project.Name, project.Description, project.UpdatedAt, project.ID,
 	)
 	if err != nil {
@@ -90,7 +115,7 @@ func (r *ProjectRepo) Update(ctx context.Context, project *domain.Project) error
 }
 
 func (r *ProjectRepo) Delete(ctx context.Context, id uuid.UUID) error {
-	tag, err := r.pool.Exec(ctx, `DELETE FROM projects WHERE id = $1`, id)
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM projects WHERE id = $1`, id)
 	if err != nil {
 		return err
 	}
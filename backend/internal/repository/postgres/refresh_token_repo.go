@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -21,23 +22,25 @@ func NewRefreshTokenRepo(pool *pgxpool.Pool) *RefreshTokenRepo {
 
 func (r *RefreshTokenRepo) Create(ctx context.Context, token *domain.RefreshToken) error {
 	query := `
-		INSERT INTO refresh_tokens (id, user_id, token_hash, expires_at, created_at)
-		VALUES ($1, $2, $3, $4, $5)`
+		INSERT INTO refresh_tokens (id, family_id, user_id, token_hash, user_agent, ip_address, expires_at, last_used_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
 
-	_, err := r.pool.Exec(ctx, query,
-		token.ID, token.UserID, token.TokenHash, token.ExpiresAt, token.CreatedAt,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		token.ID, token.FamilyID, token.UserID, token.TokenHash, token.UserAgent, token.IP,
+		token.ExpiresAt, token.LastUsedAt, token.CreatedAt,
 	)
 	return err
 }
 
 func (r *RefreshTokenRepo) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
 	query := `
-		SELECT id, user_id, token_hash, expires_at, created_at
+		SELECT id, family_id, user_id, token_hash, user_agent, ip_address, expires_at, last_used_at, rotated_at, created_at
 		FROM refresh_tokens WHERE token_hash = $1`
 
 	token := &domain.RefreshToken{}
-	err := r.pool.QueryRow(ctx, query, tokenHash).Scan(
-		&token.ID, &token.UserID, &token.TokenHash, &token.ExpiresAt, &token.CreatedAt,
+	err := conn(ctx, r.pool).QueryRow(ctx, query, tokenHash).Scan(
+		&token.ID, &token.FamilyID, &token.UserID, &token.TokenHash, &token.UserAgent, &token.IP,
+		&token.ExpiresAt, &token.LastUsedAt, &token.RotatedAt, &token.CreatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -48,12 +51,100 @@ func (r *RefreshTokenRepo) GetByTokenHash(ctx context.Context, tokenHash string)
 	return token, nil
 }
 
+func (r *RefreshTokenRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.RefreshToken, error) {
+	query := `
+		SELECT id, family_id, user_id, token_hash, user_agent, ip_address, expires_at, last_used_at, rotated_at, created_at
+		FROM refresh_tokens
+		WHERE user_id = $1 AND rotated_at IS NULL AND expires_at > NOW()
+		ORDER BY last_used_at DESC`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []*domain.RefreshToken
+	for rows.Next() {
+		token := &domain.RefreshToken{}
+		if err := rows.Scan(
+			&token.ID, &token.FamilyID, &token.UserID, &token.TokenHash, &token.UserAgent, &token.IP,
+			&token.ExpiresAt, &token.LastUsedAt, &token.RotatedAt, &token.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+func (r *RefreshTokenRepo) Rotate(ctx context.Context, oldID uuid.UUID, rotatedAt time.Time, next *domain.RefreshToken) error {
+	tx, err := conn(ctx, r.pool).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx,
+		`UPDATE refresh_tokens SET rotated_at = $1 WHERE id = $2 AND rotated_at IS NULL`,
+		rotatedAt, oldID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO refresh_tokens (id, family_id, user_id, token_hash, user_agent, ip_address, expires_at, last_used_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		next.ID, next.FamilyID, next.UserID, next.TokenHash, next.UserAgent, next.IP,
+		next.ExpiresAt, next.LastUsedAt, next.CreatedAt,
+	); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *RefreshTokenRepo) DeleteFamily(ctx context.Context, userID, familyID uuid.UUID) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx,
+		`DELETE FROM refresh_tokens WHERE family_id = $1 AND user_id = $2`, familyID, userID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
 func (r *RefreshTokenRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
-	_, err := r.pool.Exec(ctx, `DELETE FROM refresh_tokens WHERE user_id = $1`, userID)
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM refresh_tokens WHERE user_id = $1`, userID)
+	return err
+}
+
+func (r *RefreshTokenRepo) DeleteOthers(ctx context.Context, userID, keepFamilyID uuid.UUID) error {
+	_, err := conn(ctx, r.pool).Exec(ctx,
+		`DELETE FROM refresh_tokens WHERE user_id = $1 AND family_id <> $2`, userID, keepFamilyID,
+	)
 	return err
 }
 
 func (r *RefreshTokenRepo) DeleteByTokenHash(ctx context.Context, tokenHash string) error {
-	_, err := r.pool.Exec(ctx, `DELETE FROM refresh_tokens WHERE token_hash = $1`, tokenHash)
+	_, err := conn(ctx, r.pool).Exec(ctx, `
+		DELETE FROM refresh_tokens
+		WHERE family_id IN (SELECT family_id FROM refresh_tokens WHERE token_hash = $1)`,
+		tokenHash,
+	)
 	return err
 }
+
+func (r *RefreshTokenRepo) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM refresh_tokens WHERE expires_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,28 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type SecurityEventRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewSecurityEventRepo(pool *pgxpool.Pool) *SecurityEventRepo {
+	return &SecurityEventRepo{pool: pool}
+}
+
+func (r *SecurityEventRepo) Create(ctx context.Context, event *domain.SecurityEvent) error {
+	query := `
+		INSERT INTO security_events (id, user_id, type, user_agent, ip_address, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		event.ID, event.UserID, event.Type, event.UserAgent, event.IP, event.CreatedAt,
+	)
+	return err
+}
@@ -26,7 +26,7 @@ func (r *TaskRepo) Create(ctx context.Context, task *domain.Task) error {
 		INSERT INTO tasks (id, column_id, title, description, priority, assignee_id, position, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
 		task.ID, task.ColumnID, task.Title, task.Description,
 		task.Priority, task.AssigneeID, task.Position,
 		task.CreatedAt, task.UpdatedAt,
@@ -40,7 +40,7 @@ func (r *TaskRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Task, err
 		FROM tasks WHERE id = $1`
 
 	t := &domain.Task{}
-	err := r.pool.QueryRow(ctx, query, id).Scan(
+	err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(
 		&t.ID, &t.ColumnID, &t.Title, &t.Description,
 		&t.Priority, &t.AssigneeID, &t.Position,
 		&t.CreatedAt, &t.UpdatedAt,
@@ -85,6 +85,11 @@ func (r *TaskRepo) ListByBoard(ctx context.Context, boardID uuid.UUID, filter do
 	if filter.AssigneeID != nil {
 		conditions = append(conditions, fmt.Sprintf("t.assignee_id = $%d", argIdx))
 		args = append(args, *filter.AssigneeID)
+		argIdx++
+	}
+	if filter.LabelID != nil {
+		conditions = append(conditions, fmt.Sprintf("t.id IN (SELECT task_id FROM task_labels WHERE label_id = $%d)", argIdx))
+		args = append(args, *filter.LabelID)
 	}
 
 	query := fmt.Sprintf(`
@@ -102,7 +107,7 @@ func (r *TaskRepo) Update(ctx context.Context, task *domain.Task) error {
 		assignee_id = $5, position = $6, updated_at = $7
 		WHERE id = $8`
 
-	tag, err := r.pool.Exec(ctx, query,
+	tag, err := conn(ctx, r.pool).Exec(ctx, query,
 		task.ColumnID, task.Title, task.Description, task.Priority,
 		task.AssigneeID, task.Position, task.UpdatedAt, task.ID,
 	)
@@ -116,7 +121,7 @@ func (r *TaskRepo) Update(ctx context.Context, task *domain.Task) error {
 }
 
 func (r *TaskRepo) Delete(ctx context.Context, id uuid.UUID) error {
-	tag, err := r.pool.Exec(ctx, `DELETE FROM tasks WHERE id = $1`, id)
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM tasks WHERE id = $1`, id)
 	if err != nil {
 		return err
 	}
@@ -127,7 +132,7 @@ func (r *TaskRepo) Delete(ctx context.Context, id uuid.UUID) error {
 }
 
 func (r *TaskRepo) queryTasks(ctx context.Context, query string, args ...interface{}) ([]*domain.Task, error) {
-	rows, err := r.pool.Query(ctx, query, args...)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
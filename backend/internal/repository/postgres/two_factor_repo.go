@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type TwoFactorRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewTwoFactorRepo(pool *pgxpool.Pool) *TwoFactorRepo {
+	return &TwoFactorRepo{pool: pool}
+}
+
+func (r *TwoFactorRepo) SaveTOTP(ctx context.Context, e *domain.TOTPEnrollment) error {
+	query := `
+		INSERT INTO user_totp (user_id, secret, confirmed_at, last_used_step, created_at)
+		VALUES ($1, $2, NULL, 0, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, confirmed_at = NULL, last_used_step = 0, created_at = EXCLUDED.created_at`
+	_, err := conn(ctx, r.pool).Exec(ctx, query, e.UserID, e.Secret, e.CreatedAt)
+	return err
+}
+
+func (r *TwoFactorRepo) GetTOTP(ctx context.Context, userID uuid.UUID) (*domain.TOTPEnrollment, error) {
+	query := `
+		SELECT user_id, secret, confirmed_at, last_used_step, created_at
+		FROM user_totp WHERE user_id = $1`
+
+	e := &domain.TOTPEnrollment{}
+	err := conn(ctx, r.pool).QueryRow(ctx, query, userID).Scan(
+		&e.UserID, &e.Secret, &e.ConfirmedAt, &e.LastUsedStep, &e.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return e, nil
+}
+
+func (r *TwoFactorRepo) ConfirmTOTP(ctx context.Context, userID uuid.UUID, confirmedAt time.Time) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx,
+		`UPDATE user_totp SET confirmed_at = $1 WHERE user_id = $2`, confirmedAt, userID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *TwoFactorRepo) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx,
+		`UPDATE user_totp SET last_used_step = $1 WHERE user_id = $2 AND last_used_step < $1`, step, userID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrConflict
+	}
+	return nil
+}
+
+func (r *TwoFactorRepo) Delete(ctx context.Context, userID uuid.UUID) error {
+	if _, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM user_totp WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *TwoFactorRepo) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string, createdAt time.Time) error {
+	tx, err := conn(ctx, r.pool).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	for _, hash := range codeHashes {
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO user_recovery_codes (id, user_id, code_hash, created_at) VALUES ($1, $2, $3, $4)`,
+			uuid.New(), userID, hash, createdAt,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *TwoFactorRepo) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, usedAt time.Time) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `
+		UPDATE user_recovery_codes SET used_at = $1
+		WHERE user_id = $2 AND code_hash = $3 AND used_at IS NULL`,
+		usedAt, userID, codeHash,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *TwoFactorRepo) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	var n int
+	err := conn(ctx, r.pool).QueryRow(ctx,
+		`SELECT COUNT(*) FROM user_recovery_codes WHERE user_id = $1 AND used_at IS NULL`, userID,
+	).Scan(&n)
+	return n, err
+}
+
+func (r *TwoFactorRepo) CreateChallenge(ctx context.Context, c *domain.TwoFactorChallenge) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `
+		INSERT INTO two_factor_challenges (id, user_id, attempts, expires_at, consumed_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		c.ID, c.UserID, c.Attempts, c.ExpiresAt, c.ConsumedAt, c.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *TwoFactorRepo) UseChallengeAttempt(ctx context.Context, id uuid.UUID, maxAttempts int, at time.Time) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `
+		UPDATE two_factor_challenges SET attempts = attempts + 1
+		WHERE id = $1 AND consumed_at IS NULL AND expires_at > $2 AND attempts < $3`,
+		id, at, maxAttempts,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *TwoFactorRepo) ConsumeChallenge(ctx context.Context, id uuid.UUID, at time.Time) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx,
+		`UPDATE two_factor_challenges SET consumed_at = $1 WHERE id = $2 AND consumed_at IS NULL`, at, id,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *TwoFactorRepo) DeleteExpiredChallenges(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM two_factor_challenges WHERE expires_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
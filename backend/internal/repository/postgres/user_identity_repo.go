@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type UserIdentityRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewUserIdentityRepo(pool *pgxpool.Pool) *UserIdentityRepo {
+	return &UserIdentityRepo{pool: pool}
+}
+
+func (r *UserIdentityRepo) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (id, user_id, issuer, subject, email, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		identity.ID, identity.UserID, identity.Issuer, identity.Subject, identity.Email, identity.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *UserIdentityRepo) GetBySubject(ctx context.Context, issuer, subject string) (*domain.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, issuer, subject, email, created_at
+		FROM user_identities WHERE issuer = $1 AND subject = $2`
+
+	i := &domain.UserIdentity{}
+	err := conn(ctx, r.pool).QueryRow(ctx, query, issuer, subject).Scan(
+		&i.ID, &i.UserID, &i.Issuer, &i.Subject, &i.Email, &i.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return i, nil
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -21,11 +22,12 @@ func NewUserRepo(pool *pgxpool.Pool) *UserRepo {
 
 func (r *UserRepo) Create(ctx context.Context, user *domain.User) error {
 	query := `
-		INSERT INTO users (id, email, name, password_hash, role, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+		INSERT INTO users (id, email, name, password_hash, role, email_verified_at, deactivated_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
 
-	_, err := r.pool.Exec(ctx, query,
-		user.ID, user.Email, user.Name, user.PasswordHash, user.Role, user.CreatedAt, user.UpdatedAt,
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		user.ID, user.Email, user.Name, user.PasswordHash, user.Role, user.EmailVerifiedAt, user.DeactivatedAt,
+		user.CreatedAt, user.UpdatedAt,
 	)
 	if err != nil {
 		if isUniqueViolation(err) {
@@ -38,12 +40,13 @@ func (r *UserRepo) Create(ctx context.Context, user *domain.User) error {
 
 func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, email, name, password_hash, role, created_at, updated_at
+		SELECT id, email, name, password_hash, role, email_verified_at, deactivated_at, created_at, updated_at
 		FROM users WHERE email = $1`
 
 	user := &domain.User{}
-	err := r.pool.QueryRow(ctx, query, email).Scan(
-		&user.ID, &user.Email, &user.Name, &user.PasswordHash, &user.Role, &user.CreatedAt, &user.UpdatedAt,
+	err := conn(ctx, r.pool).QueryRow(ctx, query, email).Scan(
+		&user.ID, &user.Email, &user.Name, &user.PasswordHash, &user.Role, &user.EmailVerifiedAt, &user.DeactivatedAt,
+		&user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -56,12 +59,13 @@ func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*domain.User,
 
 func (r *UserRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	query := `
-		SELECT id, email, name, password_hash, role, created_at, updated_at
+		SELECT id, email, name, password_hash, role, email_verified_at, deactivated_at, created_at, updated_at
 		FROM users WHERE id = $1`
 
 	user := &domain.User{}
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&user.ID, &user.Email, &user.Name, &user.PasswordHash, &user.Role, &user.CreatedAt, &user.UpdatedAt,
+	err := conn(ctx, r.pool).QueryRow(ctx, query, id).Scan(
+		&user.ID, &user.Email, &user.Name, &user.PasswordHash, &user.Role, &user.EmailVerifiedAt, &user.DeactivatedAt,
+		&user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -76,6 +80,106 @@ func (r *UserRepo) Update(ctx context.Context, user *domain.User) error {
 	query := `
 		UPDATE users SET name = $1, updated_at = $2
 		WHERE id = $3`
-	_, err := r.pool.Exec(ctx, query, user.Name, user.UpdatedAt, user.ID)
+	_, err := conn(ctx, r.pool).Exec(ctx, query, user.Name, user.UpdatedAt, user.ID)
 	return err
 }
+
+func (r *UserRepo) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string, updatedAt time.Time) error {
+	query := `
+		UPDATE users SET password_hash = $1, updated_at = $2
+		WHERE id = $3`
+	tag, err := conn(ctx, r.pool).Exec(ctx, query, passwordHash, updatedAt, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *UserRepo) MarkEmailVerified(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error {
+	query := `
+		UPDATE users SET email_verified_at = $1, updated_at = $1
+		WHERE id = $2`
+	tag, err := conn(ctx, r.pool).Exec(ctx, query, verifiedAt, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *UserRepo) List(ctx context.Context, filter domain.UserFilter) ([]*domain.User, error) {
+	query := `
+		SELECT id, email, name, password_hash, role, email_verified_at, deactivated_at, created_at, updated_at
+		FROM users
+		WHERE $1 = '' OR email ILIKE '%' || $1 || '%' OR name ILIKE '%' || $1 || '%'
+		ORDER BY created_at, id
+		LIMIT $2 OFFSET $3`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, filter.Query, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		user := &domain.User{}
+		if err := rows.Scan(
+			&user.ID, &user.Email, &user.Name, &user.PasswordHash, &user.Role, &user.EmailVerifiedAt, &user.DeactivatedAt,
+			&user.CreatedAt, &user.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (r *UserRepo) UpdateRole(ctx context.Context, id uuid.UUID, role string, updatedAt time.Time) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `UPDATE users SET role = $1, updated_at = $2 WHERE id = $3`, role, updatedAt, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *UserRepo) SetDeactivated(ctx context.Context, id uuid.UUID, deactivatedAt *time.Time, updatedAt time.Time) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx,
+		`UPDATE users SET deactivated_at = $1, updated_at = $2 WHERE id = $3`, deactivatedAt, updatedAt, id,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *UserRepo) Stats(ctx context.Context) (*domain.SystemStats, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM users),
+			(SELECT COUNT(*) FROM users WHERE deactivated_at IS NOT NULL),
+			(SELECT COUNT(*) FROM users WHERE role = 'admin'),
+			(SELECT COUNT(*) FROM projects),
+			(SELECT COUNT(*) FROM boards),
+			(SELECT COUNT(*) FROM tasks)`
+
+	stats := &domain.SystemStats{}
+	err := conn(ctx, r.pool).QueryRow(ctx, query).Scan(
+		&stats.Users, &stats.DeactivatedUsers, &stats.Admins, &stats.Projects, &stats.Boards, &stats.Tasks,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,94 @@
+package repotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+func testAuditEvents(t *testing.T, r Repositories) {
+	actor, other := uuid.New(), uuid.New()
+	projectID, otherProjectID := uuid.New(), uuid.New()
+	taskID := uuid.New()
+
+	newEvent := func(actorID uuid.UUID, projectID *uuid.UUID, entityType string, entityID uuid.UUID, minute int) *domain.AuditEvent {
+		e := &domain.AuditEvent{
+			ID: uuid.New(), ActorID: actorID, ProjectID: projectID,
+			EntityType: entityType, EntityID: entityID, Action: domain.AuditActionUpdate,
+			IP: "203.0.113.7", RequestID: fmt.Sprintf("req-%d", minute), CreatedAt: at(minute),
+		}
+		must(t, r.AuditEvents.Create(ctx, e))
+		return e
+	}
+
+	created := &domain.AuditEvent{
+		ID: uuid.New(), ActorID: actor, ProjectID: &projectID,
+		EntityType: domain.AuditEntityTask, EntityID: taskID, Action: domain.AuditActionUpdate,
+		Before: json.RawMessage(`{"title":"Draft"}`), After: json.RawMessage(`{"title":"Final"}`),
+		IP: "203.0.113.7", RequestID: "req-0", CreatedAt: at(0),
+	}
+	must(t, r.AuditEvents.Create(ctx, created))
+	wantErr(t, r.AuditEvents.Create(ctx, created), domain.ErrConflict)
+	e1 := newEvent(other, &projectID, domain.AuditEntityBoard, uuid.New(), 1)
+	e2 := newEvent(actor, &projectID, domain.AuditEntityTask, taskID, 2)
+	e3 := newEvent(actor, nil, domain.AuditEntityUser, actor, 3)
+	e4 := newEvent(actor, &otherProjectID, domain.AuditEntityTask, uuid.New(), 4)
+
+	all, err := r.AuditEvents.List(ctx, domain.AuditFilter{Limit: 10})
+	must(t, err)
+	wantAuditIDs(t, all, e4, e3, e2, e1, created)
+
+	got := all[len(all)-1]
+	switch {
+	case got.ActorID != actor || got.ProjectID == nil || *got.ProjectID != projectID:
+		t.Fatalf("unexpected actor or project on %+v", got)
+	case got.IP != "203.0.113.7" || got.RequestID != "req-0" || !got.CreatedAt.Equal(at(0)):
+		t.Fatalf("unexpected client or time on %+v", got)
+	}
+	var before, after map[string]string
+	must(t, json.Unmarshal(got.Before, &before))
+	must(t, json.Unmarshal(got.After, &after))
+	if before["title"] != "Draft" || after["title"] != "Final" {
+		t.Fatalf("unexpected diff %s -> %s", got.Before, got.After)
+	}
+	if e3.ProjectID != nil || all[1].ProjectID != nil || all[1].Before != nil || all[1].After != nil {
+		t.Fatalf("expected an account event without project or diff, got %+v", all[1])
+	}
+
+	page, err := r.AuditEvents.List(ctx, domain.AuditFilter{Limit: 2, Offset: 1})
+	must(t, err)
+	wantAuditIDs(t, page, e3, e2)
+
+	byProject, err := r.AuditEvents.List(ctx, domain.AuditFilter{ProjectID: &projectID, Limit: 10})
+	must(t, err)
+	wantAuditIDs(t, byProject, e2, e1, created)
+
+	byActor, err := r.AuditEvents.List(ctx, domain.AuditFilter{ProjectID: &projectID, ActorID: &actor, Limit: 10})
+	must(t, err)
+	wantAuditIDs(t, byActor, e2, created)
+
+	byEntity, err := r.AuditEvents.List(ctx, domain.AuditFilter{EntityType: domain.AuditEntityTask, EntityID: &taskID, Limit: 10})
+	must(t, err)
+	wantAuditIDs(t, byEntity, e2, created)
+
+	since, until := at(1), at(3)
+	inRange, err := r.AuditEvents.List(ctx, domain.AuditFilter{Since: &since, Until: &until, Limit: 10})
+	must(t, err)
+	wantAuditIDs(t, inRange, e2, e1)
+}
+
+func wantAuditIDs(t *testing.T, got []*domain.AuditEvent, want ...*domain.AuditEvent) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i].ID != want[i].ID {
+			t.Fatalf("event %d: expected %s (%s), got %s (%s)", i, want[i].ID, want[i].RequestID, got[i].ID, got[i].RequestID)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package repotest
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+func testOrganizations(t *testing.T, r Repositories) {
+	u := newUser(t, r, "ada@example.com", base)
+	other := newUser(t, r, "bob@example.com", base)
+
+	newOrg := func(name string, owner *uuid.UUID) *domain.Organization {
+		t.Helper()
+		o := &domain.Organization{ID: uuid.New(), Name: name, PersonalOwnerID: owner, CreatedAt: base, UpdatedAt: base}
+		must(t, r.Organizations.Create(ctx, o))
+		must(t, r.OrgMembers.Create(ctx, &domain.OrganizationMember{
+			OrganizationID: o.ID, UserID: u.ID, Role: domain.OrgRoleOwner, CreatedAt: base, UpdatedAt: base,
+		}))
+		return o
+	}
+	acme := newOrg("Acme", nil)
+	personal := newOrg("Zed's workspace", &u.ID)
+	beta := newOrg("Beta", nil)
+
+	dup := &domain.Organization{ID: uuid.New(), Name: "Again", PersonalOwnerID: &u.ID, CreatedAt: base, UpdatedAt: base}
+	wantErr(t, r.Organizations.Create(ctx, dup), domain.ErrConflict)
+
+	got, err := r.Organizations.GetPersonal(ctx, u.ID)
+	must(t, err)
+	if got.ID != personal.ID || got.PersonalOwnerID == nil || *got.PersonalOwnerID != u.ID {
+		t.Fatalf("GetPersonal returned %+v", got)
+	}
+	_, err = r.Organizations.GetPersonal(ctx, other.ID)
+	wantErr(t, err, domain.ErrNotFound)
+
+	orgID := func(o *domain.Organization) uuid.UUID { return o.ID }
+
+	// The personal organization comes first, the rest by name.
+	orgs, err := r.Organizations.ListByMember(ctx, u.ID)
+	must(t, err)
+	wantIDs(t, ids(orgs, orgID), personal.ID, acme.ID, beta.ID)
+
+	orgs, err = r.Organizations.ListByMember(ctx, other.ID)
+	must(t, err)
+	if len(orgs) != 0 {
+		t.Fatalf("expected no organizations for a non-member, got %d", len(orgs))
+	}
+
+	wantErr(t, r.OrgMembers.Create(ctx, &domain.OrganizationMember{
+		OrganizationID: acme.ID, UserID: u.ID, Role: domain.OrgRoleMember, CreatedAt: base, UpdatedAt: base,
+	}), domain.ErrConflict)
+
+	must(t, r.Organizations.Delete(ctx, beta.ID))
+	_, err = r.OrgMembers.Get(ctx, beta.ID, u.ID)
+	wantErr(t, err, domain.ErrNotFound)
+	wantErr(t, r.Organizations.Delete(ctx, beta.ID), domain.ErrNotFound)
+}
@@ -0,0 +1,331 @@
+package repotest
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+func testProjects(t *testing.T, r Repositories) {
+	owner := newUser(t, r, "owner@example.com", base)
+	member := newUser(t, r, "member@example.com", base)
+	older := newProject(t, r, owner, at(1))
+	newer := newProject(t, r, owner, at(2))
+
+	must(t, r.ProjectMembers.Create(ctx, &domain.ProjectMember{
+		ProjectID: older.ID, UserID: member.ID, Role: domain.ProjectRoleMember, CreatedAt: base, UpdatedAt: base,
+	}))
+
+	projectID := func(p *domain.Project) uuid.UUID { return p.ID }
+
+	projects, err := r.Projects.ListByOwner(ctx, owner.ID)
+	must(t, err)
+	wantIDs(t, ids(projects, projectID), newer.ID, older.ID)
+
+	projects, err = r.Projects.ListByMember(ctx, member.ID)
+	must(t, err)
+	wantIDs(t, ids(projects, projectID), older.ID)
+
+	older.Name = "Renamed"
+	older.Description = "Now with a description"
+	older.UpdatedAt = at(3)
+	must(t, r.Projects.Update(ctx, older))
+
+	got, err := r.Projects.GetByID(ctx, older.ID)
+	must(t, err)
+	if got.Name != "Renamed" || got.Description != "Now with a description" || got.OrganizationID != older.OrganizationID || !got.UpdatedAt.Equal(at(3)) {
+		t.Fatalf("update not applied: %+v", got)
+	}
+
+	missing := &domain.Project{ID: uuid.New(), Name: "Missing", UpdatedAt: base}
+	wantErr(t, r.Projects.Update(ctx, missing), domain.ErrNotFound)
+	wantErr(t, r.Projects.Delete(ctx, missing.ID), domain.ErrNotFound)
+
+	must(t, r.Projects.Delete(ctx, older.ID))
+	_, err = r.Projects.GetByID(ctx, older.ID)
+	wantErr(t, err, domain.ErrNotFound)
+	projects, err = r.Projects.ListByMember(ctx, member.ID)
+	must(t, err)
+	if len(projects) != 0 {
+		t.Fatalf("expected membership to be deleted with the project, got %d projects", len(projects))
+	}
+}
+
+func testBoards(t *testing.T, r Repositories) {
+	owner := newUser(t, r, "owner@example.com", base)
+	project := newProject(t, r, owner, base)
+	other := newProject(t, r, owner, base)
+	second := newBoard(t, r, project, at(2))
+	first := newBoard(t, r, project, at(1))
+	newBoard(t, r, other, at(3))
+
+	boards, err := r.Boards.ListByProject(ctx, project.ID)
+	must(t, err)
+	wantIDs(t, ids(boards, func(b *domain.Board) uuid.UUID { return b.ID }), first.ID, second.ID)
+
+	first.Name = "Renamed"
+	first.UpdatedAt = at(4)
+	must(t, r.Boards.Update(ctx, first))
+	got, err := r.Boards.GetByID(ctx, first.ID)
+	must(t, err)
+	if got.Name != "Renamed" || got.ProjectID != project.ID || !got.UpdatedAt.Equal(at(4)) {
+		t.Fatalf("update not applied: %+v", got)
+	}
+
+	missing := &domain.Board{ID: uuid.New(), Name: "Missing", UpdatedAt: base}
+	wantErr(t, r.Boards.Update(ctx, missing), domain.ErrNotFound)
+	wantErr(t, r.Boards.Delete(ctx, missing.ID), domain.ErrNotFound)
+	_, err = r.Boards.GetByID(ctx, missing.ID)
+	wantErr(t, err, domain.ErrNotFound)
+
+	must(t, r.Boards.Delete(ctx, first.ID))
+	_, err = r.Boards.GetByID(ctx, first.ID)
+	wantErr(t, err, domain.ErrNotFound)
+}
+
+func testColumns(t *testing.T, r Repositories) {
+	owner := newUser(t, r, "owner@example.com", base)
+	board := newBoard(t, r, newProject(t, r, owner, base), base)
+	done := newColumn(t, r, board, 3)
+	todo := newColumn(t, r, board, 1)
+	doing := newColumn(t, r, board, 2)
+
+	columnID := func(c *domain.Column) uuid.UUID { return c.ID }
+
+	cols, err := r.Columns.ListByBoard(ctx, board.ID)
+	must(t, err)
+	wantIDs(t, ids(cols, columnID), todo.ID, doing.ID, done.ID)
+
+	done.Name = "Shipped"
+	done.Position = 0.5
+	done.Category = domain.ColumnCategoryDone
+	done.UpdatedAt = at(1)
+	must(t, r.Columns.Update(ctx, done))
+
+	cols, err = r.Columns.ListByBoard(ctx, board.ID)
+	must(t, err)
+	wantIDs(t, ids(cols, columnID), done.ID, todo.ID, doing.ID)
+	if cols[0].Name != "Shipped" || cols[0].Category != domain.ColumnCategoryDone || !cols[0].UpdatedAt.Equal(at(1)) {
+		t.Fatalf("update not applied: %+v", cols[0])
+	}
+
+	missing := &domain.Column{ID: uuid.New(), Name: "Missing", UpdatedAt: base}
+	wantErr(t, r.Columns.Update(ctx, missing), domain.ErrNotFound)
+	wantErr(t, r.Columns.Delete(ctx, missing.ID), domain.ErrNotFound)
+
+	must(t, r.Columns.Delete(ctx, doing.ID))
+	_, err = r.Columns.GetByID(ctx, doing.ID)
+	wantErr(t, err, domain.ErrNotFound)
+}
+
+func testTasks(t *testing.T, r Repositories) {
+	owner := newUser(t, r, "owner@example.com", base)
+	board := newBoard(t, r, newProject(t, r, owner, base), base)
+	todo := newColumn(t, r, board, 1)
+	done := newColumn(t, r, board, 2)
+	second := newTask(t, r, todo, 2, "low", nil)
+	first := newTask(t, r, todo, 1, "high", &owner.ID)
+
+	taskID := func(task *domain.Task) uuid.UUID { return task.ID }
+
+	tasks, err := r.Tasks.ListByColumn(ctx, todo.ID)
+	must(t, err)
+	wantIDs(t, ids(tasks, taskID), first.ID, second.ID)
+
+	got, err := r.Tasks.GetByID(ctx, first.ID)
+	must(t, err)
+	if got.AssigneeID == nil || *got.AssigneeID != owner.ID || got.Priority != "high" {
+		t.Fatalf("GetByID returned %+v", got)
+	}
+
+	first.ColumnID = done.ID
+	first.Title = "Moved"
+	first.Description = "Now done"
+	first.Priority = "medium"
+	first.AssigneeID = nil
+	first.Position = 5
+	first.UpdatedAt = at(1)
+	must(t, r.Tasks.Update(ctx, first))
+
+	got, err = r.Tasks.GetByID(ctx, first.ID)
+	must(t, err)
+	switch {
+	case got.ColumnID != done.ID, got.Title != "Moved", got.Description != "Now done", got.Priority != "medium":
+		t.Fatalf("update not applied: %+v", got)
+	case got.AssigneeID != nil, got.Position != 5, !got.UpdatedAt.Equal(at(1)):
+		t.Fatalf("update not applied: %+v", got)
+	}
+
+	tasks, err = r.Tasks.ListByColumn(ctx, todo.ID)
+	must(t, err)
+	wantIDs(t, ids(tasks, taskID), second.ID)
+
+	missing := &domain.Task{ID: uuid.New(), ColumnID: todo.ID, Title: "Missing", Priority: "low", UpdatedAt: base}
+	wantErr(t, r.Tasks.Update(ctx, missing), domain.ErrNotFound)
+	wantErr(t, r.Tasks.Delete(ctx, missing.ID), domain.ErrNotFound)
+
+	must(t, r.Tasks.Delete(ctx, second.ID))
+	_, err = r.Tasks.GetByID(ctx, second.ID)
+	wantErr(t, err, domain.ErrNotFound)
+}
+
+func testTaskFilter(t *testing.T, r Repositories) {
+	owner := newUser(t, r, "owner@example.com", base)
+	other := newUser(t, r, "other@example.com", base)
+	project := newProject(t, r, owner, base)
+	board := newBoard(t, r, project, base)
+	todo := newColumn(t, r, board, 1)
+	done := newColumn(t, r, board, 2)
+	elsewhere := newColumn(t, r, newBoard(t, r, project, base), 1)
+
+	t1 := newTask(t, r, todo, 3, "high", &owner.ID)
+	t2 := newTask(t, r, done, 1, "low", &other.ID)
+	t3 := newTask(t, r, todo, 2, "high", nil)
+	newTask(t, r, elsewhere, 0, "high", &owner.ID)
+	bug := newLabel(t, r, project, "bug")
+	must(t, r.Labels.AddToTask(ctx, t1.ID, bug.ID))
+	must(t, r.Labels.AddToTask(ctx, t2.ID, bug.ID))
+
+	high := "high"
+	tests := []struct {
+		name   string
+		filter domain.TaskFilter
+		want   []uuid.UUID
+	}{
+		{"no filter", domain.TaskFilter{}, []uuid.UUID{t2.ID, t3.ID, t1.ID}},
+		{"column", domain.TaskFilter{ColumnID: &todo.ID}, []uuid.UUID{t3.ID, t1.ID}},
+		{"column from another board", domain.TaskFilter{ColumnID: &elsewhere.ID}, nil},
+		{"priority", domain.TaskFilter{Priority: &high}, []uuid.UUID{t3.ID, t1.ID}},
+		{"assignee", domain.TaskFilter{AssigneeID: &owner.ID}, []uuid.UUID{t1.ID}},
+		{"label", domain.TaskFilter{LabelID: &bug.ID}, []uuid.UUID{t2.ID, t1.ID}},
+		{"combined", domain.TaskFilter{ColumnID: &done.ID, Priority: &high}, nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tasks, err := r.Tasks.ListByBoard(ctx, board.ID, tc.filter)
+			must(t, err)
+			wantIDs(t, ids(tasks, func(task *domain.Task) uuid.UUID { return task.ID }), tc.want...)
+		})
+	}
+}
+
+func testComments(t *testing.T, r Repositories) {
+	author := newUser(t, r, "author@example.com", base)
+	board := newBoard(t, r, newProject(t, r, author, base), base)
+	task := newTask(t, r, newColumn(t, r, board, 1), 1, "low", nil)
+	later := newComment(t, r, task, author, at(2))
+	earlier := newComment(t, r, task, author, at(1))
+
+	commentID := func(c *domain.Comment) uuid.UUID { return c.ID }
+
+	comments, err := r.Comments.ListByTask(ctx, task.ID)
+	must(t, err)
+	wantIDs(t, ids(comments, commentID), earlier.ID, later.ID)
+
+	earlier.Content = "Edited"
+	earlier.UpdatedAt = at(3)
+	must(t, r.Comments.Update(ctx, earlier))
+	got, err := r.Comments.GetByID(ctx, earlier.ID)
+	must(t, err)
+	if got.Content != "Edited" || got.AuthorID != author.ID || !got.CreatedAt.Equal(at(1)) || !got.UpdatedAt.Equal(at(3)) {
+		t.Fatalf("update not applied: %+v", got)
+	}
+
+	missing := &domain.Comment{ID: uuid.New(), Content: "Missing", UpdatedAt: base}
+	wantErr(t, r.Comments.Update(ctx, missing), domain.ErrNotFound)
+	wantErr(t, r.Comments.Delete(ctx, missing.ID), domain.ErrNotFound)
+
+	must(t, r.Comments.Delete(ctx, later.ID))
+	comments, err = r.Comments.ListByTask(ctx, task.ID)
+	must(t, err)
+	wantIDs(t, ids(comments, commentID), earlier.ID)
+}
+
+func testLabels(t *testing.T, r Repositories) {
+	owner := newUser(t, r, "owner@example.com", base)
+	project := newProject(t, r, owner, base)
+	bug := newLabel(t, r, project, "bug")
+	docs := newLabel(t, r, project, "docs")
+	api := newLabel(t, r, project, "api")
+	newLabel(t, r, newProject(t, r, owner, base), "elsewhere")
+
+	labelID := func(l *domain.Label) uuid.UUID { return l.ID }
+
+	labels, err := r.Labels.ListByProject(ctx, project.ID)
+	must(t, err)
+	wantIDs(t, ids(labels, labelID), api.ID, bug.ID, docs.ID)
+
+	got, err := r.Labels.GetByID(ctx, bug.ID)
+	must(t, err)
+	if got.Name != "bug" || got.Color != "#ff0000" || got.ProjectID != project.ID {
+		t.Fatalf("GetByID returned %+v", got)
+	}
+
+	board := newBoard(t, r, project, base)
+	task := newTask(t, r, newColumn(t, r, board, 1), 1, "low", nil)
+	must(t, r.Labels.AddToTask(ctx, task.ID, docs.ID))
+	must(t, r.Labels.AddToTask(ctx, task.ID, bug.ID))
+	must(t, r.Labels.AddToTask(ctx, task.ID, bug.ID))
+
+	labels, err = r.Labels.ListByTask(ctx, task.ID)
+	must(t, err)
+	wantIDs(t, ids(labels, labelID), bug.ID, docs.ID)
+
+	must(t, r.Labels.RemoveFromTask(ctx, task.ID, docs.ID))
+	must(t, r.Labels.RemoveFromTask(ctx, task.ID, docs.ID))
+	labels, err = r.Labels.ListByTask(ctx, task.ID)
+	must(t, err)
+	wantIDs(t, ids(labels, labelID), bug.ID)
+
+	wantErr(t, r.Labels.Delete(ctx, uuid.New()), domain.ErrNotFound)
+	must(t, r.Labels.Delete(ctx, bug.ID))
+	_, err = r.Labels.GetByID(ctx, bug.ID)
+	wantErr(t, err, domain.ErrNotFound)
+	labels, err = r.Labels.ListByTask(ctx, task.ID)
+	must(t, err)
+	if len(labels) != 0 {
+		t.Fatalf("expected deleted label to be removed from the task, got %d labels", len(labels))
+	}
+}
+
+// testCascades checks that deleting a parent removes everything beneath it.
+func testCascades(t *testing.T, r Repositories) {
+	owner := newUser(t, r, "owner@example.com", base)
+	project := newProject(t, r, owner, base)
+	board := newBoard(t, r, project, base)
+	col := newColumn(t, r, board, 1)
+	task := newTask(t, r, col, 1, "low", nil)
+	comment := newComment(t, r, task, owner, base)
+	label := newLabel(t, r, project, "bug")
+	must(t, r.Labels.AddToTask(ctx, task.ID, label.ID))
+
+	must(t, r.Boards.Delete(ctx, board.ID))
+	_, err := r.Columns.GetByID(ctx, col.ID)
+	wantErr(t, err, domain.ErrNotFound)
+	_, err = r.Tasks.GetByID(ctx, task.ID)
+	wantErr(t, err, domain.ErrNotFound)
+	_, err = r.Comments.GetByID(ctx, comment.ID)
+	wantErr(t, err, domain.ErrNotFound)
+	labels, err := r.Labels.ListByTask(ctx, task.ID)
+	must(t, err)
+	if len(labels) != 0 {
+		t.Fatalf("expected task labels to be deleted with the task, got %d", len(labels))
+	}
+	_, err = r.Labels.GetByID(ctx, label.ID)
+	must(t, err)
+
+	board = newBoard(t, r, project, base)
+	must(t, r.Projects.Delete(ctx, project.ID))
+	_, err = r.Boards.GetByID(ctx, board.ID)
+	wantErr(t, err, domain.ErrNotFound)
+	_, err = r.Labels.GetByID(ctx, label.ID)
+	wantErr(t, err, domain.ErrNotFound)
+
+	stats, err := r.Users.Stats(ctx)
+	must(t, err)
+	if stats.Projects != 0 || stats.Boards != 0 || stats.Tasks != 0 {
+		t.Fatalf("expected nothing left after the cascade, got %+v", *stats)
+	}
+}
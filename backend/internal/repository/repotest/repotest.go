@@ -0,0 +1,182 @@
+// Package repotest is a conformance suite for the domain repositories. Each
+// storage implementation runs it against its own repositories so they stay
+// interchangeable: same ordering, same filters, same ErrNotFound and
+// ErrConflict cases, same cascading deletes.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+// Repositories are the implementations under test. ProjectMembers is only
+// used to build fixtures.
+type Repositories struct {
+	Users          domain.UserRepository
+	RefreshTokens  domain.RefreshTokenRepository
+	AccessTokens   domain.PersonalAccessTokenRepository
+	Organizations  domain.OrganizationRepository
+	OrgMembers     domain.OrganizationMemberRepository
+	Projects       domain.ProjectRepository
+	ProjectMembers domain.ProjectMemberRepository
+	Boards         domain.BoardRepository
+	Columns        domain.ColumnRepository
+	Tasks          domain.TaskRepository
+	Comments       domain.CommentRepository
+	Labels         domain.LabelRepository
+	AuditEvents    domain.AuditEventRepository
+	Transitions    domain.TaskTransitionRepository
+	TwoFactor      domain.TwoFactorRepository
+	Tx             domain.TxManager
+}
+
+// Run runs the suite. open is called once per test and must return
+// repositories over empty storage.
+func Run(t *testing.T, open func(t *testing.T) Repositories) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, r Repositories)
+	}{
+		{"Users", testUsers},
+		{"UserList", testUserList},
+		{"UserStats", testUserStats},
+		{"RefreshTokens", testRefreshTokens},
+		{"RefreshTokenDeletes", testRefreshTokenDeletes},
+		{"AccessTokens", testAccessTokens},
+		{"TwoFactorChallenges", testTwoFactorChallenges},
+		{"Organizations", testOrganizations},
+		{"Projects", testProjects},
+		{"Boards", testBoards},
+		{"Columns", testColumns},
+		{"Tasks", testTasks},
+		{"TaskFilter", testTaskFilter},
+		{"Comments", testComments},
+		{"Labels", testLabels},
+		{"AuditEvents", testAuditEvents},
+		{"TaskTransitions", testTaskTransitions},
+		{"Cascades", testCascades},
+		{"Transactions", testTransactions},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.fn(t, open(t))
+		})
+	}
+}
+
+var ctx = context.Background()
+
+// base is truncated to microseconds, the precision postgres stores.
+var base = time.Now().UTC().Truncate(time.Microsecond)
+
+func at(minutes int) time.Time {
+	return base.Add(time.Duration(minutes) * time.Minute)
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func wantErr(t *testing.T, err, want error) {
+	t.Helper()
+	if !errors.Is(err, want) {
+		t.Fatalf("expected %v, got %v", want, err)
+	}
+}
+
+func ids[T any](items []*T, id func(*T) uuid.UUID) []uuid.UUID {
+	out := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		out[i] = id(item)
+	}
+	return out
+}
+
+func wantIDs(t *testing.T, got []uuid.UUID, want ...uuid.UUID) {
+	t.Helper()
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+// Fixtures
+
+func newUser(t *testing.T, r Repositories, email string, createdAt time.Time) *domain.User {
+	t.Helper()
+	u := &domain.User{
+		ID: uuid.New(), Email: email, Name: "User " + email, PasswordHash: "hash",
+		Role: domain.UserRoleMember, CreatedAt: createdAt, UpdatedAt: createdAt,
+	}
+	must(t, r.Users.Create(ctx, u))
+	return u
+}
+
+func newProject(t *testing.T, r Repositories, owner *domain.User, createdAt time.Time) *domain.Project {
+	t.Helper()
+	org := &domain.Organization{ID: uuid.New(), Name: "Org", CreatedAt: createdAt, UpdatedAt: createdAt}
+	must(t, r.Organizations.Create(ctx, org))
+	p := &domain.Project{
+		ID: uuid.New(), OrganizationID: org.ID, Name: "Project", OwnerID: owner.ID,
+		CreatedAt: createdAt, UpdatedAt: createdAt,
+	}
+	must(t, r.Projects.Create(ctx, p))
+	return p
+}
+
+func newBoard(t *testing.T, r Repositories, project *domain.Project, createdAt time.Time) *domain.Board {
+	t.Helper()
+	b := &domain.Board{ID: uuid.New(), ProjectID: project.ID, Name: "Board", CreatedAt: createdAt, UpdatedAt: createdAt}
+	must(t, r.Boards.Create(ctx, b))
+	return b
+}
+
+func newColumn(t *testing.T, r Repositories, board *domain.Board, position float64) *domain.Column {
+	t.Helper()
+	c := &domain.Column{ID: uuid.New(), BoardID: board.ID, Name: "Column", Position: position, CreatedAt: base, UpdatedAt: base}
+	must(t, r.Columns.Create(ctx, c))
+	return c
+}
+
+func newTask(t *testing.T, r Repositories, col *domain.Column, position float64, priority string, assignee *uuid.UUID) *domain.Task {
+	t.Helper()
+	task := &domain.Task{
+		ID: uuid.New(), ColumnID: col.ID, Title: "Task", Priority: priority, AssigneeID: assignee,
+		Position: position, CreatedAt: base, UpdatedAt: base,
+	}
+	must(t, r.Tasks.Create(ctx, task))
+	return task
+}
+
+func newComment(t *testing.T, r Repositories, task *domain.Task, author *domain.User, createdAt time.Time) *domain.Comment {
+	t.Helper()
+	c := &domain.Comment{ID: uuid.New(), TaskID: task.ID, AuthorID: author.ID, Content: "Comment", CreatedAt: createdAt, UpdatedAt: createdAt}
+	must(t, r.Comments.Create(ctx, c))
+	return c
+}
+
+func newLabel(t *testing.T, r Repositories, project *domain.Project, name string) *domain.Label {
+	t.Helper()
+	l := &domain.Label{ID: uuid.New(), ProjectID: project.ID, Name: name, Color: "#ff0000", CreatedAt: base}
+	must(t, r.Labels.Create(ctx, l))
+	return l
+}
+
+func newRefreshToken(t *testing.T, r Repositories, user *domain.User, family uuid.UUID, lastUsed, expires time.Time) *domain.RefreshToken {
+	t.Helper()
+	rt := &domain.RefreshToken{
+		ID: uuid.New(), FamilyID: family, UserID: user.ID, TokenHash: uuid.NewString(),
+		ExpiresAt: expires, LastUsedAt: lastUsed, CreatedAt: base,
+	}
+	must(t, r.RefreshTokens.Create(ctx, rt))
+	return rt
+}
@@ -0,0 +1,57 @@
+package repotest
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+func testTaskTransitions(t *testing.T, r Repositories) {
+	owner := newUser(t, r, "owner@example.com", base)
+	project := newProject(t, r, owner, base)
+	board := newBoard(t, r, project, base)
+	todo := newColumn(t, r, board, 1)
+	doing := newColumn(t, r, board, 2)
+	other := newColumn(t, r, newBoard(t, r, project, base), 1)
+
+	task := newTask(t, r, todo, 1, "low", nil)
+	moved := newTask(t, r, todo, 2, "low", nil)
+	elsewhere := newTask(t, r, other, 1, "low", nil)
+
+	newTransition := func(task *domain.Task, from *uuid.UUID, to uuid.UUID, minute int) *domain.TaskTransition {
+		tr := &domain.TaskTransition{ID: uuid.New(), TaskID: task.ID, FromColumnID: from, ToColumnID: to, ActorID: owner.ID, CreatedAt: at(minute)}
+		must(t, r.Transitions.Create(ctx, tr))
+		return tr
+	}
+	later := newTransition(task, &todo.ID, doing.ID, 2)
+	created := newTransition(task, nil, todo.ID, 0)
+	movedIn := newTransition(moved, nil, todo.ID, 1)
+	newTransition(elsewhere, nil, other.ID, 0)
+	wantErr(t, r.Transitions.Create(ctx, created), domain.ErrConflict)
+
+	transitionID := func(tr *domain.TaskTransition) uuid.UUID { return tr.ID }
+	got, err := r.Transitions.ListByBoard(ctx, board.ID)
+	must(t, err)
+	wantIDs(t, ids(got, transitionID), created.ID, movedIn.ID, later.ID)
+	first, last := got[0], got[2]
+	if first.FromColumnID != nil || first.ToColumnID != todo.ID || first.ActorID != owner.ID || !first.CreatedAt.Equal(at(0)) {
+		t.Fatalf("unexpected first transition %+v", first)
+	}
+	if last.FromColumnID == nil || *last.FromColumnID != todo.ID || last.ToColumnID != doing.ID {
+		t.Fatalf("unexpected last transition %+v", last)
+	}
+
+	// Transitions follow the task, not the column they were recorded in.
+	moved.ColumnID = other.ID
+	must(t, r.Tasks.Update(ctx, moved))
+	got, err = r.Transitions.ListByBoard(ctx, board.ID)
+	must(t, err)
+	wantIDs(t, ids(got, transitionID), created.ID, later.ID)
+
+	must(t, r.Tasks.Delete(ctx, task.ID))
+	got, err = r.Transitions.ListByBoard(ctx, board.ID)
+	must(t, err)
+	wantIDs(t, ids(got, transitionID))
+}
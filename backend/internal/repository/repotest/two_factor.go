@@ -0,0 +1,40 @@
+package repotest
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+func testTwoFactorChallenges(t *testing.T, r Repositories) {
+	user := newUser(t, r, "ada@example.com", base)
+	newChallenge := func(expiresAt int) *domain.TwoFactorChallenge {
+		c := &domain.TwoFactorChallenge{ID: uuid.New(), UserID: user.ID, ExpiresAt: at(expiresAt), CreatedAt: base}
+		must(t, r.TwoFactor.CreateChallenge(ctx, c))
+		return c
+	}
+	c := newChallenge(5)
+	expired := newChallenge(1)
+	wantErr(t, r.TwoFactor.CreateChallenge(ctx, c), domain.ErrConflict)
+
+	// Attempts are limited, and only count while the challenge is open.
+	must(t, r.TwoFactor.UseChallengeAttempt(ctx, c.ID, 2, at(2)))
+	must(t, r.TwoFactor.UseChallengeAttempt(ctx, c.ID, 2, at(2)))
+	wantErr(t, r.TwoFactor.UseChallengeAttempt(ctx, c.ID, 2, at(2)), domain.ErrNotFound)
+	must(t, r.TwoFactor.UseChallengeAttempt(ctx, c.ID, 3, at(2)))
+	wantErr(t, r.TwoFactor.UseChallengeAttempt(ctx, expired.ID, 3, at(2)), domain.ErrNotFound)
+	wantErr(t, r.TwoFactor.UseChallengeAttempt(ctx, uuid.New(), 3, at(2)), domain.ErrNotFound)
+
+	must(t, r.TwoFactor.ConsumeChallenge(ctx, c.ID, at(3)))
+	wantErr(t, r.TwoFactor.ConsumeChallenge(ctx, c.ID, at(3)), domain.ErrNotFound)
+	wantErr(t, r.TwoFactor.UseChallengeAttempt(ctx, c.ID, 10, at(3)), domain.ErrNotFound)
+
+	n, err := r.TwoFactor.DeleteExpiredChallenges(ctx, at(2))
+	must(t, err)
+	if n != 1 {
+		t.Fatalf("expected one expired challenge deleted, got %d", n)
+	}
+	wantErr(t, r.TwoFactor.ConsumeChallenge(ctx, expired.ID, at(3)), domain.ErrNotFound)
+}
@@ -0,0 +1,41 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+func testTransactions(t *testing.T, r Repositories) {
+	var committed, rolledBack *domain.User
+	must(t, r.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		committed = &domain.User{ID: uuid.New(), Email: "kept@example.com", Name: "Kept", PasswordHash: "x", Role: domain.UserRoleMember, CreatedAt: base, UpdatedAt: base}
+		return r.Users.Create(ctx, committed)
+	}))
+
+	errAbort := errors.New("abort")
+	err := r.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		rolledBack = &domain.User{ID: uuid.New(), Email: "dropped@example.com", Name: "Dropped", PasswordHash: "x", Role: domain.UserRoleMember, CreatedAt: base, UpdatedAt: base}
+		if err := r.Users.Create(ctx, rolledBack); err != nil {
+			return err
+		}
+		if err := r.Users.UpdateRole(ctx, committed.ID, domain.UserRoleAdmin, at(1)); err != nil {
+			return err
+		}
+		// Nested units of work join the outer one.
+		return r.Tx.WithinTx(ctx, func(ctx context.Context) error { return errAbort })
+	})
+	wantErr(t, err, errAbort)
+
+	got, err := r.Users.GetByID(ctx, committed.ID)
+	must(t, err)
+	if got.Role != domain.UserRoleMember {
+		t.Fatalf("expected role change to be rolled back, got %q", got.Role)
+	}
+	_, err = r.Users.GetByID(ctx, rolledBack.ID)
+	wantErr(t, err, domain.ErrNotFound)
+}
@@ -0,0 +1,247 @@
+package repotest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+func testUsers(t *testing.T, r Repositories) {
+	u := newUser(t, r, "ada@example.com", base)
+
+	dup := &domain.User{ID: uuid.New(), Email: "ada@example.com", Name: "Other", PasswordHash: "x", Role: domain.UserRoleMember, CreatedAt: base, UpdatedAt: base}
+	wantErr(t, r.Users.Create(ctx, dup), domain.ErrConflict)
+
+	got, err := r.Users.GetByEmail(ctx, "ada@example.com")
+	must(t, err)
+	if got.ID != u.ID || got.Name != u.Name || got.PasswordHash != "hash" || !got.CreatedAt.Equal(base) {
+		t.Fatalf("GetByEmail returned %+v", got)
+	}
+	_, err = r.Users.GetByEmail(ctx, "nobody@example.com")
+	wantErr(t, err, domain.ErrNotFound)
+	_, err = r.Users.GetByID(ctx, uuid.New())
+	wantErr(t, err, domain.ErrNotFound)
+
+	u.Name = "Ada Lovelace"
+	u.UpdatedAt = at(1)
+	must(t, r.Users.Update(ctx, u))
+
+	must(t, r.Users.UpdatePassword(ctx, u.ID, "new-hash", at(2)))
+	must(t, r.Users.MarkEmailVerified(ctx, u.ID, at(3)))
+	must(t, r.Users.UpdateRole(ctx, u.ID, domain.UserRoleAdmin, at(4)))
+	deactivated := at(5)
+	must(t, r.Users.SetDeactivated(ctx, u.ID, &deactivated, at(5)))
+
+	got, err = r.Users.GetByID(ctx, u.ID)
+	must(t, err)
+	switch {
+	case got.Name != "Ada Lovelace", got.PasswordHash != "new-hash", got.Role != domain.UserRoleAdmin:
+		t.Fatalf("updates not applied: %+v", got)
+	case got.EmailVerifiedAt == nil || !got.EmailVerifiedAt.Equal(at(3)):
+		t.Fatalf("expected email verified at %v, got %v", at(3), got.EmailVerifiedAt)
+	case got.DeactivatedAt == nil || !got.DeactivatedAt.Equal(deactivated):
+		t.Fatalf("expected deactivated at %v, got %v", deactivated, got.DeactivatedAt)
+	case !got.UpdatedAt.Equal(at(5)):
+		t.Fatalf("expected updated at %v, got %v", at(5), got.UpdatedAt)
+	}
+
+	must(t, r.Users.SetDeactivated(ctx, u.ID, nil, at(6)))
+	got, err = r.Users.GetByID(ctx, u.ID)
+	must(t, err)
+	if got.DeactivatedAt != nil {
+		t.Fatalf("expected reactivated user, got deactivated at %v", got.DeactivatedAt)
+	}
+
+	missing := uuid.New()
+	wantErr(t, r.Users.UpdatePassword(ctx, missing, "x", base), domain.ErrNotFound)
+	wantErr(t, r.Users.MarkEmailVerified(ctx, missing, base), domain.ErrNotFound)
+	wantErr(t, r.Users.UpdateRole(ctx, missing, domain.UserRoleAdmin, base), domain.ErrNotFound)
+	wantErr(t, r.Users.SetDeactivated(ctx, missing, nil, base), domain.ErrNotFound)
+}
+
+func testUserList(t *testing.T, r Repositories) {
+	a := newUser(t, r, "alice@example.com", at(1))
+	b := newUser(t, r, "bob@example.com", at(2))
+	c := newUser(t, r, "carol@corp.test", at(3))
+
+	userID := func(u *domain.User) uuid.UUID { return u.ID }
+
+	users, err := r.Users.List(ctx, domain.UserFilter{Limit: 10})
+	must(t, err)
+	wantIDs(t, ids(users, userID), a.ID, b.ID, c.ID)
+
+	users, err = r.Users.List(ctx, domain.UserFilter{Query: "EXAMPLE", Limit: 10})
+	must(t, err)
+	wantIDs(t, ids(users, userID), a.ID, b.ID)
+
+	users, err = r.Users.List(ctx, domain.UserFilter{Query: "user carol", Limit: 10})
+	must(t, err)
+	wantIDs(t, ids(users, userID), c.ID)
+
+	users, err = r.Users.List(ctx, domain.UserFilter{Limit: 1, Offset: 1})
+	must(t, err)
+	wantIDs(t, ids(users, userID), b.ID)
+
+	users, err = r.Users.List(ctx, domain.UserFilter{Limit: 10, Offset: 5})
+	must(t, err)
+	if len(users) != 0 {
+		t.Fatalf("expected no users past the end, got %d", len(users))
+	}
+}
+
+func testUserStats(t *testing.T, r Repositories) {
+	owner := newUser(t, r, "owner@example.com", base)
+	admin := newUser(t, r, "admin@example.com", base)
+	gone := newUser(t, r, "gone@example.com", base)
+	must(t, r.Users.UpdateRole(ctx, admin.ID, domain.UserRoleAdmin, base))
+	deactivated := base
+	must(t, r.Users.SetDeactivated(ctx, gone.ID, &deactivated, base))
+
+	project := newProject(t, r, owner, base)
+	board := newBoard(t, r, project, base)
+	col := newColumn(t, r, board, 1)
+	newTask(t, r, col, 1, "low", nil)
+	newTask(t, r, col, 2, "low", nil)
+
+	stats, err := r.Users.Stats(ctx)
+	must(t, err)
+	want := domain.SystemStats{Users: 3, DeactivatedUsers: 1, Admins: 1, Projects: 1, Boards: 1, Tasks: 2}
+	if *stats != want {
+		t.Fatalf("expected %+v, got %+v", want, *stats)
+	}
+}
+
+func testRefreshTokens(t *testing.T, r Repositories) {
+	u := newUser(t, r, "ada@example.com", base)
+	other := newUser(t, r, "bob@example.com", base)
+	future := time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
+	past := time.Now().Add(-time.Hour).UTC().Truncate(time.Microsecond)
+
+	older := newRefreshToken(t, r, u, uuid.New(), at(1), future)
+	newer := newRefreshToken(t, r, u, uuid.New(), at(2), future)
+	newRefreshToken(t, r, u, uuid.New(), at(3), past)
+	newRefreshToken(t, r, other, uuid.New(), at(4), future)
+
+	got, err := r.RefreshTokens.GetByTokenHash(ctx, older.TokenHash)
+	must(t, err)
+	if got.ID != older.ID || got.FamilyID != older.FamilyID || got.RotatedAt != nil {
+		t.Fatalf("GetByTokenHash returned %+v", got)
+	}
+	_, err = r.RefreshTokens.GetByTokenHash(ctx, "missing")
+	wantErr(t, err, domain.ErrNotFound)
+
+	tokenID := func(rt *domain.RefreshToken) uuid.UUID { return rt.ID }
+
+	tokens, err := r.RefreshTokens.ListByUser(ctx, u.ID)
+	must(t, err)
+	wantIDs(t, ids(tokens, tokenID), newer.ID, older.ID)
+
+	next := &domain.RefreshToken{
+		ID: uuid.New(), FamilyID: older.FamilyID, UserID: u.ID, TokenHash: uuid.NewString(),
+		ExpiresAt: future, LastUsedAt: at(5), CreatedAt: at(5),
+	}
+	must(t, r.RefreshTokens.Rotate(ctx, older.ID, at(5), next))
+
+	// A rotated token can't be rotated again, but is still found so reuse
+	// can be detected.
+	again := &domain.RefreshToken{
+		ID: uuid.New(), FamilyID: older.FamilyID, UserID: u.ID, TokenHash: uuid.NewString(),
+		ExpiresAt: future, LastUsedAt: at(6), CreatedAt: at(6),
+	}
+	wantErr(t, r.RefreshTokens.Rotate(ctx, older.ID, at(6), again), domain.ErrNotFound)
+	_, err = r.RefreshTokens.GetByTokenHash(ctx, again.TokenHash)
+	wantErr(t, err, domain.ErrNotFound)
+
+	got, err = r.RefreshTokens.GetByTokenHash(ctx, older.TokenHash)
+	must(t, err)
+	if got.RotatedAt == nil || !got.RotatedAt.Equal(at(5)) {
+		t.Fatalf("expected rotated at %v, got %v", at(5), got.RotatedAt)
+	}
+
+	tokens, err = r.RefreshTokens.ListByUser(ctx, u.ID)
+	must(t, err)
+	wantIDs(t, ids(tokens, tokenID), next.ID, newer.ID)
+}
+
+func testRefreshTokenDeletes(t *testing.T, r Repositories) {
+	u := newUser(t, r, "ada@example.com", base)
+	other := newUser(t, r, "bob@example.com", base)
+	future := time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
+
+	familyA, familyB, familyC := uuid.New(), uuid.New(), uuid.New()
+	a1 := newRefreshToken(t, r, u, familyA, at(1), future)
+	a2 := newRefreshToken(t, r, u, familyA, at(2), future)
+	b := newRefreshToken(t, r, u, familyB, at(3), future)
+	c := newRefreshToken(t, r, u, familyC, at(4), future)
+	o := newRefreshToken(t, r, other, uuid.New(), at(5), future)
+
+	// Deleting by any token's hash revokes the whole family.
+	must(t, r.RefreshTokens.DeleteByTokenHash(ctx, a1.TokenHash))
+	for _, rt := range []*domain.RefreshToken{a1, a2} {
+		_, err := r.RefreshTokens.GetByTokenHash(ctx, rt.TokenHash)
+		wantErr(t, err, domain.ErrNotFound)
+	}
+
+	wantErr(t, r.RefreshTokens.DeleteFamily(ctx, other.ID, familyB), domain.ErrNotFound)
+	must(t, r.RefreshTokens.DeleteFamily(ctx, u.ID, familyB))
+	wantErr(t, r.RefreshTokens.DeleteFamily(ctx, u.ID, familyB), domain.ErrNotFound)
+
+	d := newRefreshToken(t, r, u, uuid.New(), at(6), future)
+	must(t, r.RefreshTokens.DeleteOthers(ctx, u.ID, familyC))
+	_, err := r.RefreshTokens.GetByTokenHash(ctx, d.TokenHash)
+	wantErr(t, err, domain.ErrNotFound)
+	_, err = r.RefreshTokens.GetByTokenHash(ctx, c.TokenHash)
+	must(t, err)
+	_, err = r.RefreshTokens.GetByTokenHash(ctx, b.TokenHash)
+	wantErr(t, err, domain.ErrNotFound)
+
+	must(t, r.RefreshTokens.DeleteByUserID(ctx, u.ID))
+	_, err = r.RefreshTokens.GetByTokenHash(ctx, c.TokenHash)
+	wantErr(t, err, domain.ErrNotFound)
+
+	newRefreshToken(t, r, other, uuid.New(), at(7), at(-10))
+	newRefreshToken(t, r, other, uuid.New(), at(8), at(-5))
+	n, err := r.RefreshTokens.DeleteExpired(ctx, at(-1))
+	must(t, err)
+	if n != 2 {
+		t.Fatalf("expected 2 expired tokens deleted, got %d", n)
+	}
+	_, err = r.RefreshTokens.GetByTokenHash(ctx, o.TokenHash)
+	must(t, err)
+}
+
+func testAccessTokens(t *testing.T, r Repositories) {
+	u := newUser(t, r, "ada@example.com", base)
+	other := newUser(t, r, "bob@example.com", base)
+
+	newToken := func(user *domain.User) *domain.PersonalAccessToken {
+		pat := &domain.PersonalAccessToken{
+			ID: uuid.New(), UserID: user.ID, Name: "ci", Prefix: "pat_", TokenHash: uuid.NewString(),
+			Scope: domain.TokenScopeRead, CreatedAt: base,
+		}
+		must(t, r.AccessTokens.Create(ctx, pat))
+		return pat
+	}
+	first, second := newToken(u), newToken(u)
+	kept := newToken(other)
+
+	wantErr(t, r.AccessTokens.Delete(ctx, first.ID, other.ID), domain.ErrNotFound)
+	must(t, r.AccessTokens.Delete(ctx, first.ID, u.ID))
+	_, err := r.AccessTokens.GetByTokenHash(ctx, first.TokenHash)
+	wantErr(t, err, domain.ErrNotFound)
+
+	newToken(u)
+	must(t, r.AccessTokens.DeleteByUserID(ctx, u.ID))
+	tokens, err := r.AccessTokens.ListByUser(ctx, u.ID)
+	must(t, err)
+	if len(tokens) != 0 {
+		t.Fatalf("expected all of the user's tokens deleted, got %d", len(tokens))
+	}
+	_, err = r.AccessTokens.GetByTokenHash(ctx, second.TokenHash)
+	wantErr(t, err, domain.ErrNotFound)
+	_, err = r.AccessTokens.GetByTokenHash(ctx, kept.TokenHash)
+	must(t, err)
+}
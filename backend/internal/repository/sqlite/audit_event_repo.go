@@ -0,0 +1,111 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type AuditEventRepo struct {
+	db *sql.DB
+}
+
+func NewAuditEventRepo(db *sql.DB) *AuditEventRepo {
+	return &AuditEventRepo{db: db}
+}
+
+func (r *AuditEventRepo) Create(ctx context.Context, event *domain.AuditEvent) error {
+	query := `
+		INSERT INTO audit_events (id, actor_id, project_id, entity_type, entity_id, action, before, after, ip_address, request_id, created_at)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		event.ID, event.ActorID, event.ProjectID, event.EntityType, event.EntityID, event.Action,
+		jsonArg(event.Before), jsonArg(event.After), event.IP, event.RequestID, event.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *AuditEventRepo) List(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEvent, error) {
+	conditions := []string{"1 = 1"}
+	var args []interface{}
+	argIdx := 1
+
+	if filter.ProjectID != nil {
+		conditions = append(conditions, fmt.Sprintf("project_id = ?%d", argIdx))
+		args = append(args, *filter.ProjectID)
+		argIdx++
+	}
+	if filter.ActorID != nil {
+		conditions = append(conditions, fmt.Sprintf("actor_id = ?%d", argIdx))
+		args = append(args, *filter.ActorID)
+		argIdx++
+	}
+	if filter.EntityType != "" {
+		conditions = append(conditions, fmt.Sprintf("entity_type = ?%d", argIdx))
+		args = append(args, filter.EntityType)
+		argIdx++
+	}
+	if filter.EntityID != nil {
+		conditions = append(conditions, fmt.Sprintf("entity_id = ?%d", argIdx))
+		args = append(args, *filter.EntityID)
+		argIdx++
+	}
+	if filter.Since != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at >= ?%d", argIdx))
+		args = append(args, *filter.Since)
+		argIdx++
+	}
+	if filter.Until != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at < ?%d", argIdx))
+		args = append(args, *filter.Until)
+		argIdx++
+	}
+	args = append(args, filter.Limit, filter.Offset)
+
+	query := fmt.Sprintf(`
+		SELECT id, actor_id, project_id, entity_type, entity_id, action, before, after, ip_address, request_id, created_at
+		FROM audit_events
+		WHERE %s
+		ORDER BY created_at DESC, id
+		LIMIT ?%d OFFSET ?%d`, strings.Join(conditions, " AND "), argIdx, argIdx+1)
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*domain.AuditEvent
+	for rows.Next() {
+		e := &domain.AuditEvent{}
+		var before, after []byte
+		if err := rows.Scan(
+			&e.ID, &e.ActorID, &e.ProjectID, &e.EntityType, &e.EntityID, &e.Action,
+			&before, &after, &e.IP, &e.RequestID, &e.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		e.Before, e.After = before, after
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// jsonArg passes a JSON document as text, or NULL when there is none.
+func jsonArg(v json.RawMessage) any {
+	if len(v) == 0 {
+		return nil
+	}
+	return string(v)
+}
@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type ColumnRepo struct {
+	db *sql.DB
+}
+
+func NewColumnRepo(db *sql.DB) *ColumnRepo {
+	return &ColumnRepo{db: db}
+}
+
+func (r *ColumnRepo) Create(ctx context.Context, col *domain.Column) error {
+	query := `
+		INSERT INTO columns (id, board_id, name, position, category, created_at, updated_at)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		col.ID, col.BoardID, col.Name, col.Position, col.Category, col.CreatedAt, col.UpdatedAt,
+	)
+	return err
+}
+
+func (r *ColumnRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Column, error) {
+	query := `
+		SELECT id, board_id, name, position, category, created_at, updated_at
+		FROM columns WHERE id = ?1`
+
+	col := &domain.Column{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
+		&col.ID, &col.BoardID, &col.Name, &col.Position, &col.Category, &col.CreatedAt, &col.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return col, nil
+}
+
+func (r *ColumnRepo) ListByBoard(ctx context.Context, boardID uuid.UUID) ([]*domain.Column, error) {
+	query := `
+		SELECT id, board_id, name, position, category, created_at, updated_at
+		FROM columns WHERE board_id = ?1
+		ORDER BY position ASC`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, boardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []*domain.Column
+	for rows.Next() {
+		col := &domain.Column{}
+		if err := rows.Scan(&col.ID, &col.BoardID, &col.Name, &col.Position, &col.Category, &col.CreatedAt, &col.UpdatedAt); err != nil {
+			return nil, err
+		}
+		columns = append(columns, col)
+	}
+	return columns, rows.Err()
+}
+
+func (r *ColumnRepo) Update(ctx context.Context, col *domain.Column) error {
+	query := `
+		UPDATE columns SET name = ?1, position = ?2, category = ?3, updated_at = ?4
+		WHERE id = ?5`
+
+	n, err := conn(ctx, r.db).Exec(ctx, query, col.Name, col.Position, col.Category, col.UpdatedAt, col.ID)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ColumnRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	n, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM columns WHERE id = ?1`, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
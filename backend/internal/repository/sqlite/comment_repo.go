@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type CommentRepo struct {
+	db *sql.DB
+}
+
+func NewCommentRepo(db *sql.DB) *CommentRepo {
+	return &CommentRepo{db: db}
+}
+
+func (r *CommentRepo) Create(ctx context.Context, comment *domain.Comment) error {
+	query := `
+		INSERT INTO comments (id, task_id, author_id, content, created_at, updated_at)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6)`
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		comment.ID, comment.TaskID, comment.AuthorID,
+		comment.Content, comment.CreatedAt, comment.UpdatedAt,
+	)
+	return err
+}
+
+func (r *CommentRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Comment, error) {
+	query := `
+		SELECT id, task_id, author_id, content, created_at, updated_at
+		FROM comments WHERE id = ?1`
+	c := &domain.Comment{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
+		&c.ID, &c.TaskID, &c.AuthorID, &c.Content, &c.CreatedAt, &c.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+func (r *CommentRepo) ListByTask(ctx context.Context, taskID uuid.UUID) ([]*domain.Comment, error) {
+	query := `
+		SELECT id, task_id, author_id, content, created_at, updated_at
+		FROM comments WHERE task_id = ?1
+		ORDER BY created_at ASC`
+	rows, err := conn(ctx, r.db).Query(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []*domain.Comment
+	for rows.Next() {
+		c := &domain.Comment{}
+		if err := rows.Scan(&c.ID, &c.TaskID, &c.AuthorID, &c.Content, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, err
+		}
+		comments = append(comments, c)
+	}
+	return comments, rows.Err()
+}
+
+func (r *CommentRepo) Update(ctx context.Context, comment *domain.Comment) error {
+	query := `UPDATE comments SET content = ?1, updated_at = ?2 WHERE id = ?3`
+	n, err := conn(ctx, r.db).Exec(ctx, query, comment.Content, comment.UpdatedAt, comment.ID)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *CommentRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	n, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM comments WHERE id = ?1`, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
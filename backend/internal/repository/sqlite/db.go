@@ -0,0 +1,31 @@
+// Package sqlite implements the domain repositories on SQLite, for
+// single-user and demo deployments that don't want to run PostgreSQL. Its
+// schema lives in migrations/sqlite.
+package sqlite
+
+import (
+	"database/sql"
+	"net/url"
+
+	_ "modernc.org/sqlite"
+)
+
+// Open opens, creating if needed, the SQLite database at path. Foreign keys
+// are enforced so deletes cascade as they do in postgres.
+func Open(path string) (*sql.DB, error) {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", "synchronous(NORMAL)")
+	params.Set("_time_format", "sqlite")
+
+	db, err := sql.Open("sqlite", "file:"+path+"?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows one writer at a time. A single connection queues writes
+	// here instead of failing them with SQLITE_BUSY.
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type LoginThrottleRepo struct {
+	db *sql.DB
+}
+
+func NewLoginThrottleRepo(db *sql.DB) *LoginThrottleRepo {
+	return &LoginThrottleRepo{db: db}
+}
+
+func (r *LoginThrottleRepo) Get(ctx context.Context, key string) (*domain.LoginThrottle, error) {
+	query := `SELECT key, failures, last_failure_at FROM login_throttles WHERE key = ?1`
+
+	t := &domain.LoginThrottle{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, key).Scan(&t.Key, &t.Failures, &t.LastFailureAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *LoginThrottleRepo) RecordFailure(ctx context.Context, key string, at, windowStart time.Time) (*domain.LoginThrottle, error) {
+	query := `
+		INSERT INTO login_throttles (key, failures, last_failure_at)
+		VALUES (?1, 1, ?2)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE WHEN login_throttles.last_failure_at < ?3 THEN 1 ELSE login_throttles.failures + 1 END,
+			last_failure_at = EXCLUDED.last_failure_at
+		RETURNING key, failures, last_failure_at`
+
+	t := &domain.LoginThrottle{}
+	if err := conn(ctx, r.db).QueryRow(ctx, query, key, at, windowStart).Scan(&t.Key, &t.Failures, &t.LastFailureAt); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *LoginThrottleRepo) Delete(ctx context.Context, key string) error {
+	_, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM login_throttles WHERE key = ?1`, key)
+	return err
+}
+
+func (r *LoginThrottleRepo) DeleteStale(ctx context.Context, before time.Time) (int64, error) {
+	n, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM login_throttles WHERE last_failure_at < ?1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
@@ -0,0 +1,128 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type OrganizationMemberRepo struct {
+	db *sql.DB
+}
+
+func NewOrganizationMemberRepo(db *sql.DB) *OrganizationMemberRepo {
+	return &OrganizationMemberRepo{db: db}
+}
+
+func (r *OrganizationMemberRepo) Create(ctx context.Context, member *domain.OrganizationMember) error {
+	query := `
+		INSERT INTO organization_members (organization_id, user_id, role, created_at, updated_at)
+		VALUES (?1, ?2, ?3, ?4, ?5)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		member.OrganizationID, member.UserID, member.Role, member.CreatedAt, member.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *OrganizationMemberRepo) Get(ctx context.Context, orgID, userID uuid.UUID) (*domain.OrganizationMember, error) {
+	query := `
+		SELECT om.organization_id, om.user_id, om.role, u.email, u.name, om.created_at, om.updated_at
+		FROM organization_members om
+		JOIN users u ON u.id = om.user_id
+		WHERE om.organization_id = ?1 AND om.user_id = ?2`
+
+	m := &domain.OrganizationMember{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, orgID, userID).Scan(
+		&m.OrganizationID, &m.UserID, &m.Role, &m.Email, &m.Name, &m.CreatedAt, &m.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+func (r *OrganizationMemberRepo) ListByOrganization(ctx context.Context, orgID uuid.UUID) ([]*domain.OrganizationMember, error) {
+	query := `
+		SELECT om.organization_id, om.user_id, om.role, u.email, u.name, om.created_at, om.updated_at
+		FROM organization_members om
+		JOIN users u ON u.id = om.user_id
+		WHERE om.organization_id = ?1
+		ORDER BY om.created_at ASC`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*domain.OrganizationMember
+	for rows.Next() {
+		m := &domain.OrganizationMember{}
+		if err := rows.Scan(&m.OrganizationID, &m.UserID, &m.Role, &m.Email, &m.Name, &m.CreatedAt, &m.UpdatedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+func (r *OrganizationMemberRepo) CountByRole(ctx context.Context, orgID uuid.UUID, role domain.OrgRole) (int, error) {
+	var count int
+	err := conn(ctx, r.db).QueryRow(ctx,
+		`SELECT COUNT(*) FROM organization_members WHERE organization_id = ?1 AND role = ?2`, orgID, role,
+	).Scan(&count)
+	return count, err
+}
+
+func (r *OrganizationMemberRepo) Update(ctx context.Context, member *domain.OrganizationMember) error {
+	query := `
+		UPDATE organization_members SET role = ?1, updated_at = ?2
+		WHERE organization_id = ?3 AND user_id = ?4`
+
+	n, err := conn(ctx, r.db).Exec(ctx, query, member.Role, member.UpdatedAt, member.OrganizationID, member.UserID)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *OrganizationMemberRepo) Delete(ctx context.Context, orgID, userID uuid.UUID) error {
+	return withinTx(ctx, r.db, func(ctx context.Context) error {
+		tx := conn(ctx, r.db)
+
+		n, err := tx.Exec(ctx,
+			`DELETE FROM organization_members WHERE organization_id = ?1 AND user_id = ?2`, orgID, userID,
+		)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return domain.ErrNotFound
+		}
+
+		_, err = tx.Exec(ctx, `
+			DELETE FROM project_members
+			WHERE user_id = ?2
+			  AND project_id IN (SELECT id FROM projects WHERE organization_id = ?1)`,
+			orgID, userID,
+		)
+		return err
+	})
+}
@@ -0,0 +1,101 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type PersonalAccessTokenRepo struct {
+	db *sql.DB
+}
+
+func NewPersonalAccessTokenRepo(db *sql.DB) *PersonalAccessTokenRepo {
+	return &PersonalAccessTokenRepo{db: db}
+}
+
+func (r *PersonalAccessTokenRepo) Create(ctx context.Context, t *domain.PersonalAccessToken) error {
+	query := `
+		INSERT INTO personal_access_tokens (id, user_id, name, token_prefix, token_hash, scope, project_id, expires_at, created_at)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		t.ID, t.UserID, t.Name, t.Prefix, t.TokenHash, t.Scope, t.ProjectID, t.ExpiresAt, t.CreatedAt,
+	)
+	return err
+}
+
+func (r *PersonalAccessTokenRepo) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.PersonalAccessToken, error) {
+	query := `
+		SELECT id, user_id, name, token_prefix, token_hash, scope, project_id, expires_at, last_used_at, created_at
+		FROM personal_access_tokens WHERE token_hash = ?1`
+
+	t := &domain.PersonalAccessToken{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, tokenHash).Scan(
+		&t.ID, &t.UserID, &t.Name, &t.Prefix, &t.TokenHash, &t.Scope,
+		&t.ProjectID, &t.ExpiresAt, &t.LastUsedAt, &t.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *PersonalAccessTokenRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.PersonalAccessToken, error) {
+	query := `
+		SELECT id, user_id, name, token_prefix, token_hash, scope, project_id, expires_at, last_used_at, created_at
+		FROM personal_access_tokens WHERE user_id = ?1
+		ORDER BY created_at DESC`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []*domain.PersonalAccessToken
+	for rows.Next() {
+		t := &domain.PersonalAccessToken{}
+		if err := rows.Scan(
+			&t.ID, &t.UserID, &t.Name, &t.Prefix, &t.TokenHash, &t.Scope,
+			&t.ProjectID, &t.ExpiresAt, &t.LastUsedAt, &t.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, rows.Err()
+}
+
+func (r *PersonalAccessTokenRepo) UpdateLastUsed(ctx context.Context, id uuid.UUID, lastUsedAt time.Time) error {
+	_, err := conn(ctx, r.db).Exec(ctx,
+		`UPDATE personal_access_tokens SET last_used_at = ?1 WHERE id = ?2`, lastUsedAt, id,
+	)
+	return err
+}
+
+func (r *PersonalAccessTokenRepo) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	n, err := conn(ctx, r.db).Exec(ctx,
+		`DELETE FROM personal_access_tokens WHERE id = ?1 AND user_id = ?2`, id, userID,
+	)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PersonalAccessTokenRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM personal_access_tokens WHERE user_id = ?1`, userID)
+	return err
+}
@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type ProjectRepo struct {
+	db *sql.DB
+}
+
+func NewProjectRepo(db *sql.DB) *ProjectRepo {
+	return &ProjectRepo{db: db}
+}
+
+func (r *ProjectRepo) Create(ctx context.Context, project *domain.Project) error {
+	query := `
+		INSERT INTO projects (id, organization_id, name, description, owner_id, created_at, updated_at)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		project.ID, project.OrganizationID, project.Name, project.Description, project.OwnerID,
+		project.CreatedAt, project.UpdatedAt,
+	)
+	return err
+}
+
+func (r *ProjectRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
+	query := `
+		SELECT id, organization_id, name, description, owner_id, created_at, updated_at
+		FROM projects WHERE id = ?1`
+
+	p := &domain.Project{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
+		&p.ID, &p.OrganizationID, &p.Name, &p.Description, &p.OwnerID, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+func (r *ProjectRepo) ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*domain.Project, error) {
+	query := `
+		SELECT id, organization_id, name, description, owner_id, created_at, updated_at
+		FROM projects WHERE owner_id = ?1
+		ORDER BY created_at DESC`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []*domain.Project
+	for rows.Next() {
+		p := &domain.Project{}
+		if err := rows.Scan(&p.ID, &p.OrganizationID, &p.Name, &p.Description, &p.OwnerID, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		projects = append(projects, p)
+	}
+	return projects, rows.Err()
+}
+
+func (r *ProjectRepo) ListByMember(ctx context.Context, userID uuid.UUID) ([]*domain.Project, error) {
+	query := `
+		SELECT p.id, p.organization_id, p.name, p.description, p.owner_id, p.created_at, p.updated_at
+		FROM projects p
+		JOIN project_members pm ON pm.project_id = p.id
+		WHERE pm.user_id = ?1
+		ORDER BY p.created_at DESC`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []*domain.Project
+	for rows.Next() {
+		p := &domain.Project{}
+		if err := rows.Scan(&p.ID, &p.OrganizationID, &p.Name, &p.Description, &p.OwnerID, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		projects = append(projects, p)
+	}
+	return projects, rows.Err()
+}
+
+func (r *ProjectRepo) Update(ctx context.Context, project *domain.Project) error {
+	query := `
+		UPDATE projects SET name = ?1, description = ?2, updated_at = ?3
+		WHERE id = ?4`
+
+	n, err := conn(ctx, r.db).Exec(ctx, query,
+		project.Name, project.Description, project.UpdatedAt, project.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ProjectRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	n, err := conn(ctx, r.db).Exec(ctx, `DELETE FROM projects WHERE id = ?1`, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type SecurityEventRepo struct {
+	db *sql.DB
+}
+
+func NewSecurityEventRepo(db *sql.DB) *SecurityEventRepo {
+	return &SecurityEventRepo{db: db}
+}
+
+func (r *SecurityEventRepo) Create(ctx context.Context, event *domain.SecurityEvent) error {
+	query := `
+		INSERT INTO security_events (id, user_id, type, user_agent, ip_address, created_at)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		event.ID, event.UserID, event.Type, event.UserAgent, event.IP, event.CreatedAt,
+	)
+	return err
+}
@@ -0,0 +1,47 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/letyshub/project-management/internal/migrate"
+	"github.com/letyshub/project-management/internal/repository/repotest"
+	"github.com/letyshub/project-management/migrations"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		db, err := Open(filepath.Join(t.TempDir(), "test.db"))
+		if err != nil {
+			t.Fatalf("open: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		m, err := migrate.NewSQLite(db, migrations.SQLite)
+		if err != nil {
+			t.Fatalf("load migrations: %v", err)
+		}
+		if err := m.Up(context.Background()); err != nil {
+			t.Fatalf("migrate: %v", err)
+		}
+
+		return repotest.Repositories{
+			Users:          NewUserRepo(db),
+			RefreshTokens:  NewRefreshTokenRepo(db),
+			AccessTokens:   NewPersonalAccessTokenRepo(db),
+			Organizations:  NewOrganizationRepo(db),
+			OrgMembers:     NewOrganizationMemberRepo(db),
+			Projects:       NewProjectRepo(db),
+			ProjectMembers: NewProjectMemberRepo(db),
+			Boards:         NewBoardRepo(db),
+			Columns:        NewColumnRepo(db),
+			Tasks:          NewTaskRepo(db),
+			Comments:       NewCommentRepo(db),
+			Labels:         NewLabelRepo(db),
+			AuditEvents:    NewAuditEventRepo(db),
+			Transitions:    NewTaskTransitionRepo(db),
+			TwoFactor:      NewTwoFactorRepo(db),
+			Tx:             NewTxManager(db),
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type TaskTransitionRepo struct {
+	db *sql.DB
+}
+
+func NewTaskTransitionRepo(db *sql.DB) *TaskTransitionRepo {
+	return &TaskTransitionRepo{db: db}
+}
+
+func (r *TaskTransitionRepo) Create(ctx context.Context, transition *domain.TaskTransition) error {
+	query := `
+		INSERT INTO task_transitions (id, task_id, from_column_id, to_column_id, actor_id, created_at)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		transition.ID, transition.TaskID, transition.FromColumnID,
+		transition.ToColumnID, transition.ActorID, transition.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *TaskTransitionRepo) ListByBoard(ctx context.Context, boardID uuid.UUID) ([]*domain.TaskTransition, error) {
+	query := `
+		SELECT tt.id, tt.task_id, tt.from_column_id, tt.to_column_id, tt.actor_id, tt.created_at
+		FROM task_transitions tt
+		JOIN tasks t ON t.id = tt.task_id
+		JOIN columns c ON c.id = t.column_id
+		WHERE c.board_id = ?1
+		ORDER BY tt.created_at, tt.id`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, boardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transitions []*domain.TaskTransition
+	for rows.Next() {
+		tr := &domain.TaskTransition{}
+		if err := rows.Scan(&tr.ID, &tr.TaskID, &tr.FromColumnID, &tr.ToColumnID, &tr.ActorID, &tr.CreatedAt); err != nil {
+			return nil, err
+		}
+		transitions = append(transitions, tr)
+	}
+	return transitions, rows.Err()
+}
@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type txKey struct{}
+
+// TxManager implements domain.TxManager. The transaction travels in the
+// context, and every repository picks it up through conn.
+type TxManager struct {
+	db *sql.DB
+}
+
+func NewTxManager(db *sql.DB) *TxManager {
+	return &TxManager{db: db}
+}
+
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTx(ctx, m.db, fn)
+}
+
+// withinTx runs fn in the transaction carried by ctx, or in a new one.
+// Repositories that write several statements at once use it too, since
+// database/sql has no nested transactions to open inside a unit of work.
+func withinTx(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// dbtx is the part of sql.DB and sql.Tx the repositories use.
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type querier struct {
+	dbtx
+}
+
+// conn returns the transaction in ctx, or the database outside a unit of
+// work.
+func conn(ctx context.Context, db *sql.DB) querier {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return querier{tx}
+	}
+	return querier{db}
+}
+
+// Exec runs a statement and returns the number of rows it changed.
+func (q querier) Exec(ctx context.Context, query string, args ...any) (int64, error) {
+	res, err := q.ExecContext(ctx, query, utc(args)...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (q querier) Query(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return q.QueryContext(ctx, query, utc(args)...)
+}
+
+func (q querier) QueryRow(ctx context.Context, query string, args ...any) *sql.Row {
+	return q.QueryRowContext(ctx, query, utc(args)...)
+}
+
+// utc converts time arguments to UTC. SQLite stores timestamps as text,
+// which only compares and sorts correctly when every value has the same
+// offset.
+func utc(args []any) []any {
+	for i, arg := range args {
+		switch t := arg.(type) {
+		case time.Time:
+			args[i] = t.UTC()
+		case *time.Time:
+			if t != nil {
+				args[i] = t.UTC()
+			}
+		}
+	}
+	return args
+}
@@ -0,0 +1,52 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type UserIdentityRepo struct {
+	db *sql.DB
+}
+
+func NewUserIdentityRepo(db *sql.DB) *UserIdentityRepo {
+	return &UserIdentityRepo{db: db}
+}
+
+func (r *UserIdentityRepo) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (id, user_id, issuer, subject, email, created_at)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		identity.ID, identity.UserID, identity.Issuer, identity.Subject, identity.Email, identity.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *UserIdentityRepo) GetBySubject(ctx context.Context, issuer, subject string) (*domain.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, issuer, subject, email, created_at
+		FROM user_identities WHERE issuer = ?1 AND subject = ?2`
+
+	i := &domain.UserIdentity{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, issuer, subject).Scan(
+		&i.ID, &i.UserID, &i.Issuer, &i.Subject, &i.Email, &i.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return i, nil
+}
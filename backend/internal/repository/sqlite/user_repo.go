@@ -0,0 +1,184 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type UserRepo struct {
+	db *sql.DB
+}
+
+func NewUserRepo(db *sql.DB) *UserRepo {
+	return &UserRepo{db: db}
+}
+
+func (r *UserRepo) Create(ctx context.Context, user *domain.User) error {
+	query := `
+		INSERT INTO users (id, email, name, password_hash, role, email_verified_at, deactivated_at, created_at, updated_at)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		user.ID, user.Email, user.Name, user.PasswordHash, user.Role, user.EmailVerifiedAt, user.DeactivatedAt,
+		user.CreatedAt, user.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	query := `
+		SELECT id, email, name, password_hash, role, email_verified_at, deactivated_at, created_at, updated_at
+		FROM users WHERE email = ?1`
+
+	user := &domain.User{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, email).Scan(
+		&user.ID, &user.Email, &user.Name, &user.PasswordHash, &user.Role, &user.EmailVerifiedAt, &user.DeactivatedAt,
+		&user.CreatedAt, &user.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *UserRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	query := `
+		SELECT id, email, name, password_hash, role, email_verified_at, deactivated_at, created_at, updated_at
+		FROM users WHERE id = ?1`
+
+	user := &domain.User{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
+		&user.ID, &user.Email, &user.Name, &user.PasswordHash, &user.Role, &user.EmailVerifiedAt, &user.DeactivatedAt,
+		&user.CreatedAt, &user.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *UserRepo) Update(ctx context.Context, user *domain.User) error {
+	query := `
+		UPDATE users SET name = ?1, updated_at = ?2
+		WHERE id = ?3`
+	_, err := conn(ctx, r.db).Exec(ctx, query, user.Name, user.UpdatedAt, user.ID)
+	return err
+}
+
+func (r *UserRepo) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string, updatedAt time.Time) error {
+	query := `
+		UPDATE users SET password_hash = ?1, updated_at = ?2
+		WHERE id = ?3`
+	n, err := conn(ctx, r.db).Exec(ctx, query, passwordHash, updatedAt, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *UserRepo) MarkEmailVerified(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error {
+	query := `
+		UPDATE users SET email_verified_at = ?1, updated_at = ?1
+		WHERE id = ?2`
+	n, err := conn(ctx, r.db).Exec(ctx, query, verifiedAt, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *UserRepo) List(ctx context.Context, filter domain.UserFilter) ([]*domain.User, error) {
+	query := `
+		SELECT id, email, name, password_hash, role, email_verified_at, deactivated_at, created_at, updated_at
+		FROM users
+		WHERE ?1 = '' OR email LIKE '%' || ?1 || '%' OR name LIKE '%' || ?1 || '%'
+		ORDER BY created_at, id
+		LIMIT ?2 OFFSET ?3`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, filter.Query, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		user := &domain.User{}
+		if err := rows.Scan(
+			&user.ID, &user.Email, &user.Name, &user.PasswordHash, &user.Role, &user.EmailVerifiedAt, &user.DeactivatedAt,
+			&user.CreatedAt, &user.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (r *UserRepo) UpdateRole(ctx context.Context, id uuid.UUID, role string, updatedAt time.Time) error {
+	n, err := conn(ctx, r.db).Exec(ctx, `UPDATE users SET role = ?1, updated_at = ?2 WHERE id = ?3`, role, updatedAt, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *UserRepo) SetDeactivated(ctx context.Context, id uuid.UUID, deactivatedAt *time.Time, updatedAt time.Time) error {
+	n, err := conn(ctx, r.db).Exec(ctx,
+		`UPDATE users SET deactivated_at = ?1, updated_at = ?2 WHERE id = ?3`, deactivatedAt, updatedAt, id,
+	)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *UserRepo) Stats(ctx context.Context) (*domain.SystemStats, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM users),
+			(SELECT COUNT(*) FROM users WHERE deactivated_at IS NOT NULL),
+			(SELECT COUNT(*) FROM users WHERE role = 'admin'),
+			(SELECT COUNT(*) FROM projects),
+			(SELECT COUNT(*) FROM boards),
+			(SELECT COUNT(*) FROM tasks)`
+
+	stats := &domain.SystemStats{}
+	err := conn(ctx, r.db).QueryRow(ctx, query).Scan(
+		&stats.Users, &stats.DeactivatedUsers, &stats.Admins, &stats.Projects, &stats.Boards, &stats.Tasks,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,88 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type UserTokenRepo struct {
+	db *sql.DB
+}
+
+func NewUserTokenRepo(db *sql.DB) *UserTokenRepo {
+	return &UserTokenRepo{db: db}
+}
+
+func (r *UserTokenRepo) Create(ctx context.Context, token *domain.UserToken) error {
+	query := `
+		INSERT INTO user_tokens (id, user_id, purpose, token_hash, expires_at, created_at)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6)`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query,
+		token.ID, token.UserID, token.Purpose, token.TokenHash, token.ExpiresAt, token.CreatedAt,
+	)
+	return err
+}
+
+func (r *UserTokenRepo) GetByTokenHash(ctx context.Context, purpose, tokenHash string) (*domain.UserToken, error) {
+	query := `
+		SELECT id, user_id, purpose, token_hash, expires_at, used_at, created_at
+		FROM user_tokens WHERE purpose = ?1 AND token_hash = ?2`
+
+	t := &domain.UserToken{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, purpose, tokenHash).Scan(
+		&t.ID, &t.UserID, &t.Purpose, &t.TokenHash, &t.ExpiresAt, &t.UsedAt, &t.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *UserTokenRepo) GetLatest(ctx context.Context, userID uuid.UUID, purpose string) (*domain.UserToken, error) {
+	query := `
+		SELECT id, user_id, purpose, token_hash, expires_at, used_at, created_at
+		FROM user_tokens WHERE user_id = ?1 AND purpose = ?2
+		ORDER BY created_at DESC LIMIT 1`
+
+	t := &domain.UserToken{}
+	err := conn(ctx, r.db).QueryRow(ctx, query, userID, purpose).Scan(
+		&t.ID, &t.UserID, &t.Purpose, &t.TokenHash, &t.ExpiresAt, &t.UsedAt, &t.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *UserTokenRepo) MarkUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	n, err := conn(ctx, r.db).Exec(ctx,
+		`UPDATE user_tokens SET used_at = ?1 WHERE id = ?2 AND used_at IS NULL`, usedAt, id,
+	)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *UserTokenRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID, purpose string) error {
+	_, err := conn(ctx, r.db).Exec(ctx,
+		`DELETE FROM user_tokens WHERE user_id = ?1 AND purpose = ?2`, userID, purpose,
+	)
+	return err
+}
@@ -0,0 +1,43 @@
+// Package requestinfo carries details about the calling client through the
+// request context, so services can record them without depending on
+// net/http.
+package requestinfo
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+type Client struct {
+	UserAgent string
+	IP        string
+	RequestID string
+}
+
+type contextKey struct{}
+
+func WithClient(ctx context.Context, c Client) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+// FromContext returns the client stored in ctx, or a zero Client.
+func FromContext(ctx context.Context) Client {
+	c, _ := ctx.Value(contextKey{}).(Client)
+	return c
+}
+
+// Middleware records the caller's user agent, IP and request ID. Run it
+// after chi's RequestID and RealIP so RemoteAddr reflects proxy headers.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+		ctx := WithClient(r.Context(), Client{UserAgent: r.UserAgent(), IP: ip, RequestID: chimiddleware.GetReqID(r.Context())})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/tracing"
+)
+
+const (
+	defaultUserListLimit = 50
+	maxUserListLimit     = 200
+)
+
+// AdminService backs the global admin API. Callers must already be admins;
+// the routes are guarded by middleware.RequireRole.
+type AdminService struct {
+	userRepo         domain.UserRepository
+	refreshTokenRepo domain.RefreshTokenRepository
+	auditService     *AuditService
+	passwordService  *PasswordService
+}
+
+func NewAdminService(
+	userRepo domain.UserRepository,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	auditService *AuditService,
+	passwordService *PasswordService,
+) *AdminService {
+	return &AdminService{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		auditService:     auditService,
+		passwordService:  passwordService,
+	}
+}
+
+func (s *AdminService) ListUsers(ctx context.Context, filter domain.UserFilter) ([]*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "AdminService.ListUsers")
+	defer span.End()
+
+	filter.Query = strings.TrimSpace(filter.Query)
+	if filter.Limit <= 0 {
+		filter.Limit = defaultUserListLimit
+	}
+	filter.Limit = min(filter.Limit, maxUserListLimit)
+	filter.Offset = max(filter.Offset, 0)
+	return s.userRepo.List(ctx, filter)
+}
+
+// Deactivate blocks the user from signing in and ends their sessions.
+// Admins can't deactivate themselves.
+func (s *AdminService) Deactivate(ctx context.Context, actorID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "AdminService.Deactivate")
+	defer span.End()
+
+	if actorID == userID {
+		return fmt.Errorf("%w: you can't deactivate your own account", domain.ErrValidation)
+	}
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	before, after := *user, *user
+	after.DeactivatedAt = &now
+	return s.auditService.apply(ctx, auditEntry{
+		actorID:    actorID,
+		entityType: domain.AuditEntityUser,
+		entityID:   userID,
+		action:     domain.AuditActionDeactivate,
+		before:     &before,
+		after:      &after,
+	}, func(ctx context.Context) error {
+		if err := s.userRepo.SetDeactivated(ctx, userID, &now, now); err != nil {
+			return err
+		}
+		return s.refreshTokenRepo.DeleteByUserID(ctx, userID)
+	})
+}
+
+func (s *AdminService) Reactivate(ctx context.Context, actorID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "AdminService.Reactivate")
+	defer span.End()
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	before, after := *user, *user
+	after.DeactivatedAt = nil
+	return s.auditService.apply(ctx, auditEntry{
+		actorID:    actorID,
+		entityType: domain.AuditEntityUser,
+		entityID:   userID,
+		action:     domain.AuditActionReactivate,
+		before:     &before,
+		after:      &after,
+	}, func(ctx context.Context) error {
+		return s.userRepo.SetDeactivated(ctx, userID, nil, time.Now())
+	})
+}
+
+type SetRoleInput struct {
+	Role string `json:"role"`
+}
+
+// SetRole promotes a user to admin or demotes them to member. Admins can't
+// change their own role, so there is always at least one admin left.
+func (s *AdminService) SetRole(ctx context.Context, actorID, userID uuid.UUID, input SetRoleInput) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "AdminService.SetRole")
+	defer span.End()
+
+	if !domain.ValidUserRole(input.Role) {
+		return nil, fmt.Errorf("%w: role must be %q or %q", domain.ErrValidation, domain.UserRoleMember, domain.UserRoleAdmin)
+	}
+	if actorID == userID {
+		return nil, fmt.Errorf("%w: you can't change your own role", domain.ErrValidation)
+	}
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	before, after := *user, *user
+	after.Role = input.Role
+	err = s.auditService.apply(ctx, auditEntry{
+		actorID:    actorID,
+		entityType: domain.AuditEntityUser,
+		entityID:   userID,
+		action:     domain.AuditActionRoleChange,
+		before:     &before,
+		after:      &after,
+	}, func(ctx context.Context) error {
+		return s.userRepo.UpdateRole(ctx, userID, input.Role, time.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.userRepo.GetByID(ctx, userID)
+}
+
+// ForcePasswordReset makes the user choose a new password via an emailed link.
+func (s *AdminService) ForcePasswordReset(ctx context.Context, actorID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "AdminService.ForcePasswordReset")
+	defer span.End()
+
+	return s.passwordService.ForceReset(ctx, actorID, userID)
+}
+
+func (s *AdminService) Stats(ctx context.Context) (*domain.SystemStats, error) {
+	ctx, span := tracing.Start(ctx, "AdminService.Stats")
+	defer span.End()
+
+	return s.userRepo.Stats(ctx)
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/repository/memory"
+)
+
+type adminFixture struct {
+	*authStack
+	svc    *AdminService
+	mailer *mockMailer
+	tokens *memory.PersonalAccessTokenRepo
+	admin  *domain.User
+}
+
+func newAdminFixture(t *testing.T) *adminFixture {
+	t.Helper()
+	f := &adminFixture{authStack: newAuthStack(t, testAuthConfig), mailer: &mockMailer{}}
+	f.admin = f.addUser(t, "Admin", "admin@example.com", domain.UserRoleAdmin)
+	f.tokens = memory.NewPersonalAccessTokenRepo(f.store)
+	passwords := NewPasswordService(f.users, f.refresh, f.tokens, memory.NewUserTokenRepo(f.store), f.tx, f.auth, f.audit, f.mailer, "http://app", time.Hour, time.Minute)
+	f.svc = NewAdminService(f.users, f.refresh, f.audit, passwords)
+	return f
+}
+
+func (f *adminFixture) login() (*LoginResult, error) {
+	return f.auth.Login(context.Background(), LoginInput{Email: f.user.Email, Password: "password123"})
+}
+
+func TestAdminService_DeactivateBlocksEveryPath(t *testing.T) {
+	f := newAdminFixture(t)
+	ctx := context.Background()
+
+	before, err := f.login()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.svc.Deactivate(ctx, f.admin.ID, f.user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.login(); !errors.Is(err, domain.ErrAccountDeactivated) {
+		t.Errorf("login: expected deactivated, got %v", err)
+	}
+	if _, _, err := f.auth.RefreshToken(ctx, before.Tokens.RefreshToken); err == nil {
+		t.Error("refresh: expected the session to be gone")
+	}
+	if _, _, err := f.auth.Authenticate(ctx, before.Tokens.AccessToken); !errors.Is(err, domain.ErrAccountDeactivated) {
+		t.Errorf("access token: expected deactivated, got %v", err)
+	}
+
+	if err := f.svc.Reactivate(ctx, f.admin.ID, f.user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.login(); err != nil {
+		t.Errorf("expected login after reactivation, got %v", err)
+	}
+}
+
+func TestAdminService_CannotTargetSelf(t *testing.T) {
+	f := newAdminFixture(t)
+	ctx := context.Background()
+
+	if err := f.svc.Deactivate(ctx, f.admin.ID, f.admin.ID); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("deactivate self: expected validation error, got %v", err)
+	}
+	if _, err := f.svc.SetRole(ctx, f.admin.ID, f.admin.ID, SetRoleInput{Role: domain.UserRoleMember}); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("demote self: expected validation error, got %v", err)
+	}
+}
+
+func TestAdminService_SetRole(t *testing.T) {
+	f := newAdminFixture(t)
+	ctx := context.Background()
+
+	if _, err := f.svc.SetRole(ctx, f.admin.ID, f.user.ID, SetRoleInput{Role: "owner"}); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("expected unknown role to be rejected, got %v", err)
+	}
+	user, err := f.svc.SetRole(ctx, f.admin.ID, f.user.ID, SetRoleInput{Role: domain.UserRoleAdmin})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Role != domain.UserRoleAdmin {
+		t.Errorf("expected admin, got %q", user.Role)
+	}
+}
+
+func TestAdminService_ForcePasswordReset(t *testing.T) {
+	f := newAdminFixture(t)
+	ctx := context.Background()
+	f.tokens.Create(ctx, &domain.PersonalAccessToken{ID: uuid.New(), UserID: f.user.ID, TokenHash: "user", Scope: domain.TokenScopeWrite})
+	f.tokens.Create(ctx, &domain.PersonalAccessToken{ID: uuid.New(), UserID: f.admin.ID, TokenHash: "admin", Scope: domain.TokenScopeWrite})
+
+	if err := f.svc.ForcePasswordReset(ctx, f.admin.ID, f.user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.login(); !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Errorf("expected the old password to stop working, got %v", err)
+	}
+	if _, err := f.tokens.GetByTokenHash(ctx, "user"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected the user's access tokens to be revoked, got %v", err)
+	}
+	if _, err := f.tokens.GetByTokenHash(ctx, "admin"); err != nil {
+		t.Errorf("expected other users' access tokens to survive, got %v", err)
+	}
+	if len(f.mailer.sent) != 1 || f.mailer.sent[0].To != f.user.Email {
+		t.Errorf("expected a reset email to %s, got %+v", f.user.Email, f.mailer.sent)
+	}
+}
+
+func TestAdminService_RecordsAudit(t *testing.T) {
+	f := newAdminFixture(t)
+	ctx := context.Background()
+
+	if err := f.svc.Deactivate(ctx, f.admin.ID, f.user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.svc.Reactivate(ctx, f.admin.ID, f.user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.svc.SetRole(ctx, f.admin.ID, f.user.ID, SetRoleInput{Role: domain.UserRoleAdmin}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.svc.ForcePasswordReset(ctx, f.admin.ID, f.user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	events := auditEvents(t, f.store)
+	want := []struct {
+		action, before, after string
+	}{
+		{domain.AuditActionDeactivate, `{"deactivated_at":null}`, ""},
+		{domain.AuditActionReactivate, "", `{"deactivated_at":null}`},
+		{domain.AuditActionRoleChange, `{"role":"member"}`, `{"role":"admin"}`},
+		{domain.AuditActionForcePasswordReset, "", ""},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d audit events, got %d", len(want), len(events))
+	}
+	for i, w := range want {
+		e := events[i]
+		if e.Action != w.action || e.ActorID != f.admin.ID || e.EntityType != domain.AuditEntityUser || e.EntityID != f.user.ID {
+			t.Errorf("event %d: expected %s on the user by the admin, got %+v", i, w.action, e)
+		}
+		if w.before != "" && string(e.Before) != w.before {
+			t.Errorf("event %d: expected before %s, got %s", i, w.before, e.Before)
+		}
+		if w.after != "" && string(e.After) != w.after {
+			t.Errorf("event %d: expected after %s, got %s", i, w.after, e.After)
+		}
+	}
+	if e := events[0]; len(e.After) == 0 || string(e.After) == `{"deactivated_at":null}` {
+		t.Errorf("expected deactivated_at to be set after deactivating, got %s", e.After)
+	}
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/authz"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/logging"
+	"github.com/letyshub/project-management/internal/requestinfo"
+	"github.com/letyshub/project-management/internal/tracing"
+)
+
+const (
+	defaultAuditListLimit = 50
+	maxAuditListLimit     = 500
+)
+
+// AuditService writes the audit log for the other services and reads it back
+// for project admins and global admins.
+type AuditService struct {
+	auditRepo domain.AuditEventRepository
+	txManager domain.TxManager
+	policy    authz.Policy
+}
+
+func NewAuditService(auditRepo domain.AuditEventRepository, txManager domain.TxManager, policy authz.Policy) *AuditService {
+	return &AuditService{auditRepo: auditRepo, txManager: txManager, policy: policy}
+}
+
+// auditEntry describes one change. before and after are the entity as it was
+// and as it is now, or nil for creates and deletes; only the fields that
+// differ are stored.
+type auditEntry struct {
+	actorID    uuid.UUID
+	projectID  *uuid.UUID
+	entityType string
+	entityID   uuid.UUID
+	action     string
+	before     any
+	after      any
+	// keep names fields stored in before and after even when unchanged, for
+	// entities that entityID alone doesn't identify.
+	keep []string
+}
+
+// apply runs fn, which makes the change e describes, and records e in the
+// same transaction, so the event is stored if and only if the change is.
+func (s *AuditService) apply(ctx context.Context, e auditEntry, fn func(ctx context.Context) error) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+		return s.record(ctx, e)
+	})
+}
+
+// record appends an event. Inside a transaction, the event is only stored if
+// the transaction commits.
+func (s *AuditService) record(ctx context.Context, e auditEntry) error {
+	before, after, err := auditDiff(e.before, e.after, e.keep...)
+	if err != nil {
+		return fmt.Errorf("audit %s %s: %w", e.action, e.entityType, err)
+	}
+	client := requestinfo.FromContext(ctx)
+	return s.auditRepo.Create(ctx, &domain.AuditEvent{
+		ID:         uuid.New(),
+		ActorID:    e.actorID,
+		ProjectID:  e.projectID,
+		EntityType: e.entityType,
+		EntityID:   e.entityID,
+		Action:     e.action,
+		Before:     before,
+		After:      after,
+		IP:         client.IP,
+		RequestID:  client.RequestID,
+		CreatedAt:  time.Now(),
+	})
+}
+
+// recordAccount appends an event about userID's account, such as a login.
+// actorID is usually the user themselves, or an admin acting on them. These
+// aren't changes the caller could roll back, so a failure is logged rather
+// than failing the request.
+func (s *AuditService) recordAccount(ctx context.Context, actorID, userID uuid.UUID, action string) {
+	err := s.record(ctx, auditEntry{
+		actorID:    actorID,
+		entityType: domain.AuditEntityUser,
+		entityID:   userID,
+		action:     action,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to record audit event", "action", action, "user_id", userID, "error", err)
+	}
+}
+
+// ListByProject returns a project's audit log to its admins.
+func (s *AuditService) ListByProject(ctx context.Context, projectID, userID uuid.UUID, filter domain.AuditFilter) ([]*domain.AuditEvent, error) {
+	ctx, span := tracing.Start(ctx, "AuditService.ListByProject")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionManage, authz.Project(projectID)); err != nil {
+		return nil, err
+	}
+	filter.ProjectID = &projectID
+	return s.List(ctx, filter)
+}
+
+// List returns the audit log across every project and account. Callers must
+// already be global admins; the route is guarded by middleware.RequireRole.
+func (s *AuditService) List(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEvent, error) {
+	ctx, span := tracing.Start(ctx, "AuditService.List")
+	defer span.End()
+
+	if filter.Since != nil && filter.Until != nil && !filter.Since.Before(*filter.Until) {
+		return nil, fmt.Errorf("%w: since must be before until", domain.ErrValidation)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditListLimit
+	}
+	filter.Limit = min(filter.Limit, maxAuditListLimit)
+	filter.Offset = max(filter.Offset, 0)
+	return s.auditRepo.List(ctx, filter)
+}
+
+// auditDiff encodes before and after as JSON objects holding only the fields
+// that differ between them, plus any fields named in keep. updated_at is left
+// out, since the event has its own timestamp.
+func auditDiff(before, after any, keep ...string) (json.RawMessage, json.RawMessage, error) {
+	b, err := auditFields(before)
+	if err != nil {
+		return nil, nil, err
+	}
+	a, err := auditFields(after)
+	if err != nil {
+		return nil, nil, err
+	}
+	delete(b, "updated_at")
+	delete(a, "updated_at")
+	if b != nil && a != nil {
+		for k, v := range b {
+			if bytes.Equal(v, a[k]) && !slices.Contains(keep, k) {
+				delete(b, k)
+				delete(a, k)
+			}
+		}
+	}
+	bj, err := auditJSON(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	aj, err := auditJSON(a)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bj, aj, nil
+}
+
+func auditFields(v any) (map[string]json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func auditJSON(fields map[string]json.RawMessage) (json.RawMessage, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(fields)
+}
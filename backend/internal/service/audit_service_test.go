@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/repository/memory"
+	"github.com/letyshub/project-management/internal/requestinfo"
+)
+
+type fakeAuditRepo struct {
+	events    []*domain.AuditEvent
+	lastQuery domain.AuditFilter
+}
+
+func (r *fakeAuditRepo) Create(ctx context.Context, event *domain.AuditEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *fakeAuditRepo) List(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEvent, error) {
+	r.lastQuery = filter
+	return r.events, nil
+}
+
+// newTestAuditService returns an audit log over store that lets every actor
+// read it.
+func newTestAuditService(store *memory.Store) *AuditService {
+	return NewAuditService(memory.NewAuditEventRepo(store), memory.NewTxManager(store), &mockPolicy{})
+}
+
+// auditEvents returns the events recorded in store, oldest first.
+func auditEvents(t *testing.T, store *memory.Store) []*domain.AuditEvent {
+	t.Helper()
+	events, err := memory.NewAuditEventRepo(store).List(context.Background(), domain.AuditFilter{Limit: maxAuditListLimit})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Reverse(events)
+	return events
+}
+
+// failingProjectDelete fails every project delete.
+type failingProjectDelete struct {
+	*memory.ProjectRepo
+}
+
+func (r failingProjectDelete) Delete(ctx context.Context, id uuid.UUID) error {
+	return errors.New("delete failed")
+}
+
+func TestAuditDiff(t *testing.T) {
+	before := domain.Project{ID: uuid.New(), Name: "Roadmap", Description: "Q3", UpdatedAt: time.Now()}
+	after := before
+	after.Name = "Roadmap 2"
+	after.UpdatedAt = before.UpdatedAt.Add(time.Minute)
+
+	b, a, err := auditDiff(before, &after)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if string(b) != `{"name":"Roadmap"}` || string(a) != `{"name":"Roadmap 2"}` {
+		t.Errorf("expected only the name to be recorded, got %s -> %s", b, a)
+	}
+
+	b, a, err = auditDiff(nil, &after)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(a, &fields); err != nil {
+		t.Fatalf("decode after: %v", err)
+	}
+	if b != nil || fields["description"] != "Q3" || fields["updated_at"] != nil {
+		t.Errorf("expected a create to record every field but updated_at, got %s -> %s", b, a)
+	}
+}
+
+func TestProjectService_Update_RecordsAudit(t *testing.T) {
+	ownerID := uuid.New()
+	f := newProjectFixture(ownerOnly(ownerID))
+	projectID := f.addProject(t, uuid.New(), ownerID, "Roadmap").ID
+
+	ctx := requestinfo.WithClient(context.Background(), requestinfo.Client{IP: "203.0.113.7", RequestID: "req-1"})
+	name := "Launch"
+	if _, err := f.svc.Update(ctx, projectID, ownerID, UpdateProjectInput{Name: &name}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	events := auditEvents(t, f.store)
+	if len(events) != 1 {
+		t.Fatalf("expected one audit event, got %d", len(events))
+	}
+	e := events[0]
+	if e.ActorID != ownerID || e.ProjectID == nil || *e.ProjectID != projectID || e.EntityID != projectID {
+		t.Errorf("unexpected actor or entity on %+v", e)
+	}
+	if e.EntityType != domain.AuditEntityProject || e.Action != domain.AuditActionUpdate {
+		t.Errorf("expected a project update, got %s %s", e.EntityType, e.Action)
+	}
+	if e.IP != "203.0.113.7" || e.RequestID != "req-1" {
+		t.Errorf("expected the client to be recorded, got ip %q request %q", e.IP, e.RequestID)
+	}
+	if string(e.Before) != `{"name":"Roadmap"}` || string(e.After) != `{"name":"Launch"}` {
+		t.Errorf("unexpected diff %s -> %s", e.Before, e.After)
+	}
+}
+
+func TestProjectService_Delete_NoAuditOnFailure(t *testing.T) {
+	ownerID := uuid.New()
+	f := newProjectFixture(ownerOnly(ownerID))
+	project := f.addProject(t, uuid.New(), ownerID, "Roadmap")
+	svc := NewProjectService(failingProjectDelete{f.projects}, f.members, f.boards, f.columns, f.orgs, f.audit, f.tx, ownerOnly(ownerID))
+
+	if err := svc.Delete(context.Background(), project.ID, ownerID); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if events := auditEvents(t, f.store); len(events) != 0 {
+		t.Errorf("expected no audit event for a failed change, got %d", len(events))
+	}
+}
+
+func TestProjectMemberService_UpdateRole_RecordsAudit(t *testing.T) {
+	ownerID, userID := uuid.New(), uuid.New()
+	f := newProjectFixture(&mockPolicy{})
+	projectID := f.addProject(t, uuid.New(), ownerID, "Roadmap").ID
+	now := time.Now()
+	must(t, f.members.Create(context.Background(), &domain.ProjectMember{ProjectID: projectID, UserID: userID, Role: domain.ProjectRoleMember, CreatedAt: now, UpdatedAt: now}))
+	svc := NewProjectMemberService(f.members, memory.NewUserRepo(f.store), f.projects, memory.NewOrganizationMemberRepo(f.store), f.audit, &mockPolicy{})
+
+	if _, err := svc.UpdateRole(context.Background(), projectID, ownerID, userID, UpdateMemberInput{Role: domain.ProjectRoleAdmin}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.Remove(context.Background(), projectID, ownerID, userID); err != nil {
+		t.Fatal(err)
+	}
+
+	events := auditEvents(t, f.store)
+	if len(events) != 2 {
+		t.Fatalf("expected two audit events, got %d", len(events))
+	}
+	update := events[0]
+	if update.EntityType != domain.AuditEntityProjectMember || update.EntityID != userID || update.ProjectID == nil || *update.ProjectID != projectID {
+		t.Errorf("expected the membership of %s in the project, got %+v", userID, update)
+	}
+	if string(update.Before) != `{"role":"member"}` || string(update.After) != `{"role":"admin"}` {
+		t.Errorf("unexpected diff %s -> %s", update.Before, update.After)
+	}
+	if remove := events[1]; remove.Action != domain.AuditActionDelete || remove.After != nil {
+		t.Errorf("expected a delete with only before, got %s %s -> %s", remove.Action, remove.Before, remove.After)
+	}
+}
+
+func TestAuditService_List_Validation(t *testing.T) {
+	events := &fakeAuditRepo{}
+	svc := NewAuditService(events, memory.NewTxManager(memory.NewStore()), &mockPolicy{})
+
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+	if _, err := svc.List(context.Background(), domain.AuditFilter{Since: &now, Until: &earlier}); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("expected ErrValidation for an empty range, got %v", err)
+	}
+
+	if _, err := svc.List(context.Background(), domain.AuditFilter{Limit: 10_000}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if events.lastQuery.Limit != maxAuditListLimit {
+		t.Errorf("expected limit to be capped at %d, got %d", maxAuditListLimit, events.lastQuery.Limit)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -15,12 +16,36 @@ import (
 
 	"github.com/letyshub/project-management/internal/config"
 	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/jwtkeys"
+	"github.com/letyshub/project-management/internal/logging"
+	"github.com/letyshub/project-management/internal/metrics"
+	"github.com/letyshub/project-management/internal/requestinfo"
+	"github.com/letyshub/project-management/internal/tracing"
+)
+
+// Two-factor login challenges are JWTs signed with their own HMAC key, which
+// is never published with the access token keys, so other services that
+// trust the JWKS can't mistake one for an access token. Each one is
+// also stored under its jti, so it can be exchanged only once and only for
+// twoFactorChallengeAttempts code attempts.
+const (
+	twoFactorAudience          = "2fa-challenge"
+	twoFactorChallengeTTL      = 5 * time.Minute
+	twoFactorChallengeAttempts = 5
 )
 
 type AuthService struct {
-	userRepo         domain.UserRepository
-	refreshTokenRepo domain.RefreshTokenRepository
-	jwtCfg           config.JWTConfig
+	userRepo          domain.UserRepository
+	refreshTokenRepo  domain.RefreshTokenRepository
+	twoFactorRepo     domain.TwoFactorRepository
+	securityEventRepo domain.SecurityEventRepository
+	throttleRepo      domain.LoginThrottleRepository
+	emailVerification *EmailVerificationService
+	auditService      *AuditService
+	keys              *jwtkeys.Set
+	challengeKeys     *jwtkeys.Set
+	jwtCfg            config.JWTConfig
+	authCfg           config.AuthConfig
 }
 
 type TokenPair struct {
@@ -32,14 +57,39 @@ type Claims struct {
 	UserID uuid.UUID `json:"user_id"`
 	Email  string    `json:"email"`
 	Role   string    `json:"role"`
+	// EmailVerified is a snapshot from when the token was issued; clients
+	// refresh after verifying to pick up the change.
+	EmailVerified bool `json:"email_verified"`
+	// SessionID is the refresh token family the access token was issued for.
+	SessionID uuid.UUID `json:"sid"`
 	jwt.RegisteredClaims
 }
 
-func NewAuthService(userRepo domain.UserRepository, refreshTokenRepo domain.RefreshTokenRepository, jwtCfg config.JWTConfig) *AuthService {
+func NewAuthService(
+	userRepo domain.UserRepository,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	twoFactorRepo domain.TwoFactorRepository,
+	securityEventRepo domain.SecurityEventRepository,
+	throttleRepo domain.LoginThrottleRepository,
+	emailVerification *EmailVerificationService,
+	auditService *AuditService,
+	keys *jwtkeys.Set,
+	challengeKeys *jwtkeys.Set,
+	jwtCfg config.JWTConfig,
+	authCfg config.AuthConfig,
+) *AuthService {
 	return &AuthService{
-		userRepo:         userRepo,
-		refreshTokenRepo: refreshTokenRepo,
-		jwtCfg:           jwtCfg,
+		userRepo:          userRepo,
+		refreshTokenRepo:  refreshTokenRepo,
+		twoFactorRepo:     twoFactorRepo,
+		securityEventRepo: securityEventRepo,
+		throttleRepo:      throttleRepo,
+		emailVerification: emailVerification,
+		auditService:      auditService,
+		keys:              keys,
+		challengeKeys:     challengeKeys,
+		jwtCfg:            jwtCfg,
+		authCfg:           authCfg,
 	}
 }
 
@@ -49,17 +99,37 @@ type RegisterInput struct {
 	Password string `json:"password"`
 }
 
+// Register creates an account and emails a verification link to the address.
 func (s *AuthService) Register(ctx context.Context, input RegisterInput) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Register")
+	defer span.End()
+
+	user, err := s.register(ctx, input, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// The account exists at this point, so a mail failure shouldn't fail the
+	// signup; the user can ask for the link again.
+	if err := s.emailVerification.Send(ctx, user); err != nil {
+		logging.FromContext(ctx).Error("failed to send verification email", "user_id", user.ID, "error", err)
+	}
+	return user, nil
+}
+
+// register creates the user. verified is set by flows that have already
+// proven control of the address, such as accepting an emailed invitation.
+func (s *AuthService) register(ctx context.Context, input RegisterInput, verified bool) (*domain.User, error) {
 	if input.Email == "" || input.Name == "" || input.Password == "" {
 		return nil, fmt.Errorf("%w: email, name, and password are required", domain.ErrValidation)
 	}
-	if len(input.Password) < 8 {
-		return nil, fmt.Errorf("%w: password must be at least 8 characters", domain.ErrValidation)
+	if err := validatePassword(input.Password); err != nil {
+		return nil, err
 	}
 
-	hash, err := bcrypt.GenerateFromPassword([]byte(input.Password), 12)
+	hash, err := hashPassword(input.Password)
 	if err != nil {
-		return nil, fmt.Errorf("hashing password: %w", err)
+		return nil, err
 	}
 
 	now := time.Now()
@@ -67,15 +137,19 @@ func (s *AuthService) Register(ctx context.Context, input RegisterInput) (*domai
 		ID:           uuid.New(),
 		Email:        input.Email,
 		Name:         input.Name,
-		PasswordHash: string(hash),
-		Role:         "member",
+		PasswordHash: hash,
+		Role:         domain.UserRoleMember,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}
+	if verified {
+		user.EmailVerifiedAt = &now
+	}
 
 	if err := s.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
+	s.auditService.recordAccount(ctx, user.ID, user.ID, domain.AuditActionRegister)
 
 	return user, nil
 }
@@ -85,31 +159,88 @@ type LoginInput struct {
 	Password string `json:"password"`
 }
 
-func (s *AuthService) Login(ctx context.Context, input LoginInput) (*domain.User, *TokenPair, error) {
+// LoginResult holds either Tokens or, when the user has two-factor
+// authentication enabled, a ChallengeToken to exchange together with a code.
+type LoginResult struct {
+	User           *domain.User
+	Tokens         *TokenPair
+	ChallengeToken string
+}
+
+// Login checks the password and returns tokens, or a two-factor challenge.
+// Repeated failures for an email address or client IP lock further attempts
+// with a RateLimitError wrapping ErrLoginLocked.
+func (s *AuthService) Login(ctx context.Context, input LoginInput) (result *LoginResult, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Login")
+	defer span.End()
+
+	defer func() { metrics.Logins.WithLabelValues(loginOutcome(result, err)).Inc() }()
+
+	keys := s.throttleKeys(ctx, input.Email)
+	if err := s.checkLoginThrottle(ctx, keys); err != nil {
+		return nil, err
+	}
+
 	user, err := s.userRepo.GetByEmail(ctx, input.Email)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
-			return nil, nil, domain.ErrInvalidCredentials
+			// Pay the same bcrypt cost as a real account so response times
+			// don't reveal which addresses are registered.
+			bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(input.Password))
+			return nil, s.loginFailed(ctx, keys)
 		}
-		return nil, nil, err
+		return nil, err
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(input.Password)); err != nil {
-		return nil, nil, domain.ErrInvalidCredentials
+		return nil, s.loginFailed(ctx, keys)
+	}
+	if err := s.throttleRepo.Delete(ctx, keys.account); err != nil {
+		return nil, err
+	}
+	if !user.Active() {
+		return nil, domain.ErrAccountDeactivated
+	}
+	if s.authCfg.EmailVerification == config.EmailVerificationLogin && !user.EmailVerified() {
+		return nil, domain.ErrEmailNotVerified
+	}
+
+	return s.startSession(ctx, user)
+}
+
+// startSession finishes a login whose first factor was checked: users with
+// two-factor authentication get a challenge, everyone else a token pair.
+func (s *AuthService) startSession(ctx context.Context, user *domain.User) (*LoginResult, error) {
+	enrollment, err := s.twoFactorRepo.GetTOTP(ctx, user.ID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+	if enrollment != nil && enrollment.Enabled() {
+		challenge, err := s.issueChallenge(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		return &LoginResult{User: user, ChallengeToken: challenge}, nil
 	}
 
 	tokens, err := s.generateTokenPair(ctx, user)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-
-	return user, tokens, nil
+	return &LoginResult{User: user, Tokens: tokens}, nil
 }
 
-func (s *AuthService) RefreshToken(ctx context.Context, rawRefreshToken string) (*domain.User, *TokenPair, error) {
-	hash := hashToken(rawRefreshToken)
+// RefreshToken exchanges a refresh token for a new pair. Each refresh token
+// works once; presenting one that was already rotated means two parties hold
+// the session, so the whole session is revoked.
+func (s *AuthService) RefreshToken(ctx context.Context, rawRefreshToken string) (_ *domain.User, _ *TokenPair, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.RefreshToken")
+	defer span.End()
+
+	reused := false
+	defer func() { metrics.Refreshes.WithLabelValues(refreshOutcome(reused, err)).Inc() }()
 
-	storedToken, err := s.refreshTokenRepo.GetByTokenHash(ctx, hash)
+	storedToken, err := s.refreshTokenRepo.GetByTokenHash(ctx, hashToken(rawRefreshToken))
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return nil, nil, domain.ErrUnauthorized
@@ -117,39 +248,181 @@ func (s *AuthService) RefreshToken(ctx context.Context, rawRefreshToken string)
 		return nil, nil, err
 	}
 
-	if time.Now().After(storedToken.ExpiresAt) {
-		_ = s.refreshTokenRepo.DeleteByTokenHash(ctx, hash)
+	if storedToken.Rotated() {
+		reused = true
+		s.revokeReusedSession(ctx, storedToken)
 		return nil, nil, domain.ErrUnauthorized
 	}
 
-	// Delete old refresh token (rotation)
-	_ = s.refreshTokenRepo.DeleteByTokenHash(ctx, hash)
+	now := time.Now()
+	if now.After(storedToken.ExpiresAt) {
+		return nil, nil, domain.ErrUnauthorized
+	}
 
 	user, err := s.userRepo.GetByID(ctx, storedToken.UserID)
 	if err != nil {
 		return nil, nil, err
 	}
+	if !user.Active() {
+		return nil, nil, domain.ErrAccountDeactivated
+	}
 
-	tokens, err := s.generateTokenPair(ctx, user)
+	rawRefresh, err := generateRandomToken(32)
 	if err != nil {
+		return nil, nil, fmt.Errorf("generating refresh token: %w", err)
+	}
+	client := requestinfo.FromContext(ctx)
+	next := &domain.RefreshToken{
+		ID:         uuid.New(),
+		FamilyID:   storedToken.FamilyID,
+		UserID:     user.ID,
+		TokenHash:  hashToken(rawRefresh),
+		UserAgent:  client.UserAgent,
+		IP:         client.IP,
+		ExpiresAt:  now.Add(s.jwtCfg.RefreshExpiration),
+		LastUsedAt: now,
+		CreatedAt:  storedToken.CreatedAt,
+	}
+	// A concurrent refresh with the same token loses the race and is rejected.
+	if err := s.refreshTokenRepo.Rotate(ctx, storedToken.ID, now, next); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, domain.ErrUnauthorized
+		}
 		return nil, nil, err
 	}
 
-	return user, tokens, nil
+	accessToken, err := s.signAccessToken(user, next.FamilyID, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, &TokenPair{AccessToken: accessToken, RefreshToken: rawRefresh}, nil
+}
+
+func loginOutcome(result *LoginResult, err error) string {
+	switch {
+	case err == nil && result.ChallengeToken != "":
+		return metrics.LoginTwoFactorChallenge
+	case err == nil:
+		return metrics.LoginSuccess
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		return metrics.LoginInvalidCredentials
+	case errors.Is(err, domain.ErrLoginLocked):
+		return metrics.LoginLocked
+	case errors.Is(err, domain.ErrAccountDeactivated):
+		return metrics.LoginDeactivated
+	case errors.Is(err, domain.ErrEmailNotVerified):
+		return metrics.LoginEmailNotVerified
+	default:
+		return metrics.LoginError
+	}
+}
+
+func refreshOutcome(reused bool, err error) string {
+	switch {
+	case reused:
+		return metrics.RefreshReused
+	case err == nil:
+		return metrics.RefreshRotated
+	case errors.Is(err, domain.ErrUnauthorized), errors.Is(err, domain.ErrAccountDeactivated):
+		return metrics.RefreshRejected
+	default:
+		return metrics.RefreshError
+	}
+}
+
+// revokeReusedSession ends the session of a rotated token that was presented
+// again and records a security event. Failures are logged rather than
+// returned; the caller rejects the request either way.
+func (s *AuthService) revokeReusedSession(ctx context.Context, token *domain.RefreshToken) {
+	client := requestinfo.FromContext(ctx)
+	logging.FromContext(ctx).Warn("refresh token reuse detected, revoking session",
+		"user_id", token.UserID, "session_id", token.FamilyID, "ip", client.IP,
+	)
+
+	if err := s.refreshTokenRepo.DeleteFamily(ctx, token.UserID, token.FamilyID); err != nil && !errors.Is(err, domain.ErrNotFound) {
+		logging.FromContext(ctx).Error("failed to revoke session", "session_id", token.FamilyID, "error", err)
+	}
+
+	event := &domain.SecurityEvent{
+		ID:        uuid.New(),
+		UserID:    token.UserID,
+		Type:      domain.SecurityEventRefreshTokenReuse,
+		UserAgent: client.UserAgent,
+		IP:        client.IP,
+		CreatedAt: time.Now(),
+	}
+	if err := s.securityEventRepo.Create(ctx, event); err != nil {
+		logging.FromContext(ctx).Error("failed to record security event", "type", event.Type, "error", err)
+	}
+}
+
+// PurgeExpiredTokens deletes refresh tokens that have expired, including
+// rotated ones kept around for reuse detection.
+func (s *AuthService) PurgeExpiredTokens(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.PurgeExpiredTokens")
+	defer span.End()
+
+	return s.refreshTokenRepo.DeleteExpired(ctx, time.Now())
+}
+
+// PurgeExpiredChallenges deletes two-factor login challenges that have
+// expired.
+func (s *AuthService) PurgeExpiredChallenges(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.PurgeExpiredChallenges")
+	defer span.End()
+
+	return s.twoFactorRepo.DeleteExpiredChallenges(ctx, time.Now())
 }
 
 func (s *AuthService) Logout(ctx context.Context, rawRefreshToken string) error {
+	ctx, span := tracing.Start(ctx, "AuthService.Logout")
+	defer span.End()
+
 	hash := hashToken(rawRefreshToken)
-	return s.refreshTokenRepo.DeleteByTokenHash(ctx, hash)
+	token, err := s.refreshTokenRepo.GetByTokenHash(ctx, hash)
+	if errors.Is(err, domain.ErrNotFound) {
+		// Logging out twice is not an error.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.refreshTokenRepo.DeleteByTokenHash(ctx, hash); err != nil {
+		return err
+	}
+	s.auditService.recordAccount(ctx, token.UserID, token.UserID, domain.AuditActionLogout)
+	return nil
 }
 
-func (s *AuthService) ValidateAccessToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+// Authenticate validates an access token and loads its user, rejecting
+// users that were deleted or deactivated after the token was issued.
+func (s *AuthService) Authenticate(ctx context.Context, tokenString string) (*Claims, *domain.User, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Authenticate")
+	defer span.End()
+
+	claims, err := s.ValidateAccessToken(tokenString)
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := s.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, domain.ErrUnauthorized
 		}
-		return []byte(s.jwtCfg.Secret), nil
-	})
+		return nil, nil, err
+	}
+	if !user.Active() {
+		return nil, nil, domain.ErrAccountDeactivated
+	}
+	return claims, user, nil
+}
+
+// ValidateAccessToken verifies an access token's signature, expiry, issuer
+// and audience.
+func (s *AuthService) ValidateAccessToken(tokenString string) (*Claims, error) {
+	token, err := s.keys.Parse(tokenString, &Claims{},
+		jwt.WithIssuer(s.jwtCfg.Issuer), jwt.WithAudience(s.jwtCfg.Audience))
 	if err != nil {
 		return nil, domain.ErrUnauthorized
 	}
@@ -162,49 +435,285 @@ func (s *AuthService) ValidateAccessToken(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
+// generateTokenPair starts a new session for user. Every login path ends
+// here, so it is also where deactivated accounts are turned away.
 func (s *AuthService) generateTokenPair(ctx context.Context, user *domain.User) (*TokenPair, error) {
+	if !user.Active() {
+		return nil, domain.ErrAccountDeactivated
+	}
 	now := time.Now()
 
+	// Refresh token
+	rawRefresh, err := generateRandomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("generating refresh token: %w", err)
+	}
+
+	client := requestinfo.FromContext(ctx)
+	id := uuid.New()
+	refreshRecord := &domain.RefreshToken{
+		ID:         id,
+		FamilyID:   id,
+		UserID:     user.ID,
+		TokenHash:  hashToken(rawRefresh),
+		UserAgent:  client.UserAgent,
+		IP:         client.IP,
+		ExpiresAt:  now.Add(s.jwtCfg.RefreshExpiration),
+		LastUsedAt: now,
+		CreatedAt:  now,
+	}
+
+	if err := s.refreshTokenRepo.Create(ctx, refreshRecord); err != nil {
+		return nil, fmt.Errorf("storing refresh token: %w", err)
+	}
+
 	// Access token
+	accessToken, err := s.signAccessToken(user, refreshRecord.FamilyID, now)
+	if err != nil {
+		return nil, err
+	}
+	s.auditService.recordAccount(ctx, user.ID, user.ID, domain.AuditActionLogin)
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: rawRefresh,
+	}, nil
+}
+
+func (s *AuthService) signAccessToken(user *domain.User, sessionID uuid.UUID, now time.Time) (string, error) {
 	claims := &Claims{
-		UserID: user.ID,
-		Email:  user.Email,
-		Role:   user.Role,
+		UserID:        user.ID,
+		Email:         user.Email,
+		Role:          user.Role,
+		EmailVerified: user.EmailVerified(),
+		SessionID:     sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.jwtCfg.Issuer,
+			Audience:  jwt.ClaimStrings{s.jwtCfg.Audience},
 			ExpiresAt: jwt.NewNumericDate(now.Add(s.jwtCfg.AccessExpiration)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			Subject:   user.ID.String(),
 		},
 	}
 
-	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.jwtCfg.Secret))
-	if err != nil {
-		return nil, fmt.Errorf("signing access token: %w", err)
-	}
-
-	// Refresh token
-	rawRefresh, err := generateRandomToken(32)
+	accessToken, err := s.keys.Sign(claims)
 	if err != nil {
-		return nil, fmt.Errorf("generating refresh token: %w", err)
+		return "", fmt.Errorf("signing access token: %w", err)
 	}
+	return accessToken, nil
+}
 
-	refreshHash := hashToken(rawRefresh)
-	refreshRecord := &domain.RefreshToken{
+func (s *AuthService) issueChallenge(ctx context.Context, user *domain.User) (string, error) {
+	now := time.Now()
+	record := &domain.TwoFactorChallenge{
 		ID:        uuid.New(),
 		UserID:    user.ID,
-		TokenHash: refreshHash,
-		ExpiresAt: now.Add(s.jwtCfg.RefreshExpiration),
+		ExpiresAt: now.Add(twoFactorChallengeTTL),
 		CreatedAt: now,
 	}
+	if err := s.twoFactorRepo.CreateChallenge(ctx, record); err != nil {
+		return "", fmt.Errorf("storing two-factor challenge: %w", err)
+	}
+	claims := &jwt.RegisteredClaims{
+		ID:        record.ID.String(),
+		Subject:   user.ID.String(),
+		Audience:  jwt.ClaimStrings{twoFactorAudience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(twoFactorChallengeTTL)),
+	}
+	challenge, err := s.challengeKeys.Sign(claims)
+	if err != nil {
+		return "", fmt.Errorf("signing two-factor challenge: %w", err)
+	}
+	return challenge, nil
+}
 
-	if err := s.refreshTokenRepo.Create(ctx, refreshRecord); err != nil {
-		return nil, fmt.Errorf("storing refresh token: %w", err)
+// parseChallenge returns the user a two-factor challenge was issued to and
+// the challenge's ID.
+func (s *AuthService) parseChallenge(challenge string) (userID, challengeID uuid.UUID, err error) {
+	claims := &jwt.RegisteredClaims{}
+	if _, err := s.challengeKeys.Parse(challenge, claims, jwt.WithAudience(twoFactorAudience)); err != nil {
+		return uuid.Nil, uuid.Nil, domain.ErrUnauthorized
+	}
+	if userID, err = uuid.Parse(claims.Subject); err != nil {
+		return uuid.Nil, uuid.Nil, domain.ErrUnauthorized
 	}
+	if challengeID, err = uuid.Parse(claims.ID); err != nil {
+		return uuid.Nil, uuid.Nil, domain.ErrUnauthorized
+	}
+	return userID, challengeID, nil
+}
 
-	return &TokenPair{
-		AccessToken:  accessToken,
-		RefreshToken: rawRefresh,
-	}, nil
+// loginThrottleKeys are the throttle keys a login attempt counts against.
+// ip is empty when the client address is unknown.
+type loginThrottleKeys struct {
+	account string
+	ip      string
+}
+
+func (s *AuthService) throttleKeys(ctx context.Context, email string) loginThrottleKeys {
+	keys := loginThrottleKeys{account: accountThrottleKey(email)}
+	if ip := requestinfo.FromContext(ctx).IP; ip != "" {
+		keys.ip = "ip:" + ip
+	}
+	return keys
+}
+
+func accountThrottleKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// checkLoginThrottle returns a RateLimitError while either key is locked. It
+// runs before the password check so locked attempts cost no bcrypt work.
+func (s *AuthService) checkLoginThrottle(ctx context.Context, keys loginThrottleKeys) error {
+	now := time.Now()
+	wait, err := s.throttleWait(ctx, keys.account, s.authCfg.LoginMaxAttempts, now)
+	if err != nil {
+		return err
+	}
+	if keys.ip != "" {
+		ipWait, err := s.throttleWait(ctx, keys.ip, s.authCfg.LoginMaxAttemptsPerIP, now)
+		if err != nil {
+			return err
+		}
+		wait = max(wait, ipWait)
+	}
+	if wait > 0 {
+		return &domain.RateLimitError{RetryAfter: wait, Err: domain.ErrLoginLocked}
+	}
+	return nil
+}
+
+func (s *AuthService) throttleWait(ctx context.Context, key string, limit int, now time.Time) (time.Duration, error) {
+	t, err := s.throttleRepo.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return s.lockRemaining(t, limit, now), nil
+}
+
+// lockRemaining is how much longer t is locked: LoginBackoff after the
+// limit-th failure, doubling with each failure after that up to LoginLockout.
+func (s *AuthService) lockRemaining(t *domain.LoginThrottle, limit int, now time.Time) time.Duration {
+	if t.Failures < limit || t.LastFailureAt.Before(now.Add(-s.authCfg.LoginFailureWindow)) {
+		return 0
+	}
+	lock := s.authCfg.LoginBackoff
+	for i := limit; i < t.Failures && lock < s.authCfg.LoginLockout; i++ {
+		lock *= 2
+	}
+	lock = min(lock, s.authCfg.LoginLockout)
+	return max(t.LastFailureAt.Add(lock).Sub(now), 0)
+}
+
+// throttled runs check, a password or code check for a signed-in user, under
+// the login throttle: a locked login gets a RateLimitError, and a check
+// failing with ErrValidation counts as a failed login for the user's email
+// address and the client IP. Without this, anyone holding a session could
+// guess until they got it right.
+func (s *AuthService) throttled(ctx context.Context, userID uuid.UUID, check func() error) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	keys := s.throttleKeys(ctx, user.Email)
+	if err := s.checkLoginThrottle(ctx, keys); err != nil {
+		return err
+	}
+	if err := check(); err != nil {
+		if errors.Is(err, domain.ErrValidation) {
+			if err := s.recordLoginFailure(ctx, keys); err != nil {
+				return err
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// loginFailed counts a failed attempt against both keys. The attempt itself
+// still gets ErrInvalidCredentials; a lock it causes applies from the next one.
+func (s *AuthService) loginFailed(ctx context.Context, keys loginThrottleKeys) error {
+	if err := s.recordLoginFailure(ctx, keys); err != nil {
+		return err
+	}
+	return domain.ErrInvalidCredentials
+}
+
+// recordLoginFailure counts a failed password or two-factor code against
+// both keys.
+func (s *AuthService) recordLoginFailure(ctx context.Context, keys loginThrottleKeys) error {
+	now := time.Now()
+	windowStart := now.Add(-s.authCfg.LoginFailureWindow)
+
+	t, err := s.throttleRepo.RecordFailure(ctx, keys.account, now, windowStart)
+	if err != nil {
+		return err
+	}
+	wait := s.lockRemaining(t, s.authCfg.LoginMaxAttempts, now)
+	if keys.ip != "" {
+		t, err := s.throttleRepo.RecordFailure(ctx, keys.ip, now, windowStart)
+		if err != nil {
+			return err
+		}
+		wait = max(wait, s.lockRemaining(t, s.authCfg.LoginMaxAttemptsPerIP, now))
+	}
+	if wait > 0 {
+		logging.FromContext(ctx).Warn("login locked after repeated failures", "account", keys.account, "ip", keys.ip, "retry_after", wait)
+	}
+	return nil
+}
+
+// UnlockLogin clears the failed-login count of a user's account. Only admins
+// may do this. IP locks are left to expire on their own.
+func (s *AuthService) UnlockLogin(ctx context.Context, actorID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "AuthService.UnlockLogin")
+	defer span.End()
+
+	actor, err := s.userRepo.GetByID(ctx, actorID)
+	if err != nil {
+		return err
+	}
+	if actor.Role != domain.UserRoleAdmin {
+		return domain.ErrForbidden
+	}
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return s.throttleRepo.Delete(ctx, accountThrottleKey(user.Email))
+}
+
+// PurgeStaleThrottles deletes failed-login counts that are past the window.
+func (s *AuthService) PurgeStaleThrottles(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.PurgeStaleThrottles")
+	defer span.End()
+
+	return s.throttleRepo.DeleteStale(ctx, time.Now().Add(-s.authCfg.LoginFailureWindow))
+}
+
+func validatePassword(password string) error {
+	if len(password) < 8 {
+		return fmt.Errorf("%w: password must be at least 8 characters", domain.ErrValidation)
+	}
+	return nil
+}
+
+const passwordHashCost = 12
+
+// dummyPasswordHash is a bcrypt hash of no real password, at
+// passwordHashCost, for logins to unknown addresses to compare against.
+const dummyPasswordHash = "$2a$12$f5nSsxJTglPVMdoBl2cifuGpKCFd6oYe1iICkmT/EFSdc1oCr1lCO"
+
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), passwordHashCost)
+	if err != nil {
+		return "", fmt.Errorf("hashing password: %w", err)
+	}
+	return string(hash), nil
 }
 
 func generateRandomToken(length int) (string, error) {
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/letyshub/project-management/internal/config"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/jwtkeys"
+	"github.com/letyshub/project-management/internal/repository/memory"
+	"github.com/letyshub/project-management/internal/requestinfo"
+)
+
+var testJWTConfig = config.JWTConfig{
+	Secret:            "test",
+	Issuer:            "test",
+	Audience:          "test-api",
+	AccessExpiration:  time.Minute,
+	RefreshExpiration: time.Hour,
+}
+
+var testAuthConfig = config.AuthConfig{
+	LoginMaxAttempts:      3,
+	LoginMaxAttemptsPerIP: 10,
+	LoginBackoff:          30 * time.Second,
+	LoginLockout:          2 * time.Minute,
+	LoginFailureWindow:    time.Hour,
+}
+
+type fakeSecurityEventStore struct {
+	events []*domain.SecurityEvent
+}
+
+func (f *fakeSecurityEventStore) Create(ctx context.Context, event *domain.SecurityEvent) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+// authStack is an AuthService over an in-memory store that already holds
+// one member, Ada, whose password is "password123". Fixtures build the
+// service under test on the same store.
+type authStack struct {
+	store     *memory.Store
+	tx        *memory.TxManager
+	users     *memory.UserRepo
+	refresh   *memory.RefreshTokenRepo
+	twoFactor *memory.TwoFactorRepo
+	throttles *memory.LoginThrottleRepo
+	security  *fakeSecurityEventStore
+	audit     *AuditService
+	auth      *AuthService
+	user      *domain.User
+}
+
+func newAuthStack(t *testing.T, authCfg config.AuthConfig) *authStack {
+	t.Helper()
+	store := memory.NewStore()
+	s := &authStack{
+		store:     store,
+		tx:        memory.NewTxManager(store),
+		users:     memory.NewUserRepo(store),
+		refresh:   memory.NewRefreshTokenRepo(store),
+		twoFactor: memory.NewTwoFactorRepo(store),
+		throttles: memory.NewLoginThrottleRepo(store),
+		security:  &fakeSecurityEventStore{},
+	}
+	s.audit = newTestAuditService(store)
+	s.auth = NewAuthService(s.users, s.refresh, s.twoFactor, s.security, s.throttles, nil, s.audit,
+		jwtkeys.NewHMAC("test"), jwtkeys.NewDerivedHMAC("test", "2fa-challenge"), testJWTConfig, authCfg)
+	s.user = s.addUser(t, "Ada", "ada@example.com", domain.UserRoleMember)
+	return s
+}
+
+// addUser stores a user whose password is "password123".
+func (s *authStack) addUser(t *testing.T, name, email, role string) *domain.User {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	user := &domain.User{ID: uuid.New(), Email: email, Name: name, PasswordHash: string(hash), Role: role, CreatedAt: now, UpdatedAt: now}
+	must(t, s.users.Create(context.Background(), user))
+	return user
+}
+
+// reload returns the stored copy of a user.
+func (s *authStack) reload(t *testing.T, id uuid.UUID) *domain.User {
+	t.Helper()
+	user, err := s.users.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+func TestValidateAccessToken_RequiresIssuerAndAudience(t *testing.T) {
+	f := newAuthStack(t, testAuthConfig)
+	token, err := f.auth.signAccessToken(f.user, uuid.New(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := f.auth.ValidateAccessToken(token)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if claims.Issuer != "test" || len(claims.Audience) != 1 || claims.Audience[0] != "test-api" {
+		t.Errorf("expected issuer test and audience test-api, got %q %v", claims.Issuer, claims.Audience)
+	}
+
+	for name, registered := range map[string]jwt.RegisteredClaims{
+		"no issuer or audience": {},
+		"other issuer":          {Issuer: "other", Audience: jwt.ClaimStrings{"test-api"}},
+		"other audience":        {Issuer: "test", Audience: jwt.ClaimStrings{"other-api"}},
+	} {
+		registered.Subject = f.user.ID.String()
+		registered.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Minute))
+		token, err := f.auth.keys.Sign(&Claims{UserID: f.user.ID, RegisteredClaims: registered})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.auth.ValidateAccessToken(token); !errors.Is(err, domain.ErrUnauthorized) {
+			t.Errorf("%s: expected ErrUnauthorized, got %v", name, err)
+		}
+	}
+}
+
+func TestLogin_LocksAccountAfterRepeatedFailures(t *testing.T) {
+	f := newAuthStack(t, testAuthConfig)
+	ctx := context.Background()
+	wrong := LoginInput{Email: f.user.Email, Password: "wrong-password"}
+
+	for i := 0; i < 3; i++ {
+		if _, err := f.auth.Login(ctx, wrong); !errors.Is(err, domain.ErrInvalidCredentials) {
+			t.Fatalf("attempt %d: expected invalid credentials, got %v", i+1, err)
+		}
+	}
+
+	// Even the right password is refused while locked.
+	_, err := f.auth.Login(ctx, LoginInput{Email: f.user.Email, Password: "password123"})
+	var rl *domain.RateLimitError
+	if !errors.Is(err, domain.ErrLoginLocked) || errors.Is(err, domain.ErrInvalidCredentials) || !errors.As(err, &rl) {
+		t.Fatalf("expected login locked, got %v", err)
+	}
+	if rl.RetryAfter <= 0 || rl.RetryAfter > 30*time.Second {
+		t.Errorf("expected first lock of up to 30s, got %v", rl.RetryAfter)
+	}
+
+	// Once the lock passes, a successful login clears the count. Replaying
+	// the failures a minute ago stands in for waiting out the lock.
+	key := accountThrottleKey(f.user.Email)
+	must(t, f.throttles.Delete(ctx, key))
+	for i := 0; i < 3; i++ {
+		_, err := f.throttles.RecordFailure(ctx, key, time.Now().Add(-time.Minute), time.Now().Add(-time.Hour))
+		must(t, err)
+	}
+	if _, err := f.auth.Login(ctx, LoginInput{Email: f.user.Email, Password: "password123"}); err != nil {
+		t.Fatalf("expected login after lock expired, got %v", err)
+	}
+	if throttle, err := f.throttles.Get(ctx, key); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected throttle to be cleared, got %+v", throttle)
+	}
+}
+
+func TestLogin_BackoffDoublesUpToLockout(t *testing.T) {
+	f := newAuthStack(t, testAuthConfig)
+	now := time.Now()
+	for failures, want := range map[int]time.Duration{
+		2: 0,
+		3: 30 * time.Second,
+		4: time.Minute,
+		5: 2 * time.Minute,
+		9: 2 * time.Minute,
+	} {
+		got := f.auth.lockRemaining(&domain.LoginThrottle{Failures: failures, LastFailureAt: now}, 3, now)
+		if got != want {
+			t.Errorf("%d failures: expected %v lock, got %v", failures, want, got)
+		}
+	}
+}
+
+func TestLogin_LocksIPAcrossAccounts(t *testing.T) {
+	f := newAuthStack(t, testAuthConfig)
+	ctx := requestinfo.WithClient(context.Background(), requestinfo.Client{IP: "203.0.113.9"})
+
+	for i := 0; i < 10; i++ {
+		input := LoginInput{Email: uuid.NewString() + "@example.com", Password: "guess"}
+		if _, err := f.auth.Login(ctx, input); !errors.Is(err, domain.ErrInvalidCredentials) {
+			t.Fatalf("attempt %d: expected invalid credentials, got %v", i+1, err)
+		}
+	}
+	if _, err := f.auth.Login(ctx, LoginInput{Email: f.user.Email, Password: "password123"}); !errors.Is(err, domain.ErrLoginLocked) {
+		t.Errorf("expected the IP to be locked, got %v", err)
+	}
+	if _, err := f.auth.Login(context.Background(), LoginInput{Email: f.user.Email, Password: "password123"}); err != nil {
+		t.Errorf("expected other clients to be unaffected, got %v", err)
+	}
+}
+
+func TestUnlockLogin(t *testing.T) {
+	f := newAuthStack(t, testAuthConfig)
+	admin := f.addUser(t, "Admin", "admin@example.com", domain.UserRoleAdmin)
+	for i := 0; i < 3; i++ {
+		_, _ = f.auth.Login(context.Background(), LoginInput{Email: f.user.Email, Password: "wrong"})
+	}
+
+	if err := f.auth.UnlockLogin(context.Background(), f.user.ID, f.user.ID); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("expected non-admin to be forbidden, got %v", err)
+	}
+	if err := f.auth.UnlockLogin(context.Background(), admin.ID, f.user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.auth.Login(context.Background(), LoginInput{Email: f.user.Email, Password: "password123"}); err != nil {
+		t.Errorf("expected login after unlock, got %v", err)
+	}
+}
+
+func TestDummyPasswordHash_CostsTheSameAsARealOne(t *testing.T) {
+	cost, err := bcrypt.Cost([]byte(dummyPasswordHash))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cost != passwordHashCost {
+		t.Errorf("expected cost %d so unknown emails take as long as real ones, got %d", passwordHashCost, cost)
+	}
+}
@@ -7,24 +7,29 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/letyshub/project-management/internal/authz"
 	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/tracing"
 )
 
 type BoardService struct {
-	boardRepo   domain.BoardRepository
-	columnRepo  domain.ColumnRepository
-	projectRepo domain.ProjectRepository
+	boardRepo    domain.BoardRepository
+	columnRepo   domain.ColumnRepository
+	auditService *AuditService
+	policy       authz.Policy
 }
 
 func NewBoardService(
 	boardRepo domain.BoardRepository,
 	columnRepo domain.ColumnRepository,
-	projectRepo domain.ProjectRepository,
+	auditService *AuditService,
+	policy authz.Policy,
 ) *BoardService {
 	return &BoardService{
-		boardRepo:   boardRepo,
-		columnRepo:  columnRepo,
-		projectRepo: projectRepo,
+		boardRepo:    boardRepo,
+		columnRepo:   columnRepo,
+		auditService: auditService,
+		policy:       policy,
 	}
 }
 
@@ -32,14 +37,13 @@ type CreateBoardInput struct {
 	Name string `json:"name"`
 }
 
-func (s *BoardService) Create(ctx context.Context, projectID uuid.UUID, ownerID uuid.UUID, input CreateBoardInput) (*domain.Board, error) {
-	project, err := s.projectRepo.GetByID(ctx, projectID)
-	if err != nil {
+func (s *BoardService) Create(ctx context.Context, projectID uuid.UUID, userID uuid.UUID, input CreateBoardInput) (*domain.Board, error) {
+	ctx, span := tracing.Start(ctx, "BoardService.Create")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionManage, authz.Project(projectID)); err != nil {
 		return nil, err
 	}
-	if project.OwnerID != ownerID {
-		return nil, domain.ErrForbidden
-	}
 	if input.Name == "" {
 		return nil, fmt.Errorf("%w: name is required", domain.ErrValidation)
 	}
@@ -53,17 +57,36 @@ func (s *BoardService) Create(ctx context.Context, projectID uuid.UUID, ownerID
 		UpdatedAt: now,
 	}
 
-	if err := s.boardRepo.Create(ctx, board); err != nil {
+	err := s.auditService.apply(ctx, auditEntry{
+		actorID: userID, projectID: &projectID,
+		entityType: domain.AuditEntityBoard, entityID: board.ID, action: domain.AuditActionCreate,
+		after: board,
+	}, func(ctx context.Context) error {
+		return s.boardRepo.Create(ctx, board)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return board, nil
 }
 
-func (s *BoardService) GetByID(ctx context.Context, id uuid.UUID) (*domain.Board, error) {
+func (s *BoardService) GetByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Board, error) {
+	ctx, span := tracing.Start(ctx, "BoardService.GetByID")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionRead, authz.Board(id)); err != nil {
+		return nil, err
+	}
 	return s.boardRepo.GetByID(ctx, id)
 }
 
-func (s *BoardService) ListByProject(ctx context.Context, projectID uuid.UUID) ([]*domain.Board, error) {
+func (s *BoardService) ListByProject(ctx context.Context, projectID uuid.UUID, userID uuid.UUID) ([]*domain.Board, error) {
+	ctx, span := tracing.Start(ctx, "BoardService.ListByProject")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionRead, authz.Project(projectID)); err != nil {
+		return nil, err
+	}
 	return s.boardRepo.ListByProject(ctx, projectID)
 }
 
@@ -71,18 +94,18 @@ type UpdateBoardInput struct {
 	Name *string `json:"name"`
 }
 
-func (s *BoardService) Update(ctx context.Context, id uuid.UUID, ownerID uuid.UUID, input UpdateBoardInput) (*domain.Board, error) {
-	board, err := s.boardRepo.GetByID(ctx, id)
-	if err != nil {
+func (s *BoardService) Update(ctx context.Context, id uuid.UUID, userID uuid.UUID, input UpdateBoardInput) (*domain.Board, error) {
+	ctx, span := tracing.Start(ctx, "BoardService.Update")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionManage, authz.Board(id)); err != nil {
 		return nil, err
 	}
-	project, err := s.projectRepo.GetByID(ctx, board.ProjectID)
+	board, err := s.boardRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	if project.OwnerID != ownerID {
-		return nil, domain.ErrForbidden
-	}
+	before := *board
 
 	if input.Name != nil {
 		if *input.Name == "" {
@@ -92,48 +115,59 @@ func (s *BoardService) Update(ctx context.Context, id uuid.UUID, ownerID uuid.UU
 	}
 	board.UpdatedAt = time.Now()
 
-	if err := s.boardRepo.Update(ctx, board); err != nil {
+	err = s.auditService.apply(ctx, auditEntry{
+		actorID: userID, projectID: &board.ProjectID,
+		entityType: domain.AuditEntityBoard, entityID: board.ID, action: domain.AuditActionUpdate,
+		before: before, after: board,
+	}, func(ctx context.Context) error {
+		return s.boardRepo.Update(ctx, board)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return board, nil
 }
 
-func (s *BoardService) Delete(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error {
-	board, err := s.boardRepo.GetByID(ctx, id)
-	if err != nil {
+func (s *BoardService) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "BoardService.Delete")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionManage, authz.Board(id)); err != nil {
 		return err
 	}
-	project, err := s.projectRepo.GetByID(ctx, board.ProjectID)
+	board, err := s.boardRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	if project.OwnerID != ownerID {
-		return domain.ErrForbidden
-	}
-	return s.boardRepo.Delete(ctx, id)
+	return s.auditService.apply(ctx, auditEntry{
+		actorID: userID, projectID: &board.ProjectID,
+		entityType: domain.AuditEntityBoard, entityID: id, action: domain.AuditActionDelete,
+		before: board,
+	}, func(ctx context.Context) error {
+		return s.boardRepo.Delete(ctx, id)
+	})
 }
 
 // Column operations
 
 type CreateColumnInput struct {
-	Name string `json:"name"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
 }
 
-func (s *BoardService) CreateColumn(ctx context.Context, boardID uuid.UUID, ownerID uuid.UUID, input CreateColumnInput) (*domain.Column, error) {
-	board, err := s.boardRepo.GetByID(ctx, boardID)
-	if err != nil {
-		return nil, err
-	}
-	project, err := s.projectRepo.GetByID(ctx, board.ProjectID)
-	if err != nil {
+func (s *BoardService) CreateColumn(ctx context.Context, boardID uuid.UUID, userID uuid.UUID, input CreateColumnInput) (*domain.Column, error) {
+	ctx, span := tracing.Start(ctx, "BoardService.CreateColumn")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionManage, authz.Board(boardID)); err != nil {
 		return nil, err
 	}
-	if project.OwnerID != ownerID {
-		return nil, domain.ErrForbidden
-	}
 	if input.Name == "" {
 		return nil, fmt.Errorf("%w: name is required", domain.ErrValidation)
 	}
+	if !domain.ValidColumnCategory(input.Category) {
+		return nil, fmt.Errorf("%w: category must be empty, backlog, in_progress, or done", domain.ErrValidation)
+	}
 
 	// Get existing columns to calculate position
 	existing, err := s.columnRepo.ListByBoard(ctx, boardID)
@@ -153,41 +187,56 @@ func (s *BoardService) CreateColumn(ctx context.Context, boardID uuid.UUID, owne
 		BoardID:   boardID,
 		Name:      input.Name,
 		Position:  maxPos + 1000,
+		Category:  input.Category,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 
-	if err := s.columnRepo.Create(ctx, col); err != nil {
+	projectID, err := s.policy.ProjectOf(ctx, authz.Board(boardID))
+	if err != nil {
+		return nil, err
+	}
+	err = s.auditService.apply(ctx, auditEntry{
+		actorID: userID, projectID: &projectID,
+		entityType: domain.AuditEntityColumn, entityID: col.ID, action: domain.AuditActionCreate,
+		after: col,
+	}, func(ctx context.Context) error {
+		return s.columnRepo.Create(ctx, col)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return col, nil
 }
 
-func (s *BoardService) ListColumns(ctx context.Context, boardID uuid.UUID) ([]*domain.Column, error) {
+func (s *BoardService) ListColumns(ctx context.Context, boardID uuid.UUID, userID uuid.UUID) ([]*domain.Column, error) {
+	ctx, span := tracing.Start(ctx, "BoardService.ListColumns")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionRead, authz.Board(boardID)); err != nil {
+		return nil, err
+	}
 	return s.columnRepo.ListByBoard(ctx, boardID)
 }
 
 type UpdateColumnInput struct {
 	Name     *string  `json:"name"`
 	Position *float64 `json:"position"`
+	Category *string  `json:"category"`
 }
 
-func (s *BoardService) UpdateColumn(ctx context.Context, colID uuid.UUID, ownerID uuid.UUID, input UpdateColumnInput) (*domain.Column, error) {
-	col, err := s.columnRepo.GetByID(ctx, colID)
-	if err != nil {
-		return nil, err
-	}
-	board, err := s.boardRepo.GetByID(ctx, col.BoardID)
-	if err != nil {
+func (s *BoardService) UpdateColumn(ctx context.Context, colID uuid.UUID, userID uuid.UUID, input UpdateColumnInput) (*domain.Column, error) {
+	ctx, span := tracing.Start(ctx, "BoardService.UpdateColumn")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionManage, authz.Column(colID)); err != nil {
 		return nil, err
 	}
-	project, err := s.projectRepo.GetByID(ctx, board.ProjectID)
+	col, err := s.columnRepo.GetByID(ctx, colID)
 	if err != nil {
 		return nil, err
 	}
-	if project.OwnerID != ownerID {
-		return nil, domain.ErrForbidden
-	}
+	before := *col
 
 	if input.Name != nil {
 		if *input.Name == "" {
@@ -198,29 +247,51 @@ func (s *BoardService) UpdateColumn(ctx context.Context, colID uuid.UUID, ownerI
 	if input.Position != nil {
 		col.Position = *input.Position
 	}
+	if input.Category != nil {
+		if !domain.ValidColumnCategory(*input.Category) {
+			return nil, fmt.Errorf("%w: category must be empty, backlog, in_progress, or done", domain.ErrValidation)
+		}
+		col.Category = *input.Category
+	}
 	col.UpdatedAt = time.Now()
 
-	if err := s.columnRepo.Update(ctx, col); err != nil {
+	projectID, err := s.policy.ProjectOf(ctx, authz.Column(colID))
+	if err != nil {
+		return nil, err
+	}
+	err = s.auditService.apply(ctx, auditEntry{
+		actorID: userID, projectID: &projectID,
+		entityType: domain.AuditEntityColumn, entityID: col.ID, action: domain.AuditActionUpdate,
+		before: before, after: col,
+	}, func(ctx context.Context) error {
+		return s.columnRepo.Update(ctx, col)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return col, nil
 }
 
-func (s *BoardService) DeleteColumn(ctx context.Context, colID uuid.UUID, ownerID uuid.UUID) error {
-	col, err := s.columnRepo.GetByID(ctx, colID)
-	if err != nil {
+func (s *BoardService) DeleteColumn(ctx context.Context, colID uuid.UUID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "BoardService.DeleteColumn")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionManage, authz.Column(colID)); err != nil {
 		return err
 	}
-	board, err := s.boardRepo.GetByID(ctx, col.BoardID)
+	col, err := s.columnRepo.GetByID(ctx, colID)
 	if err != nil {
 		return err
 	}
-	project, err := s.projectRepo.GetByID(ctx, board.ProjectID)
+	projectID, err := s.policy.ProjectOf(ctx, authz.Column(colID))
 	if err != nil {
 		return err
 	}
-	if project.OwnerID != ownerID {
-		return domain.ErrForbidden
-	}
-	return s.columnRepo.Delete(ctx, colID)
+	return s.auditService.apply(ctx, auditEntry{
+		actorID: userID, projectID: &projectID,
+		entityType: domain.AuditEntityColumn, entityID: colID, action: domain.AuditActionDelete,
+		before: col,
+	}, func(ctx context.Context) error {
+		return s.columnRepo.Delete(ctx, colID)
+	})
 }
@@ -7,15 +7,20 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/letyshub/project-management/internal/authz"
 	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/metrics"
+	"github.com/letyshub/project-management/internal/tracing"
 )
 
 type CommentService struct {
-	commentRepo domain.CommentRepository
+	commentRepo  domain.CommentRepository
+	auditService *AuditService
+	policy       authz.Policy
 }
 
-func NewCommentService(commentRepo domain.CommentRepository) *CommentService {
-	return &CommentService{commentRepo: commentRepo}
+func NewCommentService(commentRepo domain.CommentRepository, auditService *AuditService, policy authz.Policy) *CommentService {
+	return &CommentService{commentRepo: commentRepo, auditService: auditService, policy: policy}
 }
 
 type CreateCommentInput struct {
@@ -23,6 +28,12 @@ type CreateCommentInput struct {
 }
 
 func (s *CommentService) Create(ctx context.Context, taskID, authorID uuid.UUID, input CreateCommentInput) (*domain.Comment, error) {
+	ctx, span := tracing.Start(ctx, "CommentService.Create")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(authorID), authz.ActionWrite, authz.Task(taskID)); err != nil {
+		return nil, err
+	}
 	if input.Content == "" {
 		return nil, fmt.Errorf("%w: content is required", domain.ErrValidation)
 	}
@@ -37,44 +48,97 @@ func (s *CommentService) Create(ctx context.Context, taskID, authorID uuid.UUID,
 		UpdatedAt: now,
 	}
 
-	if err := s.commentRepo.Create(ctx, comment); err != nil {
+	projectID, err := s.policy.ProjectOf(ctx, authz.Task(taskID))
+	if err != nil {
+		return nil, err
+	}
+	err = s.auditService.apply(ctx, auditEntry{
+		actorID: authorID, projectID: &projectID,
+		entityType: domain.AuditEntityComment, entityID: comment.ID, action: domain.AuditActionCreate,
+		after: comment,
+	}, func(ctx context.Context) error {
+		return s.commentRepo.Create(ctx, comment)
+	})
+	if err != nil {
 		return nil, err
 	}
+	metrics.CommentsCreated.Inc()
 	return comment, nil
 }
 
-func (s *CommentService) ListByTask(ctx context.Context, taskID uuid.UUID) ([]*domain.Comment, error) {
+func (s *CommentService) ListByTask(ctx context.Context, taskID, userID uuid.UUID) ([]*domain.Comment, error) {
+	ctx, span := tracing.Start(ctx, "CommentService.ListByTask")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionRead, authz.Task(taskID)); err != nil {
+		return nil, err
+	}
 	return s.commentRepo.ListByTask(ctx, taskID)
 }
 
 func (s *CommentService) Update(ctx context.Context, commentID, authorID uuid.UUID, content string) (*domain.Comment, error) {
+	ctx, span := tracing.Start(ctx, "CommentService.Update")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(authorID), authz.ActionWrite, authz.Comment(commentID)); err != nil {
+		return nil, err
+	}
 	comment, err := s.commentRepo.GetByID(ctx, commentID)
 	if err != nil {
 		return nil, err
 	}
-	if comment.AuthorID != authorID {
-		return nil, domain.ErrForbidden
-	}
 	if content == "" {
 		return nil, fmt.Errorf("%w: content is required", domain.ErrValidation)
 	}
 
+	before := *comment
+
 	comment.Content = content
 	comment.UpdatedAt = time.Now()
 
-	if err := s.commentRepo.Update(ctx, comment); err != nil {
+	projectID, err := s.policy.ProjectOf(ctx, authz.Comment(commentID))
+	if err != nil {
+		return nil, err
+	}
+	err = s.auditService.apply(ctx, auditEntry{
+		actorID: authorID, projectID: &projectID,
+		entityType: domain.AuditEntityComment, entityID: comment.ID, action: domain.AuditActionUpdate,
+		before: before, after: comment,
+	}, func(ctx context.Context) error {
+		return s.commentRepo.Update(ctx, comment)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return comment, nil
 }
 
-func (s *CommentService) Delete(ctx context.Context, commentID, authorID uuid.UUID) error {
+// Delete removes a comment. Authors can delete their own comments and
+// project admins can delete anyone's.
+func (s *CommentService) Delete(ctx context.Context, commentID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "CommentService.Delete")
+	defer span.End()
+
 	comment, err := s.commentRepo.GetByID(ctx, commentID)
 	if err != nil {
 		return err
 	}
-	if comment.AuthorID != authorID {
-		return domain.ErrForbidden
+	action := authz.ActionWrite
+	if comment.AuthorID != userID {
+		action = authz.ActionManage
+	}
+	if err := s.policy.Authorize(ctx, authz.User(userID), action, authz.Comment(commentID)); err != nil {
+		return err
+	}
+	projectID, err := s.policy.ProjectOf(ctx, authz.Comment(commentID))
+	if err != nil {
+		return err
 	}
-	return s.commentRepo.Delete(ctx, commentID)
+	return s.auditService.apply(ctx, auditEntry{
+		actorID: userID, projectID: &projectID,
+		entityType: domain.AuditEntityComment, entityID: commentID, action: domain.AuditActionDelete,
+		before: comment,
+	}, func(ctx context.Context) error {
+		return s.commentRepo.Delete(ctx, commentID)
+	})
 }
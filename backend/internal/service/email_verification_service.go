@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/mail"
+	"github.com/letyshub/project-management/internal/tracing"
+)
+
+type EmailVerificationService struct {
+	userRepo       domain.UserRepository
+	userTokenRepo  domain.UserTokenRepository
+	txManager      domain.TxManager
+	mailer         mail.Mailer
+	appURL         string
+	expiration     time.Duration
+	resendInterval time.Duration
+}
+
+func NewEmailVerificationService(
+	userRepo domain.UserRepository,
+	userTokenRepo domain.UserTokenRepository,
+	txManager domain.TxManager,
+	mailer mail.Mailer,
+	appURL string,
+	expiration time.Duration,
+	resendInterval time.Duration,
+) *EmailVerificationService {
+	return &EmailVerificationService{
+		userRepo:       userRepo,
+		userTokenRepo:  userTokenRepo,
+		txManager:      txManager,
+		mailer:         mailer,
+		appURL:         strings.TrimRight(appURL, "/"),
+		expiration:     expiration,
+		resendInterval: resendInterval,
+	}
+}
+
+// Send issues a fresh verification token, replacing any earlier one, and
+// emails the link to the user.
+func (s *EmailVerificationService) Send(ctx context.Context, user *domain.User) error {
+	ctx, span := tracing.Start(ctx, "EmailVerificationService.Send")
+	defer span.End()
+
+	if err := s.userTokenRepo.DeleteByUserID(ctx, user.ID, domain.TokenPurposeEmailVerification); err != nil {
+		return err
+	}
+
+	rawToken, err := generateRandomToken(32)
+	if err != nil {
+		return fmt.Errorf("generating verification token: %w", err)
+	}
+
+	now := time.Now()
+	token := &domain.UserToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		Purpose:   domain.TokenPurposeEmailVerification,
+		TokenHash: hashToken(rawToken),
+		ExpiresAt: now.Add(s.expiration),
+		CreatedAt: now,
+	}
+	if err := s.userTokenRepo.Create(ctx, token); err != nil {
+		return err
+	}
+
+	msg := mail.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm your email address:\n%s/verify-email?token=%s\n\n"+
+				"The link expires in %s.\n",
+			user.Name, s.appURL, rawToken, s.expiration,
+		),
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		return fmt.Errorf("sending verification email: %w", err)
+	}
+	return nil
+}
+
+// Resend emails a new link to an unverified address. Unknown and already
+// verified addresses are ignored, and requests inside the resend interval are
+// dropped, all without an error, so the endpoint can't be used to probe for
+// accounts.
+func (s *EmailVerificationService) Resend(ctx context.Context, email string) error {
+	ctx, span := tracing.Start(ctx, "EmailVerificationService.Resend")
+	defer span.End()
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if user.EmailVerified() {
+		return nil
+	}
+
+	latest, err := s.userTokenRepo.GetLatest(ctx, user.ID, domain.TokenPurposeEmailVerification)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+	if latest != nil {
+		if time.Since(latest.CreatedAt) < s.resendInterval {
+			return nil
+		}
+	}
+
+	return s.Send(ctx, user)
+}
+
+// Verify consumes a verification token and marks the user's email verified.
+func (s *EmailVerificationService) Verify(ctx context.Context, rawToken string) error {
+	ctx, span := tracing.Start(ctx, "EmailVerificationService.Verify")
+	defer span.End()
+
+	invalid := fmt.Errorf("%w: verification link is invalid or has expired", domain.ErrValidation)
+
+	token, err := s.userTokenRepo.GetByTokenHash(ctx, domain.TokenPurposeEmailVerification, hashToken(rawToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return invalid
+		}
+		return err
+	}
+	now := time.Now()
+	if token.UsedAt != nil || now.After(token.ExpiresAt) {
+		return invalid
+	}
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.userTokenRepo.MarkUsed(ctx, token.ID, now); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return invalid
+			}
+			return err
+		}
+		return s.userRepo.MarkEmailVerified(ctx, token.UserID, now)
+	})
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/mail"
+	"github.com/letyshub/project-management/internal/repository/memory"
+)
+
+type mockUserRepo struct {
+	getByEmailFn func(ctx context.Context, email string) (*domain.User, error)
+}
+
+func (m *mockUserRepo) Create(ctx context.Context, user *domain.User) error { return nil }
+func (m *mockUserRepo) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	if m.getByEmailFn != nil {
+		return m.getByEmailFn(ctx, email)
+	}
+	return nil, domain.ErrNotFound
+}
+func (m *mockUserRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	return nil, domain.ErrNotFound
+}
+func (m *mockUserRepo) Update(ctx context.Context, user *domain.User) error { return nil }
+func (m *mockUserRepo) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string, updatedAt time.Time) error {
+	return nil
+}
+func (m *mockUserRepo) MarkEmailVerified(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error {
+	return nil
+}
+func (m *mockUserRepo) List(ctx context.Context, filter domain.UserFilter) ([]*domain.User, error) {
+	return nil, nil
+}
+func (m *mockUserRepo) UpdateRole(ctx context.Context, id uuid.UUID, role string, updatedAt time.Time) error {
+	return nil
+}
+func (m *mockUserRepo) SetDeactivated(ctx context.Context, id uuid.UUID, deactivatedAt *time.Time, updatedAt time.Time) error {
+	return nil
+}
+func (m *mockUserRepo) Stats(ctx context.Context) (*domain.SystemStats, error) {
+	return &domain.SystemStats{}, nil
+}
+
+type mockUserTokenRepo struct {
+	latest  *domain.UserToken
+	created []*domain.UserToken
+}
+
+func (m *mockUserTokenRepo) Create(ctx context.Context, token *domain.UserToken) error {
+	m.created = append(m.created, token)
+	return nil
+}
+func (m *mockUserTokenRepo) GetByTokenHash(ctx context.Context, purpose, tokenHash string) (*domain.UserToken, error) {
+	return nil, domain.ErrNotFound
+}
+func (m *mockUserTokenRepo) GetLatest(ctx context.Context, userID uuid.UUID, purpose string) (*domain.UserToken, error) {
+	if m.latest != nil {
+		return m.latest, nil
+	}
+	return nil, domain.ErrNotFound
+}
+func (m *mockUserTokenRepo) MarkUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	return nil
+}
+func (m *mockUserTokenRepo) DeleteByUserID(ctx context.Context, userID uuid.UUID, purpose string) error {
+	return nil
+}
+
+type mockMailer struct {
+	sent []mail.Message
+	err  error
+}
+
+func (m *mockMailer) Send(ctx context.Context, msg mail.Message) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func TestEmailVerificationService_Resend(t *testing.T) {
+	unverified := &domain.User{ID: uuid.New(), Email: "a@example.com", Name: "A"}
+	verifiedAt := time.Now()
+	verified := &domain.User{ID: uuid.New(), Email: "b@example.com", Name: "B", EmailVerifiedAt: &verifiedAt}
+
+	tests := []struct {
+		name     string
+		user     *domain.User
+		latest   *domain.UserToken
+		wantSent int
+	}{
+		{name: "unknown email is ignored", user: nil, wantSent: 0},
+		{name: "verified email is ignored", user: verified, wantSent: 0},
+		{name: "first request sends", user: unverified, wantSent: 1},
+		{
+			name:     "request inside interval is dropped silently",
+			user:     unverified,
+			latest:   &domain.UserToken{CreatedAt: time.Now().Add(-10 * time.Second)},
+			wantSent: 0,
+		},
+		{
+			name:     "request after interval sends",
+			user:     unverified,
+			latest:   &domain.UserToken{CreatedAt: time.Now().Add(-2 * time.Minute)},
+			wantSent: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := &mockUserRepo{getByEmailFn: func(ctx context.Context, email string) (*domain.User, error) {
+				if tt.user == nil {
+					return nil, domain.ErrNotFound
+				}
+				return tt.user, nil
+			}}
+			tokenRepo := &mockUserTokenRepo{latest: tt.latest}
+			mailer := &mockMailer{}
+			svc := NewEmailVerificationService(userRepo, tokenRepo, memory.NewTxManager(memory.NewStore()), mailer, "http://app", time.Hour, time.Minute)
+
+			if err := svc.Resend(context.Background(), "x@example.com"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(mailer.sent) != tt.wantSent {
+				t.Errorf("expected %d emails, got %d", tt.wantSent, len(mailer.sent))
+			}
+			if len(tokenRepo.created) != tt.wantSent {
+				t.Errorf("expected %d tokens, got %d", tt.wantSent, len(tokenRepo.created))
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/authz"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/tracing"
+)
+
+const (
+	defaultFlowRange = 12 * 7 * 24 * time.Hour
+	maxFlowRange     = 104 * 7 * 24 * time.Hour
+)
+
+// FlowAnalyticsService computes lead time, cycle time, throughput and aging
+// work in progress for a board. The stages come from the column categories:
+// a task starts when it first enters an in progress column and is done when
+// it enters a done column. Tasks moved before transitions were recorded have
+// no history and only count once they move again.
+type FlowAnalyticsService struct {
+	taskRepo       domain.TaskRepository
+	columnRepo     domain.ColumnRepository
+	transitionRepo domain.TaskTransitionRepository
+	policy         authz.Policy
+}
+
+func NewFlowAnalyticsService(
+	taskRepo domain.TaskRepository,
+	columnRepo domain.ColumnRepository,
+	transitionRepo domain.TaskTransitionRepository,
+	policy authz.Policy,
+) *FlowAnalyticsService {
+	return &FlowAnalyticsService{
+		taskRepo:       taskRepo,
+		columnRepo:     columnRepo,
+		transitionRepo: transitionRepo,
+		policy:         policy,
+	}
+}
+
+// Report returns the board's flow metrics. The range defaults to the twelve
+// weeks up to now and may span at most two years.
+func (s *FlowAnalyticsService) Report(ctx context.Context, boardID, userID uuid.UUID, filter domain.FlowFilter) (*domain.FlowReport, error) {
+	ctx, span := tracing.Start(ctx, "FlowAnalyticsService.Report")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionRead, authz.Board(boardID)); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	until := now
+	if filter.Until != nil {
+		until = filter.Until.UTC()
+	}
+	since := until.Add(-defaultFlowRange)
+	if filter.Since != nil {
+		since = filter.Since.UTC()
+	}
+	if !since.Before(until) {
+		return nil, fmt.Errorf("%w: since must be before until", domain.ErrValidation)
+	}
+	if until.Sub(since) > maxFlowRange {
+		return nil, fmt.Errorf("%w: the range can span at most 104 weeks", domain.ErrValidation)
+	}
+
+	columns, err := s.columnRepo.ListByBoard(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.taskRepo.ListByBoard(ctx, boardID, domain.TaskFilter{LabelID: filter.LabelID, AssigneeID: filter.AssigneeID})
+	if err != nil {
+		return nil, err
+	}
+	transitions, err := s.transitionRepo.ListByBoard(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := flowReport(columns, tasks, transitions, since, until, now)
+	report.BoardID = boardID
+	return report, nil
+}
+
+// flowReport does the computation for Report. transitions must be oldest
+// first; those of tasks not in tasks are ignored.
+func flowReport(columns []*domain.Column, tasks []*domain.Task, transitions []*domain.TaskTransition, since, until, now time.Time) *domain.FlowReport {
+	category := make(map[uuid.UUID]string, len(columns))
+	for _, c := range columns {
+		category[c.ID] = c.Category
+	}
+	history := make(map[uuid.UUID][]*domain.TaskTransition)
+	for _, tr := range transitions {
+		history[tr.TaskID] = append(history[tr.TaskID], tr)
+	}
+
+	report := &domain.FlowReport{
+		Since:      since,
+		Until:      until,
+		Completed:  []*domain.TaskFlow{},
+		InProgress: []*domain.TaskFlow{},
+	}
+	var leadTimes, cycleTimes []float64
+	weekly := map[time.Time]int{}
+	for _, t := range tasks {
+		flow := taskFlow(t, category, history[t.ID])
+		switch flow.Category {
+		case domain.ColumnCategoryDone:
+			if flow.DoneAt == nil || flow.DoneAt.Before(since) || !flow.DoneAt.Before(until) {
+				continue
+			}
+			lead := hoursBetween(flow.CreatedAt, *flow.DoneAt)
+			flow.LeadTimeHours = &lead
+			leadTimes = append(leadTimes, lead)
+			if flow.StartedAt != nil {
+				cycle := hoursBetween(*flow.StartedAt, *flow.DoneAt)
+				flow.CycleTimeHours = &cycle
+				cycleTimes = append(cycleTimes, cycle)
+			}
+			weekly[weekStart(*flow.DoneAt)]++
+			report.Completed = append(report.Completed, flow)
+		case domain.ColumnCategoryInProgress:
+			started := flow.CreatedAt
+			if flow.StartedAt != nil {
+				started = *flow.StartedAt
+			}
+			age := hoursBetween(started, now)
+			flow.AgeHours = &age
+			report.InProgress = append(report.InProgress, flow)
+		}
+	}
+
+	report.LeadTime = durationStats(leadTimes)
+	report.CycleTime = durationStats(cycleTimes)
+	for w := weekStart(since); w.Before(until); w = w.AddDate(0, 0, 7) {
+		report.Throughput = append(report.Throughput, domain.WeeklyThroughput{WeekStart: w, Completed: weekly[w]})
+	}
+	slices.SortFunc(report.Completed, func(a, b *domain.TaskFlow) int {
+		return b.DoneAt.Compare(*a.DoneAt)
+	})
+	slices.SortFunc(report.InProgress, func(a, b *domain.TaskFlow) int {
+		return cmp.Compare(*b.AgeHours, *a.AgeHours)
+	})
+	return report
+}
+
+// taskFlow follows a task through its transitions to find when it started
+// and when it last entered a done column.
+func taskFlow(t *domain.Task, category map[uuid.UUID]string, history []*domain.TaskTransition) *domain.TaskFlow {
+	flow := &domain.TaskFlow{
+		TaskID:    t.ID,
+		Title:     t.Title,
+		ColumnID:  t.ColumnID,
+		Category:  category[t.ColumnID],
+		CreatedAt: t.CreatedAt.UTC(),
+	}
+	wasDone := false
+	for _, tr := range history {
+		at := tr.CreatedAt.UTC()
+		switch category[tr.ToColumnID] {
+		case domain.ColumnCategoryInProgress:
+			if flow.StartedAt == nil {
+				flow.StartedAt = &at
+			}
+			wasDone = false
+		case domain.ColumnCategoryDone:
+			if !wasDone {
+				flow.DoneAt = &at
+			}
+			wasDone = true
+		default:
+			wasDone = false
+		}
+	}
+	if flow.Category != domain.ColumnCategoryDone {
+		flow.DoneAt = nil
+	}
+	return flow
+}
+
+func durationStats(hours []float64) domain.DurationStats {
+	if len(hours) == 0 {
+		return domain.DurationStats{}
+	}
+	sorted := slices.Clone(hours)
+	slices.Sort(sorted)
+	var sum float64
+	for _, h := range sorted {
+		sum += h
+	}
+	return domain.DurationStats{
+		Count:       len(sorted),
+		MeanHours:   roundHours(sum / float64(len(sorted))),
+		MedianHours: roundHours(percentile(sorted, 0.5)),
+		P85Hours:    roundHours(percentile(sorted, 0.85)),
+	}
+}
+
+// percentile interpolates linearly between the closest ranks of sorted.
+func percentile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(pos-float64(lo))
+}
+
+func hoursBetween(from, to time.Time) float64 {
+	return roundHours(to.Sub(from).Hours())
+}
+
+func roundHours(h float64) float64 {
+	return math.Round(h*100) / 100
+}
+
+// weekStart returns the Monday, midnight UTC, that starts t's week.
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+func TestFlowReport(t *testing.T) {
+	// Monday 2025-03-03, midnight UTC.
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	hour := func(h int) time.Time { return monday.Add(time.Duration(h) * time.Hour) }
+
+	col := func(category string) *domain.Column {
+		return &domain.Column{ID: uuid.New(), Category: category}
+	}
+	backlog, doing, review, done := col(domain.ColumnCategoryBacklog), col(domain.ColumnCategoryInProgress), col(domain.ColumnCategoryNone), col(domain.ColumnCategoryDone)
+	columns := []*domain.Column{backlog, doing, review, done}
+
+	var transitions []*domain.TaskTransition
+	task := func(title string, created int, path ...any) *domain.Task {
+		t := &domain.Task{ID: uuid.New(), Title: title, CreatedAt: hour(created)}
+		for i := 0; i < len(path); i += 2 {
+			c := path[i].(*domain.Column)
+			transitions = append(transitions, &domain.TaskTransition{ID: uuid.New(), TaskID: t.ID, ToColumnID: c.ID, CreatedAt: hour(path[i+1].(int))})
+			t.ColumnID = c.ID
+		}
+		return t
+	}
+	// Lead 10h, cycle 6h, done in week one.
+	fast := task("fast", 0, backlog, 0, doing, 4, review, 8, done, 10)
+	// Reopened: started at 2, done for good at 180 (week two), lead 180h, cycle 178h.
+	reopened := task("reopened", 0, doing, 2, done, 20, doing, 30, done, 180)
+	// Straight to done: a lead time but no cycle time.
+	skipped := task("skipped", 24, backlog, 24, done, 48)
+	// Done before the range.
+	old := task("old", -100, doing, -90, done, -80)
+	// Work in progress, started at 100.
+	wip := task("wip", 50, backlog, 50, doing, 100)
+	// In progress without history, aged from its creation.
+	legacy := &domain.Task{ID: uuid.New(), Title: "legacy", ColumnID: doing.ID, CreatedAt: hour(0)}
+	// Done without history doesn't count.
+	untracked := &domain.Task{ID: uuid.New(), Title: "untracked", ColumnID: done.ID, CreatedAt: hour(0)}
+	tasks := []*domain.Task{fast, reopened, skipped, old, wip, legacy, untracked}
+
+	report := flowReport(columns, tasks, transitions, monday, hour(3*7*24), hour(200))
+
+	if len(report.Completed) != 3 || report.Completed[0].TaskID != reopened.ID || report.Completed[2].TaskID != fast.ID {
+		t.Fatalf("expected reopened, skipped and fast completed, got %+v", report.Completed)
+	}
+	if got := report.LeadTime; got.Count != 3 || got.MedianHours != 24 || got.MeanHours != 71.33 || got.P85Hours != 133.2 {
+		t.Errorf("unexpected lead time %+v", got)
+	}
+	if got := report.CycleTime; got.Count != 2 || got.MedianHours != 92 || got.MeanHours != 92 {
+		t.Errorf("unexpected cycle time %+v", got)
+	}
+	if r := report.Completed[0]; *r.StartedAt != hour(2) || *r.DoneAt != hour(180) || *r.CycleTimeHours != 178 {
+		t.Errorf("expected the reopened task to start at its first move and finish at its last, got %+v", r)
+	}
+
+	if len(report.Throughput) != 3 {
+		t.Fatalf("expected three weeks of throughput, got %+v", report.Throughput)
+	}
+	for i, want := range []int{2, 1, 0} {
+		if w := report.Throughput[i]; w.Completed != want || !w.WeekStart.Equal(monday.AddDate(0, 0, 7*i)) {
+			t.Errorf("week %d: expected %d completed from %s, got %+v", i, want, monday.AddDate(0, 0, 7*i), w)
+		}
+	}
+
+	if len(report.InProgress) != 2 || report.InProgress[0].TaskID != legacy.ID || *report.InProgress[0].AgeHours != 200 || *report.InProgress[1].AgeHours != 100 {
+		t.Errorf("expected legacy then wip, aged 200h and 100h, got %+v", report.InProgress)
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	sunday := time.Date(2025, 3, 9, 23, 30, 0, 0, time.FixedZone("CET", 3600))
+	if got := weekStart(sunday); !got.Equal(time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected Monday 2025-03-03, got %s", got)
+	}
+}
+
+func TestFlowAnalyticsService_Report_Validation(t *testing.T) {
+	svc := NewFlowAnalyticsService(nil, nil, nil, &mockPolicy{})
+
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+	if _, err := svc.Report(context.Background(), uuid.New(), uuid.New(), domain.FlowFilter{Since: &now, Until: &earlier}); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("expected ErrValidation for an empty range, got %v", err)
+	}
+	longAgo := now.AddDate(-3, 0, 0)
+	if _, err := svc.Report(context.Background(), uuid.New(), uuid.New(), domain.FlowFilter{Since: &longAgo}); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("expected ErrValidation for a range over two years, got %v", err)
+	}
+}
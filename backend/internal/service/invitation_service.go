@@ -0,0 +1,384 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/authz"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/logging"
+	"github.com/letyshub/project-management/internal/mail"
+	"github.com/letyshub/project-management/internal/tracing"
+)
+
+type InvitationService struct {
+	invitationRepo domain.InvitationRepository
+	memberRepo     domain.ProjectMemberRepository
+	projectRepo    domain.ProjectRepository
+	orgMemberRepo  domain.OrganizationMemberRepository
+	userRepo       domain.UserRepository
+	txManager      domain.TxManager
+	authService    *AuthService
+	auditService   *AuditService
+	policy         authz.Policy
+	mailer         mail.Mailer
+	appURL         string
+	expiration     time.Duration
+}
+
+func NewInvitationService(
+	invitationRepo domain.InvitationRepository,
+	memberRepo domain.ProjectMemberRepository,
+	projectRepo domain.ProjectRepository,
+	orgMemberRepo domain.OrganizationMemberRepository,
+	userRepo domain.UserRepository,
+	txManager domain.TxManager,
+	authService *AuthService,
+	auditService *AuditService,
+	policy authz.Policy,
+	mailer mail.Mailer,
+	appURL string,
+	expiration time.Duration,
+) *InvitationService {
+	return &InvitationService{
+		invitationRepo: invitationRepo,
+		memberRepo:     memberRepo,
+		projectRepo:    projectRepo,
+		orgMemberRepo:  orgMemberRepo,
+		userRepo:       userRepo,
+		txManager:      txManager,
+		authService:    authService,
+		auditService:   auditService,
+		policy:         policy,
+		mailer:         mailer,
+		appURL:         strings.TrimRight(appURL, "/"),
+		expiration:     expiration,
+	}
+}
+
+type CreateInvitationInput struct {
+	Email string             `json:"email"`
+	Role  domain.ProjectRole `json:"role"`
+}
+
+// Create stores a hashed invitation token and emails the raw token to the
+// invitee as an accept link. The raw token is never persisted.
+func (s *InvitationService) Create(ctx context.Context, projectID, inviterID uuid.UUID, input CreateInvitationInput) (*domain.Invitation, error) {
+	ctx, span := tracing.Start(ctx, "InvitationService.Create")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(inviterID), authz.ActionManage, authz.Project(projectID)); err != nil {
+		return nil, err
+	}
+	inviter, err := s.memberRepo.Get(ctx, projectID, inviterID)
+	if err != nil {
+		return nil, err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(input.Email))
+	if email == "" {
+		return nil, fmt.Errorf("%w: email is required", domain.ErrValidation)
+	}
+	role := input.Role
+	if role == "" {
+		role = domain.ProjectRoleMember
+	}
+	if err := checkAssignableRole(inviter, role); err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	rawToken, err := generateRandomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("generating invitation token: %w", err)
+	}
+
+	now := time.Now()
+	inv := &domain.Invitation{
+		ID:        uuid.New(),
+		ProjectID: projectID,
+		Email:     email,
+		Role:      role,
+		TokenHash: hashToken(rawToken),
+		InvitedBy: inviterID,
+		ExpiresAt: now.Add(s.expiration),
+		CreatedAt: now,
+	}
+	err = s.auditService.apply(ctx, auditEntry{
+		actorID:    inviterID,
+		projectID:  &projectID,
+		entityType: domain.AuditEntityInvitation,
+		entityID:   inv.ID,
+		action:     domain.AuditActionCreate,
+		after:      inv,
+	}, func(ctx context.Context) error {
+		return s.invitationRepo.Create(ctx, inv)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	msg := mail.Message{
+		To:      email,
+		Subject: fmt.Sprintf("You've been invited to %s", project.Name),
+		Body: fmt.Sprintf(
+			"You have been invited to join the project %q as %s.\n\n"+
+				"Accept the invitation here:\n%s/invitations/%s\n\n"+
+				"This link expires on %s.\n",
+			project.Name, role, s.appURL, rawToken, inv.ExpiresAt.Format(time.RFC1123),
+		),
+	}
+	// The invitation is already stored and listed as pending, so a mail
+	// failure shouldn't fail the request; it can be revoked and sent again.
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		logging.FromContext(ctx).Error("failed to send invitation", "invitation_id", inv.ID, "error", err)
+	}
+
+	return inv, nil
+}
+
+func (s *InvitationService) ListPending(ctx context.Context, projectID, userID uuid.UUID) ([]*domain.Invitation, error) {
+	ctx, span := tracing.Start(ctx, "InvitationService.ListPending")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionManage, authz.Project(projectID)); err != nil {
+		return nil, err
+	}
+	return s.invitationRepo.ListPendingByProject(ctx, projectID)
+}
+
+func (s *InvitationService) Revoke(ctx context.Context, projectID, invitationID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "InvitationService.Revoke")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionManage, authz.Project(projectID)); err != nil {
+		return err
+	}
+	inv, err := s.invitationRepo.GetByID(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	if inv.ProjectID != projectID {
+		return domain.ErrNotFound
+	}
+	return s.auditService.apply(ctx, auditEntry{
+		actorID:    userID,
+		projectID:  &projectID,
+		entityType: domain.AuditEntityInvitation,
+		entityID:   invitationID,
+		action:     domain.AuditActionDelete,
+		before:     inv,
+	}, func(ctx context.Context) error {
+		return s.invitationRepo.Delete(ctx, invitationID)
+	})
+}
+
+type InvitationPreview struct {
+	ProjectID   uuid.UUID          `json:"project_id"`
+	ProjectName string             `json:"project_name"`
+	Email       string             `json:"email"`
+	Role        domain.ProjectRole `json:"role"`
+	ExpiresAt   time.Time          `json:"expires_at"`
+	UserExists  bool               `json:"user_exists"`
+}
+
+// Preview describes a pending invitation so the accept page can show what
+// the user is joining and whether they need to register first.
+func (s *InvitationService) Preview(ctx context.Context, rawToken string) (*InvitationPreview, error) {
+	ctx, span := tracing.Start(ctx, "InvitationService.Preview")
+	defer span.End()
+
+	inv, err := s.pending(ctx, rawToken)
+	if err != nil {
+		return nil, err
+	}
+	project, err := s.projectRepo.GetByID(ctx, inv.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.userRepo.GetByEmail(ctx, inv.Email)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	return &InvitationPreview{
+		ProjectID:   project.ID,
+		ProjectName: project.Name,
+		Email:       inv.Email,
+		Role:        inv.Role,
+		ExpiresAt:   inv.ExpiresAt,
+		UserExists:  err == nil,
+	}, nil
+}
+
+// Accept grants the logged-in user access. The invitation is bound to the
+// address it was sent to, so the user's email must match.
+func (s *InvitationService) Accept(ctx context.Context, rawToken string, userID uuid.UUID) (*domain.ProjectMember, error) {
+	ctx, span := tracing.Start(ctx, "InvitationService.Accept")
+	defer span.End()
+
+	inv, err := s.pending(ctx, rawToken)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(user.Email, inv.Email) {
+		return nil, domain.ErrForbidden
+	}
+
+	var member *domain.ProjectMember
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		member, err = s.accept(ctx, inv, user)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+type RegisterWithInvitationInput struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+// Register creates an account for the invited email address and accepts
+// the invitation in one transaction, so a failed accept leaves no account
+// behind. Following the emailed link proves the address, so the account
+// starts out verified.
+func (s *InvitationService) Register(ctx context.Context, rawToken string, input RegisterWithInvitationInput) (*domain.User, *domain.ProjectMember, error) {
+	ctx, span := tracing.Start(ctx, "InvitationService.Register")
+	defer span.End()
+
+	inv, err := s.pending(ctx, rawToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var user *domain.User
+	var member *domain.ProjectMember
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		user, err = s.authService.register(ctx, RegisterInput{
+			Email:    inv.Email,
+			Name:     input.Name,
+			Password: input.Password,
+		}, true)
+		if err != nil {
+			return err
+		}
+		member, err = s.accept(ctx, inv, user)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, member, nil
+}
+
+func (s *InvitationService) pending(ctx context.Context, rawToken string) (*domain.Invitation, error) {
+	inv, err := s.invitationRepo.GetByTokenHash(ctx, hashToken(rawToken))
+	if err != nil {
+		return nil, err
+	}
+	if inv.AcceptedAt != nil {
+		return nil, fmt.Errorf("%w: invitation has already been accepted", domain.ErrValidation)
+	}
+	if time.Now().After(inv.ExpiresAt) {
+		return nil, fmt.Errorf("%w: invitation has expired", domain.ErrValidation)
+	}
+	return inv, nil
+}
+
+// accept adds the user to the project, and to the project's organization if
+// they aren't in it yet, and marks the invitation used. It must run inside
+// the caller's transaction.
+func (s *InvitationService) accept(ctx context.Context, inv *domain.Invitation, user *domain.User) (*domain.ProjectMember, error) {
+	project, err := s.projectRepo.GetByID(ctx, inv.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	_, err = s.orgMemberRepo.Get(ctx, project.OrganizationID, user.ID)
+	if errors.Is(err, domain.ErrNotFound) {
+		err = s.joinOrganization(ctx, project.OrganizationID, user, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Someone who is already a member keeps their role rather than being
+	// downgraded.
+	member, err := s.memberRepo.Get(ctx, inv.ProjectID, user.ID)
+	if errors.Is(err, domain.ErrNotFound) {
+		member, err = s.joinProject(ctx, inv, user, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.invitationRepo.MarkAccepted(ctx, inv.ID, now); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+func (s *InvitationService) joinOrganization(ctx context.Context, orgID uuid.UUID, user *domain.User, now time.Time) error {
+	member := &domain.OrganizationMember{
+		OrganizationID: orgID,
+		UserID:         user.ID,
+		Role:           domain.OrgRoleMember,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.orgMemberRepo.Create(ctx, member); err != nil {
+		return err
+	}
+	return s.auditService.record(ctx, auditEntry{
+		actorID:    user.ID,
+		entityType: domain.AuditEntityOrganizationMember,
+		entityID:   user.ID,
+		action:     domain.AuditActionCreate,
+		after:      member,
+	})
+}
+
+func (s *InvitationService) joinProject(ctx context.Context, inv *domain.Invitation, user *domain.User, now time.Time) (*domain.ProjectMember, error) {
+	member := &domain.ProjectMember{
+		ProjectID: inv.ProjectID,
+		UserID:    user.ID,
+		Role:      inv.Role,
+		Email:     user.Email,
+		Name:      user.Name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.memberRepo.Create(ctx, member); err != nil {
+		return nil, err
+	}
+	err := s.auditService.record(ctx, auditEntry{
+		actorID:    user.ID,
+		projectID:  &inv.ProjectID,
+		entityType: domain.AuditEntityProjectMember,
+		entityID:   user.ID,
+		action:     domain.AuditActionCreate,
+		after:      member,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
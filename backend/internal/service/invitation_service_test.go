@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/authz"
+	"github.com/letyshub/project-management/internal/config"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/jwtkeys"
+	"github.com/letyshub/project-management/internal/repository/memory"
+)
+
+// racingInvitations lets a test run something just before an invitation is
+// marked accepted, such as a concurrent accept.
+type racingInvitations struct {
+	*memory.InvitationRepo
+	beforeMark func(ctx context.Context, id uuid.UUID)
+}
+
+func (r *racingInvitations) MarkAccepted(ctx context.Context, id uuid.UUID, acceptedAt time.Time) error {
+	if r.beforeMark != nil {
+		r.beforeMark(ctx, id)
+	}
+	return r.InvitationRepo.MarkAccepted(ctx, id, acceptedAt)
+}
+
+type invitationFixture struct {
+	svc         *InvitationService
+	users       *memory.UserRepo
+	orgMembers  *memory.OrganizationMemberRepo
+	members     *memory.ProjectMemberRepo
+	invitations *racingInvitations
+	mailer      *mockMailer
+	org         uuid.UUID
+	project     uuid.UUID
+	owner       uuid.UUID
+}
+
+func newInvitationFixture(t *testing.T) *invitationFixture {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.NewStore()
+	users := memory.NewUserRepo(store)
+	projects := memory.NewProjectRepo(store)
+	members := memory.NewProjectMemberRepo(store)
+	tx := memory.NewTxManager(store)
+
+	policy := authz.NewProjectPolicy(projects, members, memory.NewBoardRepo(store), memory.NewColumnRepo(store),
+		memory.NewTaskRepo(store), memory.NewLabelRepo(store), memory.NewCommentRepo(store))
+	audit := NewAuditService(memory.NewAuditEventRepo(store), tx, policy)
+	auth := NewAuthService(users, memory.NewRefreshTokenRepo(store), memory.NewTwoFactorRepo(store), nil, memory.NewLoginThrottleRepo(store), nil, audit,
+		jwtkeys.NewHMAC("test"), jwtkeys.NewDerivedHMAC("test", "2fa-challenge"), testJWTConfig,
+		config.AuthConfig{LoginMaxAttempts: 100, LoginMaxAttemptsPerIP: 100})
+
+	f := &invitationFixture{
+		users:       users,
+		orgMembers:  memory.NewOrganizationMemberRepo(store),
+		members:     members,
+		invitations: &racingInvitations{InvitationRepo: memory.NewInvitationRepo(store)},
+		mailer:      &mockMailer{},
+	}
+	f.svc = NewInvitationService(f.invitations, members, projects, f.orgMembers, users, tx, auth, audit, policy, f.mailer, "http://app", time.Hour)
+
+	now := time.Now()
+	org := &domain.Organization{ID: uuid.New(), Name: "Acme", CreatedAt: now, UpdatedAt: now}
+	must(t, memory.NewOrganizationRepo(store).Create(ctx, org))
+	f.org = org.ID
+	f.owner = f.newUser(t, "owner@example.com").ID
+	must(t, f.orgMembers.Create(ctx, &domain.OrganizationMember{OrganizationID: f.org, UserID: f.owner, Role: domain.OrgRoleOwner, CreatedAt: now, UpdatedAt: now}))
+	project := &domain.Project{ID: uuid.New(), OrganizationID: f.org, Name: "Roadmap", OwnerID: f.owner, CreatedAt: now, UpdatedAt: now}
+	must(t, projects.Create(ctx, project))
+	f.project = project.ID
+	must(t, members.Create(ctx, &domain.ProjectMember{ProjectID: f.project, UserID: f.owner, Role: domain.ProjectRoleOwner, CreatedAt: now, UpdatedAt: now}))
+	return f
+}
+
+func (f *invitationFixture) newUser(t *testing.T, email string) *domain.User {
+	t.Helper()
+	now := time.Now()
+	u := &domain.User{ID: uuid.New(), Email: email, Name: email, Role: domain.UserRoleMember, CreatedAt: now, UpdatedAt: now}
+	must(t, f.users.Create(context.Background(), u))
+	return u
+}
+
+// invite stores an invitation directly and returns its raw token.
+func (f *invitationFixture) invite(t *testing.T, email string, role domain.ProjectRole, expiresAt time.Time) string {
+	t.Helper()
+	raw := uuid.NewString()
+	must(t, f.invitations.Create(context.Background(), &domain.Invitation{
+		ID: uuid.New(), ProjectID: f.project, Email: email, Role: role, TokenHash: hashToken(raw),
+		InvitedBy: f.owner, ExpiresAt: expiresAt, CreatedAt: time.Now(),
+	}))
+	return raw
+}
+
+func TestInvitationService_AcceptJoinsProjectAndOrganization(t *testing.T) {
+	f := newInvitationFixture(t)
+	ctx := context.Background()
+	user := f.newUser(t, "ada@example.com")
+	raw := f.invite(t, "ADA@example.com", domain.ProjectRoleMember, time.Now().Add(time.Hour))
+
+	member, err := f.svc.Accept(ctx, raw, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if member.Role != domain.ProjectRoleMember {
+		t.Errorf("expected the invited role, got %s", member.Role)
+	}
+	if _, err := f.members.Get(ctx, f.project, user.ID); err != nil {
+		t.Errorf("expected project membership, got %v", err)
+	}
+	orgMember, err := f.orgMembers.Get(ctx, f.org, user.ID)
+	if err != nil {
+		t.Fatalf("expected organization membership, got %v", err)
+	}
+	if orgMember.Role != domain.OrgRoleMember {
+		t.Errorf("expected to join the organization as a member, got %s", orgMember.Role)
+	}
+}
+
+func TestInvitationService_AcceptKeepsExistingRoles(t *testing.T) {
+	f := newInvitationFixture(t)
+	ctx := context.Background()
+	user := f.newUser(t, "ada@example.com")
+	now := time.Now()
+	must(t, f.orgMembers.Create(ctx, &domain.OrganizationMember{OrganizationID: f.org, UserID: user.ID, Role: domain.OrgRoleAdmin, CreatedAt: now, UpdatedAt: now}))
+	must(t, f.members.Create(ctx, &domain.ProjectMember{ProjectID: f.project, UserID: user.ID, Role: domain.ProjectRoleAdmin, CreatedAt: now, UpdatedAt: now}))
+	raw := f.invite(t, user.Email, domain.ProjectRoleViewer, now.Add(time.Hour))
+
+	member, err := f.svc.Accept(ctx, raw, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if member.Role != domain.ProjectRoleAdmin {
+		t.Errorf("expected the existing project role to be kept, got %s", member.Role)
+	}
+	if orgMember, err := f.orgMembers.Get(ctx, f.org, user.ID); err != nil || orgMember.Role != domain.OrgRoleAdmin {
+		t.Errorf("expected the existing organization role to be kept, got %+v, %v", orgMember, err)
+	}
+	if _, err := f.svc.Accept(ctx, raw, user.ID); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("expected the invitation to be used up, got %v", err)
+	}
+}
+
+func TestInvitationService_AcceptRejectsUnusableInvitations(t *testing.T) {
+	tests := []struct {
+		name  string
+		token func(t *testing.T, f *invitationFixture, user *domain.User) string
+		want  error
+	}{
+		{
+			name: "mismatched email",
+			token: func(t *testing.T, f *invitationFixture, user *domain.User) string {
+				return f.invite(t, "someone-else@example.com", domain.ProjectRoleMember, time.Now().Add(time.Hour))
+			},
+			want: domain.ErrForbidden,
+		},
+		{
+			name: "expired",
+			token: func(t *testing.T, f *invitationFixture, user *domain.User) string {
+				return f.invite(t, user.Email, domain.ProjectRoleMember, time.Now().Add(-time.Minute))
+			},
+			want: domain.ErrValidation,
+		},
+		{
+			name: "already accepted",
+			token: func(t *testing.T, f *invitationFixture, user *domain.User) string {
+				raw := f.invite(t, user.Email, domain.ProjectRoleMember, time.Now().Add(time.Hour))
+				inv, err := f.invitations.GetByTokenHash(context.Background(), hashToken(raw))
+				must(t, err)
+				must(t, f.invitations.MarkAccepted(context.Background(), inv.ID, time.Now()))
+				return raw
+			},
+			want: domain.ErrValidation,
+		},
+		{
+			name:  "unknown",
+			token: func(t *testing.T, f *invitationFixture, user *domain.User) string { return "unknown" },
+			want:  domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newInvitationFixture(t)
+			ctx := context.Background()
+			user := f.newUser(t, "ada@example.com")
+
+			if _, err := f.svc.Accept(ctx, tt.token(t, f, user), user.ID); !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+			if _, err := f.members.Get(ctx, f.project, user.ID); !errors.Is(err, domain.ErrNotFound) {
+				t.Errorf("expected no project membership, got %v", err)
+			}
+			if _, err := f.orgMembers.Get(ctx, f.org, user.ID); !errors.Is(err, domain.ErrNotFound) {
+				t.Errorf("expected no organization membership, got %v", err)
+			}
+		})
+	}
+}
+
+func TestInvitationService_RegisterOnAccept(t *testing.T) {
+	f := newInvitationFixture(t)
+	ctx := context.Background()
+	raw := f.invite(t, "ada@example.com", domain.ProjectRoleMember, time.Now().Add(time.Hour))
+
+	user, member, err := f.svc.Register(ctx, raw, RegisterWithInvitationInput{Name: "Ada", Password: "password123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Email != "ada@example.com" || !user.EmailVerified() {
+		t.Errorf("expected a verified account for the invited address, got %+v", user)
+	}
+	if member.UserID != user.ID || member.Role != domain.ProjectRoleMember {
+		t.Errorf("expected the new account to join with the invited role, got %+v", member)
+	}
+	if _, err := f.orgMembers.Get(ctx, f.org, user.ID); err != nil {
+		t.Errorf("expected organization membership, got %v", err)
+	}
+	if _, _, err := f.svc.Register(ctx, raw, RegisterWithInvitationInput{Name: "Eve", Password: "password123"}); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("expected the invitation to be used up, got %v", err)
+	}
+}
+
+func TestInvitationService_RegisterRollsBackWhenAcceptFails(t *testing.T) {
+	f := newInvitationFixture(t)
+	ctx := context.Background()
+	raw := f.invite(t, "ada@example.com", domain.ProjectRoleMember, time.Now().Add(time.Hour))
+	// Another request accepts the invitation first.
+	f.invitations.beforeMark = func(ctx context.Context, id uuid.UUID) {
+		f.invitations.beforeMark = nil
+		must(t, f.invitations.InvitationRepo.MarkAccepted(ctx, id, time.Now()))
+	}
+
+	if _, _, err := f.svc.Register(ctx, raw, RegisterWithInvitationInput{Name: "Ada", Password: "password123"}); err == nil {
+		t.Fatal("expected the losing accept to fail")
+	}
+	if _, err := f.users.GetByEmail(ctx, "ada@example.com"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected no account to be left behind, got %v", err)
+	}
+	inv, err := f.invitations.GetByTokenHash(ctx, hashToken(raw))
+	must(t, err)
+	if inv.AcceptedAt != nil {
+		t.Error("expected the invitation to stay pending")
+	}
+	if _, _, err := f.svc.Register(ctx, raw, RegisterWithInvitationInput{Name: "Ada", Password: "password123"}); err != nil {
+		t.Errorf("expected a retry to succeed, got %v", err)
+	}
+}
+
+func TestInvitationService_CreateSurvivesMailFailure(t *testing.T) {
+	f := newInvitationFixture(t)
+	ctx := context.Background()
+	f.mailer.err = errors.New("smtp down")
+
+	inv, err := f.svc.Create(ctx, f.project, f.owner, CreateInvitationInput{Email: "ada@example.com"})
+	if err != nil {
+		t.Fatalf("expected the invitation to be created despite the mail failure, got %v", err)
+	}
+	pending, err := f.svc.ListPending(ctx, f.project, f.owner)
+	must(t, err)
+	if len(pending) != 1 || pending[0].ID != inv.ID {
+		t.Errorf("expected the invitation to be listed as pending, got %+v", pending)
+	}
+}
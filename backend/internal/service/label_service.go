@@ -7,16 +7,25 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/letyshub/project-management/internal/authz"
 	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/tracing"
 )
 
 type LabelService struct {
-	labelRepo   domain.LabelRepository
-	projectRepo domain.ProjectRepository
+	labelRepo    domain.LabelRepository
+	auditService *AuditService
+	policy       authz.Policy
 }
 
-func NewLabelService(labelRepo domain.LabelRepository, projectRepo domain.ProjectRepository) *LabelService {
-	return &LabelService{labelRepo: labelRepo, projectRepo: projectRepo}
+func NewLabelService(labelRepo domain.LabelRepository, auditService *AuditService, policy authz.Policy) *LabelService {
+	return &LabelService{labelRepo: labelRepo, auditService: auditService, policy: policy}
+}
+
+// taskLabel is the snapshot recorded when a label is added to or removed
+// from a task.
+type taskLabel struct {
+	LabelID uuid.UUID `json:"label_id"`
 }
 
 type CreateLabelInput struct {
@@ -24,14 +33,13 @@ type CreateLabelInput struct {
 	Color string `json:"color"`
 }
 
-func (s *LabelService) Create(ctx context.Context, projectID, ownerID uuid.UUID, input CreateLabelInput) (*domain.Label, error) {
-	project, err := s.projectRepo.GetByID(ctx, projectID)
-	if err != nil {
+func (s *LabelService) Create(ctx context.Context, projectID, userID uuid.UUID, input CreateLabelInput) (*domain.Label, error) {
+	ctx, span := tracing.Start(ctx, "LabelService.Create")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionManage, authz.Project(projectID)); err != nil {
 		return nil, err
 	}
-	if project.OwnerID != ownerID {
-		return nil, domain.ErrForbidden
-	}
 	if input.Name == "" {
 		return nil, fmt.Errorf("%w: name is required", domain.ErrValidation)
 	}
@@ -48,39 +56,109 @@ func (s *LabelService) Create(ctx context.Context, projectID, ownerID uuid.UUID,
 		CreatedAt: time.Now(),
 	}
 
-	if err := s.labelRepo.Create(ctx, label); err != nil {
+	err := s.auditService.apply(ctx, auditEntry{
+		actorID: userID, projectID: &projectID,
+		entityType: domain.AuditEntityLabel, entityID: label.ID, action: domain.AuditActionCreate,
+		after: label,
+	}, func(ctx context.Context) error {
+		return s.labelRepo.Create(ctx, label)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return label, nil
 }
 
-func (s *LabelService) ListByProject(ctx context.Context, projectID uuid.UUID) ([]*domain.Label, error) {
+func (s *LabelService) ListByProject(ctx context.Context, projectID, userID uuid.UUID) ([]*domain.Label, error) {
+	ctx, span := tracing.Start(ctx, "LabelService.ListByProject")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionRead, authz.Project(projectID)); err != nil {
+		return nil, err
+	}
 	return s.labelRepo.ListByProject(ctx, projectID)
 }
 
-func (s *LabelService) Delete(ctx context.Context, labelID, ownerID uuid.UUID) error {
+func (s *LabelService) Delete(ctx context.Context, labelID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "LabelService.Delete")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionManage, authz.Label(labelID)); err != nil {
+		return err
+	}
 	label, err := s.labelRepo.GetByID(ctx, labelID)
 	if err != nil {
 		return err
 	}
-	project, err := s.projectRepo.GetByID(ctx, label.ProjectID)
+	return s.auditService.apply(ctx, auditEntry{
+		actorID: userID, projectID: &label.ProjectID,
+		entityType: domain.AuditEntityLabel, entityID: labelID, action: domain.AuditActionDelete,
+		before: label,
+	}, func(ctx context.Context) error {
+		return s.labelRepo.Delete(ctx, labelID)
+	})
+}
+
+func (s *LabelService) AddToTask(ctx context.Context, taskID, labelID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "LabelService.AddToTask")
+	defer span.End()
+
+	projectID, err := s.authorizeTaskLabel(ctx, taskID, labelID, userID)
 	if err != nil {
 		return err
 	}
-	if project.OwnerID != ownerID {
-		return domain.ErrForbidden
-	}
-	return s.labelRepo.Delete(ctx, labelID)
+	return s.auditService.apply(ctx, auditEntry{
+		actorID: userID, projectID: &projectID,
+		entityType: domain.AuditEntityTask, entityID: taskID, action: domain.AuditActionAddLabel,
+		after: taskLabel{LabelID: labelID},
+	}, func(ctx context.Context) error {
+		return s.labelRepo.AddToTask(ctx, taskID, labelID)
+	})
 }
 
-func (s *LabelService) AddToTask(ctx context.Context, taskID, labelID uuid.UUID) error {
-	return s.labelRepo.AddToTask(ctx, taskID, labelID)
-}
+func (s *LabelService) RemoveFromTask(ctx context.Context, taskID, labelID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "LabelService.RemoveFromTask")
+	defer span.End()
 
-func (s *LabelService) RemoveFromTask(ctx context.Context, taskID, labelID uuid.UUID) error {
-	return s.labelRepo.RemoveFromTask(ctx, taskID, labelID)
+	projectID, err := s.authorizeTaskLabel(ctx, taskID, labelID, userID)
+	if err != nil {
+		return err
+	}
+	return s.auditService.apply(ctx, auditEntry{
+		actorID: userID, projectID: &projectID,
+		entityType: domain.AuditEntityTask, entityID: taskID, action: domain.AuditActionRemoveLabel,
+		before: taskLabel{LabelID: labelID},
+	}, func(ctx context.Context) error {
+		return s.labelRepo.RemoveFromTask(ctx, taskID, labelID)
+	})
 }
 
-func (s *LabelService) ListByTask(ctx context.Context, taskID uuid.UUID) ([]*domain.Label, error) {
+func (s *LabelService) ListByTask(ctx context.Context, taskID, userID uuid.UUID) ([]*domain.Label, error) {
+	ctx, span := tracing.Start(ctx, "LabelService.ListByTask")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionRead, authz.Task(taskID)); err != nil {
+		return nil, err
+	}
 	return s.labelRepo.ListByTask(ctx, taskID)
 }
+
+// authorizeTaskLabel checks write access to the task and that the label
+// belongs to the same project, and returns that project's ID.
+func (s *LabelService) authorizeTaskLabel(ctx context.Context, taskID, labelID, userID uuid.UUID) (uuid.UUID, error) {
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionWrite, authz.Task(taskID)); err != nil {
+		return uuid.Nil, err
+	}
+	taskProject, err := s.policy.ProjectOf(ctx, authz.Task(taskID))
+	if err != nil {
+		return uuid.Nil, err
+	}
+	labelProject, err := s.policy.ProjectOf(ctx, authz.Label(labelID))
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if taskProject != labelProject {
+		return uuid.Nil, fmt.Errorf("%w: label belongs to another project", domain.ErrValidation)
+	}
+	return taskProject, nil
+}
@@ -0,0 +1,430 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/authz"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/tracing"
+)
+
+const personalOrganizationName = "Personal"
+
+// OrganizationService manages organizations and their members. Org roles
+// only govern the organization itself; access to a project still comes from
+// project membership.
+type OrganizationService struct {
+	orgRepo           domain.OrganizationRepository
+	memberRepo        domain.OrganizationMemberRepository
+	userRepo          domain.UserRepository
+	projectRepo       domain.ProjectRepository
+	projectMemberRepo domain.ProjectMemberRepository
+	auditService      *AuditService
+	txManager         domain.TxManager
+}
+
+func NewOrganizationService(
+	orgRepo domain.OrganizationRepository,
+	memberRepo domain.OrganizationMemberRepository,
+	userRepo domain.UserRepository,
+	projectRepo domain.ProjectRepository,
+	projectMemberRepo domain.ProjectMemberRepository,
+	auditService *AuditService,
+	txManager domain.TxManager,
+) *OrganizationService {
+	return &OrganizationService{
+		orgRepo:           orgRepo,
+		memberRepo:        memberRepo,
+		userRepo:          userRepo,
+		projectRepo:       projectRepo,
+		projectMemberRepo: projectMemberRepo,
+		auditService:      auditService,
+		txManager:         txManager,
+	}
+}
+
+type CreateOrganizationInput struct {
+	Name string `json:"name"`
+}
+
+func (s *OrganizationService) Create(ctx context.Context, userID uuid.UUID, input CreateOrganizationInput) (*domain.Organization, error) {
+	ctx, span := tracing.Start(ctx, "OrganizationService.Create")
+	defer span.End()
+
+	if input.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", domain.ErrValidation)
+	}
+	return s.create(ctx, userID, input.Name, nil)
+}
+
+func (s *OrganizationService) create(ctx context.Context, userID uuid.UUID, name string, personalOwner *uuid.UUID) (*domain.Organization, error) {
+	now := time.Now()
+	org := &domain.Organization{
+		ID:              uuid.New(),
+		Name:            name,
+		PersonalOwnerID: personalOwner,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	owner := &domain.OrganizationMember{
+		OrganizationID: org.ID,
+		UserID:         userID,
+		Role:           domain.OrgRoleOwner,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.orgRepo.Create(ctx, org); err != nil {
+			return err
+		}
+		return s.memberRepo.Create(ctx, owner)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// Personal returns the user's personal workspace, creating it the first time
+// it is needed. Users that existed before organizations got theirs from the
+// migration.
+func (s *OrganizationService) Personal(ctx context.Context, userID uuid.UUID) (*domain.Organization, error) {
+	ctx, span := tracing.Start(ctx, "OrganizationService.Personal")
+	defer span.End()
+
+	org, err := s.orgRepo.GetPersonal(ctx, userID)
+	if !errors.Is(err, domain.ErrNotFound) {
+		return org, err
+	}
+	org, err = s.create(ctx, userID, personalOrganizationName, &userID)
+	if errors.Is(err, domain.ErrConflict) {
+		// Another request created it first.
+		return s.orgRepo.GetPersonal(ctx, userID)
+	}
+	return org, err
+}
+
+// Active returns the organization new projects go into: the context's
+// active organization if one was chosen, the user's personal one otherwise.
+func (s *OrganizationService) Active(ctx context.Context, userID uuid.UUID) (*domain.Organization, error) {
+	ctx, span := tracing.Start(ctx, "OrganizationService.Active")
+	defer span.End()
+
+	orgID, ok := authz.ActiveOrganization(ctx)
+	if !ok {
+		return s.Personal(ctx, userID)
+	}
+	if _, err := s.Authorize(ctx, orgID, userID, domain.OrgRoleMember); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.GetByID(ctx, orgID)
+}
+
+func (s *OrganizationService) ListForUser(ctx context.Context, userID uuid.UUID) ([]*domain.Organization, error) {
+	ctx, span := tracing.Start(ctx, "OrganizationService.ListForUser")
+	defer span.End()
+
+	if _, err := s.Personal(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListByMember(ctx, userID)
+}
+
+func (s *OrganizationService) Get(ctx context.Context, orgID, userID uuid.UUID) (*domain.Organization, error) {
+	ctx, span := tracing.Start(ctx, "OrganizationService.Get")
+	defer span.End()
+
+	if _, err := s.Authorize(ctx, orgID, userID, domain.OrgRoleMember); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.GetByID(ctx, orgID)
+}
+
+type UpdateOrganizationInput struct {
+	Name *string `json:"name"`
+}
+
+func (s *OrganizationService) Update(ctx context.Context, orgID, userID uuid.UUID, input UpdateOrganizationInput) (*domain.Organization, error) {
+	ctx, span := tracing.Start(ctx, "OrganizationService.Update")
+	defer span.End()
+
+	if _, err := s.Authorize(ctx, orgID, userID, domain.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if input.Name != nil {
+		if *input.Name == "" {
+			return nil, fmt.Errorf("%w: name cannot be empty", domain.ErrValidation)
+		}
+		org.Name = *input.Name
+	}
+	org.UpdatedAt = time.Now()
+
+	if err := s.orgRepo.Update(ctx, org); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// Delete removes the organization and every project in it. Personal
+// workspaces can't be deleted.
+func (s *OrganizationService) Delete(ctx context.Context, orgID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "OrganizationService.Delete")
+	defer span.End()
+
+	if _, err := s.Authorize(ctx, orgID, userID, domain.OrgRoleOwner); err != nil {
+		return err
+	}
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if org.Personal() {
+		return fmt.Errorf("%w: a personal workspace can't be deleted", domain.ErrValidation)
+	}
+	return s.orgRepo.Delete(ctx, orgID)
+}
+
+func (s *OrganizationService) ListMembers(ctx context.Context, orgID, actorID uuid.UUID) ([]*domain.OrganizationMember, error) {
+	ctx, span := tracing.Start(ctx, "OrganizationService.ListMembers")
+	defer span.End()
+
+	if _, err := s.Authorize(ctx, orgID, actorID, domain.OrgRoleMember); err != nil {
+		return nil, err
+	}
+	return s.memberRepo.ListByOrganization(ctx, orgID)
+}
+
+type AddOrganizationMemberInput struct {
+	Email string         `json:"email"`
+	Role  domain.OrgRole `json:"role"`
+}
+
+func (s *OrganizationService) AddMember(ctx context.Context, orgID, actorID uuid.UUID, input AddOrganizationMemberInput) (*domain.OrganizationMember, error) {
+	ctx, span := tracing.Start(ctx, "OrganizationService.AddMember")
+	defer span.End()
+
+	actor, err := s.Authorize(ctx, orgID, actorID, domain.OrgRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if input.Email == "" {
+		return nil, fmt.Errorf("%w: email is required", domain.ErrValidation)
+	}
+	role := input.Role
+	if role == "" {
+		role = domain.OrgRoleMember
+	}
+	if err := checkAssignableOrgRole(actor, role); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, input.Email)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%w: no user with that email", domain.ErrValidation)
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	member := &domain.OrganizationMember{
+		OrganizationID: orgID,
+		UserID:         user.ID,
+		Role:           role,
+		Email:          user.Email,
+		Name:           user.Name,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	err = s.auditService.apply(ctx, auditEntry{
+		actorID:    actorID,
+		entityType: domain.AuditEntityOrganizationMember,
+		entityID:   user.ID,
+		action:     domain.AuditActionCreate,
+		after:      member,
+	}, func(ctx context.Context) error {
+		return s.memberRepo.Create(ctx, member)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+type UpdateOrganizationMemberInput struct {
+	Role domain.OrgRole `json:"role"`
+}
+
+func (s *OrganizationService) UpdateMemberRole(ctx context.Context, orgID, actorID, userID uuid.UUID, input UpdateOrganizationMemberInput) (*domain.OrganizationMember, error) {
+	ctx, span := tracing.Start(ctx, "OrganizationService.UpdateMemberRole")
+	defer span.End()
+
+	actor, err := s.Authorize(ctx, orgID, actorID, domain.OrgRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	member, err := s.memberRepo.Get(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAssignableOrgRole(actor, input.Role); err != nil {
+		return nil, err
+	}
+	if member.Role == domain.OrgRoleOwner && input.Role != domain.OrgRoleOwner {
+		if err := s.checkOwnerRemovable(ctx, actor, orgID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	before := *member
+	member.Role = input.Role
+	member.UpdatedAt = time.Now()
+
+	err = s.auditService.apply(ctx, auditEntry{
+		actorID:    actorID,
+		entityType: domain.AuditEntityOrganizationMember,
+		entityID:   userID,
+		action:     domain.AuditActionUpdate,
+		before:     &before,
+		after:      member,
+		keep:       []string{"organization_id"},
+	}, func(ctx context.Context) error {
+		return s.memberRepo.Update(ctx, member)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// RemoveMember takes the user out of the organization and all of its
+// projects. Admins can remove others; any member can leave, unless they are
+// the last owner of the organization or of one of its projects.
+func (s *OrganizationService) RemoveMember(ctx context.Context, orgID, actorID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "OrganizationService.RemoveMember")
+	defer span.End()
+
+	required := domain.OrgRoleAdmin
+	if actorID == userID {
+		required = domain.OrgRoleMember
+	}
+	actor, err := s.Authorize(ctx, orgID, actorID, required)
+	if err != nil {
+		return err
+	}
+	member, err := s.memberRepo.Get(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if member.Role == domain.OrgRoleOwner {
+		if err := s.checkOwnerRemovable(ctx, actor, orgID, userID); err != nil {
+			return err
+		}
+	}
+	if err := s.ensureNotSoleProjectOwner(ctx, orgID, userID); err != nil {
+		return err
+	}
+	return s.auditService.apply(ctx, auditEntry{
+		actorID:    actorID,
+		entityType: domain.AuditEntityOrganizationMember,
+		entityID:   userID,
+		action:     domain.AuditActionDelete,
+		before:     member,
+	}, func(ctx context.Context) error {
+		return s.memberRepo.Delete(ctx, orgID, userID)
+	})
+}
+
+// Authorize checks that userID belongs to orgID with at least the required
+// role and returns their membership.
+func (s *OrganizationService) Authorize(ctx context.Context, orgID, userID uuid.UUID, required domain.OrgRole) (*domain.OrganizationMember, error) {
+	ctx, span := tracing.Start(ctx, "OrganizationService.Authorize")
+	defer span.End()
+
+	if _, err := s.orgRepo.GetByID(ctx, orgID); err != nil {
+		return nil, err
+	}
+	member, err := s.memberRepo.Get(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrForbidden
+		}
+		return nil, err
+	}
+	if !member.Role.AtLeast(required) {
+		return nil, domain.ErrForbidden
+	}
+	return member, nil
+}
+
+// checkOwnerRemovable guards demoting or removing an owner: only owners may
+// do it, a personal workspace keeps its user as owner, and there must be
+// another owner left.
+func (s *OrganizationService) checkOwnerRemovable(ctx context.Context, actor *domain.OrganizationMember, orgID, userID uuid.UUID) error {
+	if actor.Role != domain.OrgRoleOwner {
+		return domain.ErrForbidden
+	}
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if org.PersonalOwnerID != nil && *org.PersonalOwnerID == userID {
+		return fmt.Errorf("%w: the owner of a personal workspace can't be changed", domain.ErrValidation)
+	}
+	owners, err := s.memberRepo.CountByRole(ctx, orgID, domain.OrgRoleOwner)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return fmt.Errorf("%w: an organization must keep at least one owner", domain.ErrValidation)
+	}
+	return nil
+}
+
+func (s *OrganizationService) ensureNotSoleProjectOwner(ctx context.Context, orgID, userID uuid.UUID) error {
+	projects, err := s.projectRepo.ListByMember(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, p := range projects {
+		if p.OrganizationID != orgID {
+			continue
+		}
+		member, err := s.projectMemberRepo.Get(ctx, p.ID, userID)
+		if err != nil {
+			return err
+		}
+		if member.Role != domain.ProjectRoleOwner {
+			continue
+		}
+		owners, err := s.projectMemberRepo.CountByRole(ctx, p.ID, domain.ProjectRoleOwner)
+		if err != nil {
+			return err
+		}
+		if owners <= 1 {
+			return fmt.Errorf("%w: transfer ownership of project %q first", domain.ErrValidation, p.Name)
+		}
+	}
+	return nil
+}
+
+// checkAssignableOrgRole validates role and makes sure only owners can hand
+// out ownership.
+func checkAssignableOrgRole(actor *domain.OrganizationMember, role domain.OrgRole) error {
+	if !role.Valid() {
+		return fmt.Errorf("%w: role must be owner, admin, or member", domain.ErrValidation)
+	}
+	if role == domain.OrgRoleOwner && actor.Role != domain.OrgRoleOwner {
+		return domain.ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/authz"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/repository/memory"
+)
+
+func newTestOrgService(store *memory.Store) (*OrganizationService, *memory.OrganizationRepo, *memory.OrganizationMemberRepo) {
+	orgs := memory.NewOrganizationRepo(store)
+	members := memory.NewOrganizationMemberRepo(store)
+	svc := NewOrganizationService(orgs, members, memory.NewUserRepo(store), memory.NewProjectRepo(store), memory.NewProjectMemberRepo(store),
+		newTestAuditService(store), memory.NewTxManager(store))
+	return svc, orgs, members
+}
+
+func TestOrganizationService_Personal(t *testing.T) {
+	svc, orgs, members := newTestOrgService(memory.NewStore())
+	userID := uuid.New()
+
+	first, err := svc.Personal(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	second, err := svc.Personal(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if all, _ := orgs.ListByMember(context.Background(), userID); first.ID != second.ID || len(all) != 1 {
+		t.Errorf("expected one personal workspace, got %d", len(all))
+	}
+	if m, err := members.Get(context.Background(), first.ID, userID); err != nil || m.Role != domain.OrgRoleOwner {
+		t.Errorf("expected the user to own their workspace, got %+v, %v", m, err)
+	}
+	if err := svc.Delete(context.Background(), first.ID, userID); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("expected personal workspace deletion to be refused, got %v", err)
+	}
+}
+
+func TestOrganizationService_Authorize(t *testing.T) {
+	svc, _, _ := newTestOrgService(memory.NewStore())
+	ownerID, outsiderID := uuid.New(), uuid.New()
+
+	org, err := svc.Create(context.Background(), ownerID, CreateOrganizationInput{Name: "Acme"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := svc.Authorize(context.Background(), org.ID, ownerID, domain.OrgRoleOwner); err != nil {
+		t.Errorf("expected creator to be owner, got %v", err)
+	}
+	if _, err := svc.Authorize(context.Background(), org.ID, outsiderID, domain.OrgRoleMember); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("expected ErrForbidden for outsider, got %v", err)
+	}
+	if _, err := svc.Authorize(context.Background(), uuid.New(), ownerID, domain.OrgRoleMember); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for missing organization, got %v", err)
+	}
+}
+
+func TestOrganizationService_RemoveMember(t *testing.T) {
+	svc, _, members := newTestOrgService(memory.NewStore())
+	ownerID, memberID := uuid.New(), uuid.New()
+
+	org, _ := svc.Create(context.Background(), ownerID, CreateOrganizationInput{Name: "Acme"})
+	members.Create(context.Background(), &domain.OrganizationMember{OrganizationID: org.ID, UserID: memberID, Role: domain.OrgRoleMember})
+
+	if err := svc.RemoveMember(context.Background(), org.ID, memberID, ownerID); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("expected members to be unable to remove others, got %v", err)
+	}
+	if err := svc.RemoveMember(context.Background(), org.ID, ownerID, ownerID); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("expected the last owner to be kept, got %v", err)
+	}
+	if err := svc.RemoveMember(context.Background(), org.ID, memberID, memberID); err != nil {
+		t.Errorf("expected a member to be able to leave, got %v", err)
+	}
+}
+
+func TestProjectService_Create_ActiveOrganization(t *testing.T) {
+	f := newProjectFixture(&mockPolicy{})
+	svc := f.svc
+	ownerID := uuid.New()
+	org, _ := f.orgs.Create(context.Background(), ownerID, CreateOrganizationInput{Name: "Acme"})
+
+	project, err := svc.Create(authz.WithOrganization(context.Background(), org.ID), ownerID, CreateProjectInput{Name: "Roadmap"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if project.OrganizationID != org.ID {
+		t.Errorf("expected project in %s, got %s", org.ID, project.OrganizationID)
+	}
+
+	personal, err := svc.Create(context.Background(), ownerID, CreateProjectInput{Name: "Notes"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if personal.OrganizationID == org.ID || personal.OrganizationID == uuid.Nil {
+		t.Errorf("expected project in the personal workspace, got %s", personal.OrganizationID)
+	}
+
+	_, err = svc.Create(authz.WithOrganization(context.Background(), org.ID), uuid.New(), CreateProjectInput{Name: "Intruder"})
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("expected ErrForbidden for a non-member, got %v", err)
+	}
+}
+
+func TestProjectService_ListForUser_ActiveOrganization(t *testing.T) {
+	f := newProjectFixture(&mockPolicy{})
+	userID, orgA, orgB := uuid.New(), uuid.New(), uuid.New()
+	f.addProject(t, orgA, userID, "A")
+	f.addProject(t, orgB, userID, "B")
+
+	all, _ := f.svc.ListForUser(context.Background(), userID)
+	if len(all) != 2 {
+		t.Errorf("expected every project without an active organization, got %d", len(all))
+	}
+	inA, _ := f.svc.ListForUser(authz.WithOrganization(context.Background(), orgA), userID)
+	if len(inA) != 1 || inA[0].Name != "A" {
+		t.Errorf("expected only the project in the active organization, got %+v", inA)
+	}
+}
+
+func TestProjectMemberService_Add_OutsideOrganization(t *testing.T) {
+	f := newProjectFixture(&mockPolicy{})
+	ownerID := uuid.New()
+	project := f.addProject(t, uuid.New(), ownerID, "Roadmap")
+	users := memory.NewUserRepo(f.store)
+	now := time.Now()
+	must(t, users.Create(context.Background(), &domain.User{ID: uuid.New(), Email: "stranger@example.com", Name: "Stranger", Role: domain.UserRoleMember, CreatedAt: now, UpdatedAt: now}))
+	svc := NewProjectMemberService(f.members, users, f.projects, memory.NewOrganizationMemberRepo(f.store), f.audit, &mockPolicy{})
+
+	_, err := svc.Add(context.Background(), project.ID, ownerID, AddMemberInput{Email: "stranger@example.com"})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("expected users outside the organization to be rejected, got %v", err)
+	}
+}
+
+func TestOrganizationService_UpdateMemberRole_RecordsAudit(t *testing.T) {
+	store := memory.NewStore()
+	orgs := memory.NewOrganizationRepo(store)
+	members := memory.NewOrganizationMemberRepo(store)
+	svc := NewOrganizationService(orgs, members, memory.NewUserRepo(store), memory.NewProjectRepo(store), memory.NewProjectMemberRepo(store), newTestAuditService(store), memory.NewTxManager(store))
+
+	org := &domain.Organization{ID: uuid.New(), Name: "Acme"}
+	must(t, orgs.Create(context.Background(), org))
+	ownerID, userID := uuid.New(), uuid.New()
+	_ = members.Create(context.Background(), &domain.OrganizationMember{OrganizationID: org.ID, UserID: ownerID, Role: domain.OrgRoleOwner})
+	_ = members.Create(context.Background(), &domain.OrganizationMember{OrganizationID: org.ID, UserID: userID, Role: domain.OrgRoleMember})
+
+	if _, err := svc.UpdateMemberRole(context.Background(), org.ID, ownerID, userID, UpdateOrganizationMemberInput{Role: domain.OrgRoleAdmin}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RemoveMember(context.Background(), org.ID, ownerID, userID); err != nil {
+		t.Fatal(err)
+	}
+
+	events := auditEvents(t, store)
+	if len(events) != 2 {
+		t.Fatalf("expected two audit events, got %d", len(events))
+	}
+	update := events[0]
+	if update.EntityType != domain.AuditEntityOrganizationMember || update.EntityID != userID || update.ActorID != ownerID || update.Action != domain.AuditActionUpdate {
+		t.Errorf("unexpected update event %+v", update)
+	}
+	orgField := `"organization_id":"` + org.ID.String() + `"`
+	if string(update.Before) != `{`+orgField+`,"role":"member"}` || string(update.After) != `{`+orgField+`,"role":"admin"}` {
+		t.Errorf("expected the role change with the organization kept, got %s -> %s", update.Before, update.After)
+	}
+	if remove := events[1]; remove.Action != domain.AuditActionDelete || !strings.Contains(string(remove.Before), `"role":"admin"`) {
+		t.Errorf("expected the removal to record the last role, got %s %s", remove.Action, remove.Before)
+	}
+}
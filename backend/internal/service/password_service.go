@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/mail"
+	"github.com/letyshub/project-management/internal/tracing"
+)
+
+type PasswordService struct {
+	userRepo         domain.UserRepository
+	refreshTokenRepo domain.RefreshTokenRepository
+	accessTokenRepo  domain.PersonalAccessTokenRepository
+	userTokenRepo    domain.UserTokenRepository
+	txManager        domain.TxManager
+	authService      *AuthService
+	auditService     *AuditService
+	mailer           mail.Mailer
+	appURL           string
+	resetExpiration  time.Duration
+	resendInterval   time.Duration
+}
+
+func NewPasswordService(
+	userRepo domain.UserRepository,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	accessTokenRepo domain.PersonalAccessTokenRepository,
+	userTokenRepo domain.UserTokenRepository,
+	txManager domain.TxManager,
+	authService *AuthService,
+	auditService *AuditService,
+	mailer mail.Mailer,
+	appURL string,
+	resetExpiration time.Duration,
+	resendInterval time.Duration,
+) *PasswordService {
+	return &PasswordService{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		accessTokenRepo:  accessTokenRepo,
+		userTokenRepo:    userTokenRepo,
+		txManager:        txManager,
+		authService:      authService,
+		auditService:     auditService,
+		mailer:           mailer,
+		appURL:           strings.TrimRight(appURL, "/"),
+		resetExpiration:  resetExpiration,
+		resendInterval:   resendInterval,
+	}
+}
+
+type ChangePasswordInput struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// Change sets a new password after checking the current one, and signs the
+// user out of every session but sessionID. Wrong current passwords count
+// toward the login lockout, so a stolen session can't be used to guess it.
+func (s *PasswordService) Change(ctx context.Context, userID, sessionID uuid.UUID, input ChangePasswordInput) error {
+	ctx, span := tracing.Start(ctx, "PasswordService.Change")
+	defer span.End()
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	err = s.authService.throttled(ctx, user.ID, func() error {
+		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(input.CurrentPassword)); err != nil {
+			return fmt.Errorf("%w: current password is incorrect", domain.ErrValidation)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := validatePassword(input.NewPassword); err != nil {
+		return err
+	}
+
+	hash, err := hashPassword(input.NewPassword)
+	if err != nil {
+		return err
+	}
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.UpdatePassword(ctx, user.ID, hash, time.Now()); err != nil {
+			return err
+		}
+		return s.refreshTokenRepo.DeleteOthers(ctx, user.ID, sessionID)
+	})
+	if err != nil {
+		return err
+	}
+	s.auditService.recordAccount(ctx, user.ID, user.ID, domain.AuditActionPasswordChange)
+	return nil
+}
+
+// Forgot emails a reset link if the address belongs to an active account and
+// none was sent within the resend interval. It reports success either way so
+// callers can't probe for registered emails or flood an inbox.
+func (s *PasswordService) Forgot(ctx context.Context, email string) error {
+	ctx, span := tracing.Start(ctx, "PasswordService.Forgot")
+	defer span.End()
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !user.Active() {
+		return nil
+	}
+
+	latest, err := s.userTokenRepo.GetLatest(ctx, user.ID, domain.TokenPurposePasswordReset)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+	if latest != nil && time.Since(latest.CreatedAt) < s.resendInterval {
+		return nil
+	}
+	return s.sendResetLink(ctx, user)
+}
+
+// ForceReset clears the user's password, signs them out everywhere, revokes
+// their access tokens and emails a reset link. They can't log in with a
+// password until they reset it.
+func (s *PasswordService) ForceReset(ctx context.Context, actorID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "PasswordService.ForceReset")
+	defer span.End()
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.UpdatePassword(ctx, user.ID, "", time.Now()); err != nil {
+			return err
+		}
+		if err := s.refreshTokenRepo.DeleteByUserID(ctx, user.ID); err != nil {
+			return err
+		}
+		if err := s.accessTokenRepo.DeleteByUserID(ctx, user.ID); err != nil {
+			return err
+		}
+		return s.auditService.record(ctx, auditEntry{
+			actorID:    actorID,
+			entityType: domain.AuditEntityUser,
+			entityID:   user.ID,
+			action:     domain.AuditActionForcePasswordReset,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return s.sendResetLink(ctx, user)
+}
+
+func (s *PasswordService) sendResetLink(ctx context.Context, user *domain.User) error {
+	// Only the most recent link stays valid.
+	if err := s.userTokenRepo.DeleteByUserID(ctx, user.ID, domain.TokenPurposePasswordReset); err != nil {
+		return err
+	}
+
+	rawToken, err := generateRandomToken(32)
+	if err != nil {
+		return fmt.Errorf("generating reset token: %w", err)
+	}
+
+	now := time.Now()
+	token := &domain.UserToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		Purpose:   domain.TokenPurposePasswordReset,
+		TokenHash: hashToken(rawToken),
+		ExpiresAt: now.Add(s.resetExpiration),
+		CreatedAt: now,
+	}
+	if err := s.userTokenRepo.Create(ctx, token); err != nil {
+		return err
+	}
+
+	msg := mail.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Someone asked to reset the password for your account.\n\n"+
+				"Choose a new password here:\n%s/reset-password?token=%s\n\n"+
+				"The link expires in %s. If you didn't ask for this, you can ignore this email.\n",
+			s.appURL, rawToken, s.resetExpiration,
+		),
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		return fmt.Errorf("sending password reset: %w", err)
+	}
+	return nil
+}
+
+type ResetPasswordInput struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// Reset consumes a reset token, sets the new password, signs the user out of
+// every session and revokes their access tokens.
+func (s *PasswordService) Reset(ctx context.Context, input ResetPasswordInput) error {
+	ctx, span := tracing.Start(ctx, "PasswordService.Reset")
+	defer span.End()
+
+	if err := validatePassword(input.NewPassword); err != nil {
+		return err
+	}
+
+	invalid := fmt.Errorf("%w: reset link is invalid or has expired", domain.ErrValidation)
+
+	token, err := s.userTokenRepo.GetByTokenHash(ctx, domain.TokenPurposePasswordReset, hashToken(input.Token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return invalid
+		}
+		return err
+	}
+	now := time.Now()
+	if token.UsedAt != nil || now.After(token.ExpiresAt) {
+		return invalid
+	}
+	hash, err := hashPassword(input.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.userTokenRepo.MarkUsed(ctx, token.ID, now); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return invalid
+			}
+			return err
+		}
+		// Any other link that slipped out stops working too.
+		if err := s.userTokenRepo.DeleteByUserID(ctx, token.UserID, domain.TokenPurposePasswordReset); err != nil {
+			return err
+		}
+		if err := s.userRepo.UpdatePassword(ctx, token.UserID, hash, now); err != nil {
+			return err
+		}
+		if err := s.refreshTokenRepo.DeleteByUserID(ctx, token.UserID); err != nil {
+			return err
+		}
+		return s.accessTokenRepo.DeleteByUserID(ctx, token.UserID)
+	})
+	if err != nil {
+		return err
+	}
+	s.auditService.recordAccount(ctx, token.UserID, token.UserID, domain.AuditActionPasswordReset)
+	return nil
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/repository/memory"
+)
+
+type passwordFixture struct {
+	*authStack
+	svc        *PasswordService
+	tokens     *memory.PersonalAccessTokenRepo
+	userTokens *memory.UserTokenRepo
+	mailer     *mockMailer
+}
+
+func newPasswordFixture(t *testing.T) *passwordFixture {
+	t.Helper()
+	f := &passwordFixture{authStack: newAuthStack(t, testAuthConfig), mailer: &mockMailer{}}
+	f.tokens = memory.NewPersonalAccessTokenRepo(f.store)
+	f.userTokens = memory.NewUserTokenRepo(f.store)
+	f.svc = NewPasswordService(f.users, f.refresh, f.tokens, f.userTokens, f.tx, f.auth, f.audit, f.mailer, "http://app", time.Hour, time.Minute)
+	return f
+}
+
+// resetToken returns the token from the most recently mailed reset link.
+func (f *passwordFixture) resetToken(t *testing.T) string {
+	t.Helper()
+	if len(f.mailer.sent) == 0 {
+		t.Fatal("expected a reset email")
+	}
+	body := f.mailer.sent[len(f.mailer.sent)-1].Body
+	_, rest, ok := strings.Cut(body, "token=")
+	if !ok {
+		t.Fatalf("expected a reset link in %q", body)
+	}
+	token, _, _ := strings.Cut(rest, "\n")
+	return token
+}
+
+func TestPasswordService_ResetRevokesAccessTokens(t *testing.T) {
+	f := newPasswordFixture(t)
+	ctx := context.Background()
+	other := uuid.New()
+	f.tokens.Create(ctx, &domain.PersonalAccessToken{ID: uuid.New(), UserID: f.user.ID, TokenHash: "user", Scope: domain.TokenScopeWrite})
+	f.tokens.Create(ctx, &domain.PersonalAccessToken{ID: uuid.New(), UserID: other, TokenHash: "other", Scope: domain.TokenScopeWrite})
+
+	if err := f.svc.Forgot(ctx, f.user.Email); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.svc.Reset(ctx, ResetPasswordInput{Token: f.resetToken(t), NewPassword: "new-password"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.tokens.GetByTokenHash(ctx, "user"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected the user's access tokens to be revoked, got %v", err)
+	}
+	if _, err := f.tokens.GetByTokenHash(ctx, "other"); err != nil {
+		t.Errorf("expected other users' access tokens to survive, got %v", err)
+	}
+}
+
+func TestPasswordService_ChangeRejectsWrongCurrentPassword(t *testing.T) {
+	f := newPasswordFixture(t)
+	before := f.reload(t, f.user.ID).PasswordHash
+
+	err := f.svc.Change(context.Background(), f.user.ID, uuid.New(), ChangePasswordInput{CurrentPassword: "wrong-password", NewPassword: "new-password"})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if f.reload(t, f.user.ID).PasswordHash != before {
+		t.Error("expected the password to stay unchanged")
+	}
+}
+
+func TestPasswordService_ChangeLocksAfterRepeatedWrongPasswords(t *testing.T) {
+	f := newPasswordFixture(t)
+	ctx := context.Background()
+	wrong := ChangePasswordInput{CurrentPassword: "wrong-password", NewPassword: "new-password"}
+	for i := 0; i < 3; i++ {
+		if err := f.svc.Change(ctx, f.user.ID, uuid.New(), wrong); !errors.Is(err, domain.ErrValidation) {
+			t.Fatalf("attempt %d: expected a validation error, got %v", i+1, err)
+		}
+	}
+
+	right := ChangePasswordInput{CurrentPassword: "password123", NewPassword: "new-password"}
+	var rl *domain.RateLimitError
+	if err := f.svc.Change(ctx, f.user.ID, uuid.New(), right); !errors.Is(err, domain.ErrLoginLocked) || !errors.As(err, &rl) {
+		t.Fatalf("expected the change to be locked, got %v", err)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(f.reload(t, f.user.ID).PasswordHash), []byte("password123")); err != nil {
+		t.Error("expected the password to stay unchanged")
+	}
+}
+
+func TestPasswordService_ChangeSignsOutOtherSessions(t *testing.T) {
+	f := newPasswordFixture(t)
+	ctx := context.Background()
+	current, other := uuid.New(), uuid.New()
+	for _, family := range []uuid.UUID{current, other} {
+		f.refresh.Create(ctx, &domain.RefreshToken{ID: uuid.New(), FamilyID: family, UserID: f.user.ID, TokenHash: family.String(), ExpiresAt: time.Now().Add(time.Hour)})
+	}
+
+	must(t, f.svc.Change(ctx, f.user.ID, current, ChangePasswordInput{CurrentPassword: "password123", NewPassword: "new-password"}))
+
+	if _, err := f.refresh.GetByTokenHash(ctx, current.String()); err != nil {
+		t.Errorf("expected the current session to survive, got %v", err)
+	}
+	if _, err := f.refresh.GetByTokenHash(ctx, other.String()); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected other sessions to be signed out, got %v", err)
+	}
+}
+
+func TestPasswordService_ForgotRevealsNothing(t *testing.T) {
+	deactivatedAt := time.Now()
+	tests := []struct {
+		name  string
+		email string
+		setup func(t *testing.T, f *passwordFixture)
+	}{
+		{name: "unknown email", email: "nobody@example.com"},
+		{name: "inactive account", email: "ada@example.com", setup: func(t *testing.T, f *passwordFixture) {
+			must(t, f.users.SetDeactivated(context.Background(), f.user.ID, &deactivatedAt, deactivatedAt))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newPasswordFixture(t)
+			if tt.setup != nil {
+				tt.setup(t, f)
+			}
+			if err := f.svc.Forgot(context.Background(), tt.email); err != nil {
+				t.Fatalf("expected the same response as for an active account, got %v", err)
+			}
+			if len(f.mailer.sent) != 0 {
+				t.Errorf("expected no email, got %d", len(f.mailer.sent))
+			}
+			if _, err := f.userTokens.GetLatest(context.Background(), f.user.ID, domain.TokenPurposePasswordReset); !errors.Is(err, domain.ErrNotFound) {
+				t.Errorf("expected no reset token, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPasswordService_ForgotDropsRequestsInsideResendInterval(t *testing.T) {
+	f := newPasswordFixture(t)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if err := f.svc.Forgot(ctx, f.user.Email); err != nil {
+			t.Fatalf("request %d: expected no error, got %v", i+1, err)
+		}
+	}
+	if len(f.mailer.sent) != 1 {
+		t.Fatalf("expected one email inside the interval, got %d", len(f.mailer.sent))
+	}
+	first := f.resetToken(t)
+
+	// Dropping the interval stands in for waiting it out.
+	f.svc.resendInterval = 0
+	must(t, f.svc.Forgot(ctx, f.user.Email))
+	if len(f.mailer.sent) != 2 || f.resetToken(t) == first {
+		t.Errorf("expected a new link once the interval has passed, got %d emails", len(f.mailer.sent))
+	}
+}
+
+func TestPasswordService_ResetRejectsUnusableTokens(t *testing.T) {
+	tests := []struct {
+		name  string
+		token func(t *testing.T, f *passwordFixture) string
+	}{
+		{
+			name: "used",
+			token: func(t *testing.T, f *passwordFixture) string {
+				must(t, f.svc.Forgot(context.Background(), f.user.Email))
+				token := f.resetToken(t)
+				must(t, f.svc.Reset(context.Background(), ResetPasswordInput{Token: token, NewPassword: "first-password"}))
+				return token
+			},
+		},
+		{
+			name: "expired",
+			token: func(t *testing.T, f *passwordFixture) string {
+				f.userTokens.Create(context.Background(), &domain.UserToken{
+					ID: uuid.New(), UserID: f.user.ID, Purpose: domain.TokenPurposePasswordReset, TokenHash: hashToken("expired"),
+					ExpiresAt: time.Now().Add(-time.Minute), CreatedAt: time.Now().Add(-time.Hour),
+				})
+				return "expired"
+			},
+		},
+		{
+			name: "superseded",
+			token: func(t *testing.T, f *passwordFixture) string {
+				must(t, f.svc.Forgot(context.Background(), f.user.Email))
+				token := f.resetToken(t)
+				f.svc.resendInterval = 0
+				must(t, f.svc.Forgot(context.Background(), f.user.Email))
+				return token
+			},
+		},
+		{
+			name:  "unknown",
+			token: func(t *testing.T, f *passwordFixture) string { return "unknown" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newPasswordFixture(t)
+			token := tt.token(t, f)
+			before := f.reload(t, f.user.ID).PasswordHash
+
+			err := f.svc.Reset(context.Background(), ResetPasswordInput{Token: token, NewPassword: "new-password"})
+			if !errors.Is(err, domain.ErrValidation) {
+				t.Fatalf("expected a validation error, got %v", err)
+			}
+			if f.reload(t, f.user.ID).PasswordHash != before {
+				t.Error("expected the password to stay unchanged")
+			}
+		})
+	}
+}
+
+func TestPasswordService_ResetSignsOutAndClearsResetTokens(t *testing.T) {
+	f := newPasswordFixture(t)
+	ctx := context.Background()
+	f.refresh.Create(ctx, &domain.RefreshToken{ID: uuid.New(), UserID: f.user.ID, TokenHash: "session", ExpiresAt: time.Now().Add(time.Hour)})
+
+	must(t, f.svc.Forgot(ctx, f.user.Email))
+	token := f.resetToken(t)
+	// A second outstanding link, as if two requests had raced.
+	f.userTokens.Create(ctx, &domain.UserToken{
+		ID: uuid.New(), UserID: f.user.ID, Purpose: domain.TokenPurposePasswordReset, TokenHash: hashToken("racing"),
+		ExpiresAt: time.Now().Add(time.Hour), CreatedAt: time.Now(),
+	})
+
+	must(t, f.svc.Reset(ctx, ResetPasswordInput{Token: token, NewPassword: "new-password"}))
+
+	if err := bcrypt.CompareHashAndPassword([]byte(f.reload(t, f.user.ID).PasswordHash), []byte("new-password")); err != nil {
+		t.Errorf("expected the new password to be set, got %v", err)
+	}
+	if sessions, _ := f.refresh.ListByUser(ctx, f.user.ID); len(sessions) != 0 {
+		t.Errorf("expected every session to be signed out, got %d refresh tokens", len(sessions))
+	}
+	if _, err := f.userTokens.GetLatest(ctx, f.user.ID, domain.TokenPurposePasswordReset); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected every reset token to be cleared, got %v", err)
+	}
+	err := f.svc.Reset(ctx, ResetPasswordInput{Token: "racing", NewPassword: "other-password"})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("expected the other link to stop working, got %v", err)
+	}
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/authz"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/tracing"
+)
+
+type ProjectMemberService struct {
+	memberRepo    domain.ProjectMemberRepository
+	userRepo      domain.UserRepository
+	projectRepo   domain.ProjectRepository
+	orgMemberRepo domain.OrganizationMemberRepository
+	auditService  *AuditService
+	policy        authz.Policy
+}
+
+func NewProjectMemberService(
+	memberRepo domain.ProjectMemberRepository,
+	userRepo domain.UserRepository,
+	projectRepo domain.ProjectRepository,
+	orgMemberRepo domain.OrganizationMemberRepository,
+	auditService *AuditService,
+	policy authz.Policy,
+) *ProjectMemberService {
+	return &ProjectMemberService{
+		memberRepo:    memberRepo,
+		userRepo:      userRepo,
+		projectRepo:   projectRepo,
+		orgMemberRepo: orgMemberRepo,
+		auditService:  auditService,
+		policy:        policy,
+	}
+}
+
+type AddMemberInput struct {
+	Email string             `json:"email"`
+	Role  domain.ProjectRole `json:"role"`
+}
+
+// Add looks the user up by email among the members of the project's
+// organization; people outside it have to be invited instead.
+func (s *ProjectMemberService) Add(ctx context.Context, projectID, actorID uuid.UUID, input AddMemberInput) (*domain.ProjectMember, error) {
+	ctx, span := tracing.Start(ctx, "ProjectMemberService.Add")
+	defer span.End()
+
+	actor, err := s.authorizeMembers(ctx, projectID, actorID, authz.ActionManage)
+	if err != nil {
+		return nil, err
+	}
+	if input.Email == "" {
+		return nil, fmt.Errorf("%w: email is required", domain.ErrValidation)
+	}
+	role := input.Role
+	if role == "" {
+		role = domain.ProjectRoleMember
+	}
+	if err := checkAssignableRole(actor, role); err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.GetByEmail(ctx, input.Email)
+	if err == nil {
+		_, err = s.orgMemberRepo.Get(ctx, project.OrganizationID, user.ID)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%w: no member of this organization with that email", domain.ErrValidation)
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	member := &domain.ProjectMember{
+		ProjectID: projectID,
+		UserID:    user.ID,
+		Role:      role,
+		Email:     user.Email,
+		Name:      user.Name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	err = s.auditService.apply(ctx, auditEntry{
+		actorID:    actorID,
+		projectID:  &projectID,
+		entityType: domain.AuditEntityProjectMember,
+		entityID:   user.ID,
+		action:     domain.AuditActionCreate,
+		after:      member,
+	}, func(ctx context.Context) error {
+		return s.memberRepo.Create(ctx, member)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+func (s *ProjectMemberService) List(ctx context.Context, projectID, actorID uuid.UUID) ([]*domain.ProjectMember, error) {
+	ctx, span := tracing.Start(ctx, "ProjectMemberService.List")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(actorID), authz.ActionRead, authz.Project(projectID)); err != nil {
+		return nil, err
+	}
+	return s.memberRepo.ListByProject(ctx, projectID)
+}
+
+type UpdateMemberInput struct {
+	Role domain.ProjectRole `json:"role"`
+}
+
+func (s *ProjectMemberService) UpdateRole(ctx context.Context, projectID, actorID, userID uuid.UUID, input UpdateMemberInput) (*domain.ProjectMember, error) {
+	ctx, span := tracing.Start(ctx, "ProjectMemberService.UpdateRole")
+	defer span.End()
+
+	actor, err := s.authorizeMembers(ctx, projectID, actorID, authz.ActionManage)
+	if err != nil {
+		return nil, err
+	}
+	member, err := s.memberRepo.Get(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAssignableRole(actor, input.Role); err != nil {
+		return nil, err
+	}
+	if member.Role == domain.ProjectRoleOwner && actor.Role != domain.ProjectRoleOwner {
+		return nil, domain.ErrForbidden
+	}
+	if member.Role == domain.ProjectRoleOwner && input.Role != domain.ProjectRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, projectID); err != nil {
+			return nil, err
+		}
+	}
+
+	before := *member
+	member.Role = input.Role
+	member.UpdatedAt = time.Now()
+
+	err = s.auditService.apply(ctx, auditEntry{
+		actorID:    actorID,
+		projectID:  &projectID,
+		entityType: domain.AuditEntityProjectMember,
+		entityID:   userID,
+		action:     domain.AuditActionUpdate,
+		before:     &before,
+		after:      member,
+	}, func(ctx context.Context) error {
+		return s.memberRepo.Update(ctx, member)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// Remove deletes a membership. Admins can remove others; any member can
+// remove themselves, except the last remaining owner.
+func (s *ProjectMemberService) Remove(ctx context.Context, projectID, actorID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "ProjectMemberService.Remove")
+	defer span.End()
+
+	action := authz.ActionManage
+	if actorID == userID {
+		action = authz.ActionRead
+	}
+	actor, err := s.authorizeMembers(ctx, projectID, actorID, action)
+	if err != nil {
+		return err
+	}
+	member, err := s.memberRepo.Get(ctx, projectID, userID)
+	if err != nil {
+		return err
+	}
+	if member.Role == domain.ProjectRoleOwner {
+		if actor.Role != domain.ProjectRoleOwner {
+			return domain.ErrForbidden
+		}
+		if err := s.ensureAnotherOwner(ctx, projectID); err != nil {
+			return err
+		}
+	}
+	return s.auditService.apply(ctx, auditEntry{
+		actorID:    actorID,
+		projectID:  &projectID,
+		entityType: domain.AuditEntityProjectMember,
+		entityID:   userID,
+		action:     domain.AuditActionDelete,
+		before:     member,
+	}, func(ctx context.Context) error {
+		return s.memberRepo.Delete(ctx, projectID, userID)
+	})
+}
+
+// authorizeMembers checks the policy and returns the actor's own membership,
+// which the owner-only rules below need.
+func (s *ProjectMemberService) authorizeMembers(ctx context.Context, projectID, actorID uuid.UUID, action authz.Action) (*domain.ProjectMember, error) {
+	if err := s.policy.Authorize(ctx, authz.User(actorID), action, authz.Project(projectID)); err != nil {
+		return nil, err
+	}
+	return s.memberRepo.Get(ctx, projectID, actorID)
+}
+
+func (s *ProjectMemberService) ensureAnotherOwner(ctx context.Context, projectID uuid.UUID) error {
+	owners, err := s.memberRepo.CountByRole(ctx, projectID, domain.ProjectRoleOwner)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return fmt.Errorf("%w: a project must keep at least one owner", domain.ErrValidation)
+	}
+	return nil
+}
+
+// checkAssignableRole validates role and makes sure only owners can hand out
+// ownership.
+func checkAssignableRole(actor *domain.ProjectMember, role domain.ProjectRole) error {
+	if !role.Valid() {
+		return fmt.Errorf("%w: role must be owner, admin, member, or viewer", domain.ErrValidation)
+	}
+	if role == domain.ProjectRoleOwner && actor.Role != domain.ProjectRoleOwner {
+		return domain.ErrForbidden
+	}
+	return nil
+}
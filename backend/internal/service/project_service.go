@@ -7,24 +7,42 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/letyshub/project-management/internal/authz"
 	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/metrics"
+	"github.com/letyshub/project-management/internal/tracing"
 )
 
 type ProjectService struct {
-	projectRepo domain.ProjectRepository
-	boardRepo   domain.BoardRepository
-	columnRepo  domain.ColumnRepository
+	projectRepo  domain.ProjectRepository
+	memberRepo   domain.ProjectMemberRepository
+	boardRepo    domain.BoardRepository
+	columnRepo   domain.ColumnRepository
+	orgService   *OrganizationService
+	auditService *AuditService
+	txManager    domain.TxManager
+	policy       authz.Policy
 }
 
 func NewProjectService(
 	projectRepo domain.ProjectRepository,
+	memberRepo domain.ProjectMemberRepository,
 	boardRepo domain.BoardRepository,
 	columnRepo domain.ColumnRepository,
+	orgService *OrganizationService,
+	auditService *AuditService,
+	txManager domain.TxManager,
+	policy authz.Policy,
 ) *ProjectService {
 	return &ProjectService{
-		projectRepo: projectRepo,
-		boardRepo:   boardRepo,
-		columnRepo:  columnRepo,
+		projectRepo:  projectRepo,
+		memberRepo:   memberRepo,
+		boardRepo:    boardRepo,
+		columnRepo:   columnRepo,
+		orgService:   orgService,
+		auditService: auditService,
+		txManager:    txManager,
+		policy:       policy,
 	}
 }
 
@@ -33,26 +51,69 @@ type CreateProjectInput struct {
 	Description string `json:"description"`
 }
 
+// Create adds a project to the active organization, or to the owner's
+// personal workspace when none was chosen.
 func (s *ProjectService) Create(ctx context.Context, ownerID uuid.UUID, input CreateProjectInput) (*domain.Project, error) {
+	ctx, span := tracing.Start(ctx, "ProjectService.Create")
+	defer span.End()
+
+	if _, scoped := authz.ProjectScope(ctx); scoped {
+		return nil, domain.ErrForbidden
+	}
 	if input.Name == "" {
 		return nil, fmt.Errorf("%w: name is required", domain.ErrValidation)
 	}
+	org, err := s.orgService.Active(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
 
 	now := time.Now()
 	project := &domain.Project{
-		ID:          uuid.New(),
-		Name:        input.Name,
-		Description: input.Description,
-		OwnerID:     ownerID,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:             uuid.New(),
+		OrganizationID: org.ID,
+		Name:           input.Name,
+		Description:    input.Description,
+		OwnerID:        ownerID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
 	}
 
-	if err := s.projectRepo.Create(ctx, project); err != nil {
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.createWithDefaults(ctx, project, now); err != nil {
+			return err
+		}
+		return s.auditService.record(ctx, auditEntry{
+			actorID: ownerID, projectID: &project.ID,
+			entityType: domain.AuditEntityProject, entityID: project.ID, action: domain.AuditActionCreate,
+			after: project,
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
+	metrics.ProjectsCreated.Inc()
+	return project, nil
+}
+
+// createWithDefaults stores the project with its owner membership and a
+// default board with standard columns.
+func (s *ProjectService) createWithDefaults(ctx context.Context, project *domain.Project, now time.Time) error {
+	if err := s.projectRepo.Create(ctx, project); err != nil {
+		return err
+	}
+
+	owner := &domain.ProjectMember{
+		ProjectID: project.ID,
+		UserID:    project.OwnerID,
+		Role:      domain.ProjectRoleOwner,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.memberRepo.Create(ctx, owner); err != nil {
+		return err
+	}
 
-	// Create a default board with standard columns
 	board := &domain.Board{
 		ID:        uuid.New(),
 		ProjectID: project.ID,
@@ -61,48 +122,91 @@ func (s *ProjectService) Create(ctx context.Context, ownerID uuid.UUID, input Cr
 		UpdatedAt: now,
 	}
 	if err := s.boardRepo.Create(ctx, board); err != nil {
-		return nil, err
+		return err
 	}
 
-	defaults := []string{"To Do", "In Progress", "Done"}
-	for i, name := range defaults {
+	defaults := []struct{ name, category string }{
+		{"To Do", domain.ColumnCategoryBacklog},
+		{"In Progress", domain.ColumnCategoryInProgress},
+		{"Done", domain.ColumnCategoryDone},
+	}
+	for i, d := range defaults {
 		col := &domain.Column{
 			ID:        uuid.New(),
 			BoardID:   board.ID,
-			Name:      name,
+			Name:      d.name,
 			Position:  float64((i + 1) * 1000),
+			Category:  d.category,
 			CreatedAt: now,
 			UpdatedAt: now,
 		}
 		if err := s.columnRepo.Create(ctx, col); err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	return project, nil
+	return nil
 }
 
-func (s *ProjectService) GetByID(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
+func (s *ProjectService) GetByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Project, error) {
+	ctx, span := tracing.Start(ctx, "ProjectService.GetByID")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionRead, authz.Project(id)); err != nil {
+		return nil, err
+	}
 	return s.projectRepo.GetByID(ctx, id)
 }
 
 func (s *ProjectService) ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*domain.Project, error) {
+	ctx, span := tracing.Start(ctx, "ProjectService.ListByOwner")
+	defer span.End()
+
 	return s.projectRepo.ListByOwner(ctx, ownerID)
 }
 
+// ListForUser returns every project the user is a member of, including the
+// ones they own, narrowed to the context's project scope and active
+// organization if there are any.
+func (s *ProjectService) ListForUser(ctx context.Context, userID uuid.UUID) ([]*domain.Project, error) {
+	ctx, span := tracing.Start(ctx, "ProjectService.ListForUser")
+	defer span.End()
+
+	projects, err := s.projectRepo.ListByMember(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	_, scoped := authz.ProjectScope(ctx)
+	_, inOrg := authz.ActiveOrganization(ctx)
+	if !scoped && !inOrg {
+		return projects, nil
+	}
+	var inScope []*domain.Project
+	for _, p := range projects {
+		if authz.InScope(ctx, p.ID) && authz.InOrganization(ctx, p.OrganizationID) {
+			inScope = append(inScope, p)
+		}
+	}
+	return inScope, nil
+}
+
 type UpdateProjectInput struct {
 	Name        *string `json:"name"`
 	Description *string `json:"description"`
 }
 
-func (s *ProjectService) Update(ctx context.Context, id uuid.UUID, ownerID uuid.UUID, input UpdateProjectInput) (*domain.Project, error) {
+func (s *ProjectService) Update(ctx context.Context, id uuid.UUID, userID uuid.UUID, input UpdateProjectInput) (*domain.Project, error) {
+	ctx, span := tracing.Start(ctx, "ProjectService.Update")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionManage, authz.Project(id)); err != nil {
+		return nil, err
+	}
 	project, err := s.projectRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	if project.OwnerID != ownerID {
-		return nil, domain.ErrForbidden
-	}
+	before := *project
 
 	if input.Name != nil {
 		if *input.Name == "" {
@@ -115,19 +219,35 @@ func (s *ProjectService) Update(ctx context.Context, id uuid.UUID, ownerID uuid.
 	}
 	project.UpdatedAt = time.Now()
 
-	if err := s.projectRepo.Update(ctx, project); err != nil {
+	err = s.auditService.apply(ctx, auditEntry{
+		actorID: userID, projectID: &project.ID,
+		entityType: domain.AuditEntityProject, entityID: project.ID, action: domain.AuditActionUpdate,
+		before: before, after: project,
+	}, func(ctx context.Context) error {
+		return s.projectRepo.Update(ctx, project)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return project, nil
 }
 
-func (s *ProjectService) Delete(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+func (s *ProjectService) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "ProjectService.Delete")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionDelete, authz.Project(id)); err != nil {
+		return err
+	}
 	project, err := s.projectRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	if project.OwnerID != ownerID {
-		return domain.ErrForbidden
-	}
-	return s.projectRepo.Delete(ctx, id)
+	return s.auditService.apply(ctx, auditEntry{
+		actorID: userID, projectID: &id,
+		entityType: domain.AuditEntityProject, entityID: id, action: domain.AuditActionDelete,
+		before: project,
+	}, func(ctx context.Context) error {
+		return s.projectRepo.Delete(ctx, id)
+	})
 }
@@ -2,87 +2,102 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/letyshub/project-management/internal/authz"
 	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/repository/memory"
 )
 
-// Mock repositories
+// Test helpers
 
-type mockProjectRepo struct {
-	createFn    func(ctx context.Context, project *domain.Project) error
-	getByIDFn   func(ctx context.Context, id uuid.UUID) (*domain.Project, error)
-	listByOwner func(ctx context.Context, ownerID uuid.UUID) ([]*domain.Project, error)
-	updateFn    func(ctx context.Context, project *domain.Project) error
-	deleteFn    func(ctx context.Context, id uuid.UUID) error
+type mockPolicy struct {
+	authorizeFn func(ctx context.Context, subject authz.Subject, action authz.Action, resource authz.Resource) error
 }
 
-func (m *mockProjectRepo) Create(ctx context.Context, project *domain.Project) error {
-	if m.createFn != nil {
-		return m.createFn(ctx, project)
+func (m *mockPolicy) Authorize(ctx context.Context, subject authz.Subject, action authz.Action, resource authz.Resource) error {
+	if m.authorizeFn != nil {
+		return m.authorizeFn(ctx, subject, action, resource)
 	}
 	return nil
 }
 
-func (m *mockProjectRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
-	if m.getByIDFn != nil {
-		return m.getByIDFn(ctx, id)
-	}
-	return nil, domain.ErrNotFound
+func (m *mockPolicy) ProjectOf(ctx context.Context, resource authz.Resource) (uuid.UUID, error) {
+	return uuid.Nil, domain.ErrNotFound
 }
 
-func (m *mockProjectRepo) ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*domain.Project, error) {
-	if m.listByOwner != nil {
-		return m.listByOwner(ctx, ownerID)
+// ownerOnly returns a policy that only lets ownerID through.
+func ownerOnly(ownerID uuid.UUID) *mockPolicy {
+	return &mockPolicy{
+		authorizeFn: func(ctx context.Context, subject authz.Subject, action authz.Action, resource authz.Resource) error {
+			if subject.UserID != ownerID {
+				return domain.ErrForbidden
+			}
+			return nil
+		},
 	}
-	return nil, nil
 }
 
-func (m *mockProjectRepo) Update(ctx context.Context, project *domain.Project) error {
-	if m.updateFn != nil {
-		return m.updateFn(ctx, project)
-	}
-	return nil
+// projectFixture is a ProjectService over an in-memory store, with the
+// organization service and audit log on the same store.
+type projectFixture struct {
+	store    *memory.Store
+	tx       *memory.TxManager
+	projects *memory.ProjectRepo
+	members  *memory.ProjectMemberRepo
+	boards   *memory.BoardRepo
+	columns  *memory.ColumnRepo
+	orgs     *OrganizationService
+	audit    *AuditService
+	svc      *ProjectService
 }
 
-func (m *mockProjectRepo) Delete(ctx context.Context, id uuid.UUID) error {
-	if m.deleteFn != nil {
-		return m.deleteFn(ctx, id)
+func newProjectFixture(policy authz.Policy) *projectFixture {
+	store := memory.NewStore()
+	f := &projectFixture{
+		store:    store,
+		tx:       memory.NewTxManager(store),
+		projects: memory.NewProjectRepo(store),
+		members:  memory.NewProjectMemberRepo(store),
+		boards:   memory.NewBoardRepo(store),
+		columns:  memory.NewColumnRepo(store),
+		audit:    newTestAuditService(store),
 	}
-	return nil
+	f.orgs, _, _ = newTestOrgService(store)
+	f.svc = NewProjectService(f.projects, f.members, f.boards, f.columns, f.orgs, f.audit, f.tx, policy)
+	return f
 }
 
-type mockBoardRepo struct{}
-
-func (m *mockBoardRepo) Create(ctx context.Context, board *domain.Board) error { return nil }
-func (m *mockBoardRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Board, error) {
-	return nil, domain.ErrNotFound
-}
-func (m *mockBoardRepo) ListByProject(ctx context.Context, projectID uuid.UUID) ([]*domain.Board, error) {
-	return nil, nil
+// addProject stores a project and its owner's membership directly, without
+// going through the service or the audit log.
+func (f *projectFixture) addProject(t *testing.T, orgID, ownerID uuid.UUID, name string) *domain.Project {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now()
+	project := &domain.Project{ID: uuid.New(), OrganizationID: orgID, Name: name, OwnerID: ownerID, CreatedAt: now, UpdatedAt: now}
+	must(t, f.projects.Create(ctx, project))
+	must(t, f.members.Create(ctx, &domain.ProjectMember{ProjectID: project.ID, UserID: ownerID, Role: domain.ProjectRoleOwner, CreatedAt: now, UpdatedAt: now}))
+	return project
 }
-func (m *mockBoardRepo) Update(ctx context.Context, board *domain.Board) error { return nil }
-func (m *mockBoardRepo) Delete(ctx context.Context, id uuid.UUID) error        { return nil }
-
-type mockColumnRepo struct{}
 
-func (m *mockColumnRepo) Create(ctx context.Context, col *domain.Column) error { return nil }
-func (m *mockColumnRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Column, error) {
-	return nil, domain.ErrNotFound
+// failingColumns fails every column insert.
+type failingColumns struct {
+	*memory.ColumnRepo
 }
-func (m *mockColumnRepo) ListByBoard(ctx context.Context, boardID uuid.UUID) ([]*domain.Column, error) {
-	return nil, nil
+
+func (r failingColumns) Create(ctx context.Context, col *domain.Column) error {
+	return errors.New("insert failed")
 }
-func (m *mockColumnRepo) Update(ctx context.Context, col *domain.Column) error { return nil }
-func (m *mockColumnRepo) Delete(ctx context.Context, id uuid.UUID) error       { return nil }
 
 // Tests
 
 func TestProjectService_Create_Success(t *testing.T) {
-	svc := NewProjectService(&mockProjectRepo{}, &mockBoardRepo{}, &mockColumnRepo{})
+	f := newProjectFixture(&mockPolicy{})
 
-	project, err := svc.Create(context.Background(), uuid.New(), CreateProjectInput{
+	project, err := f.svc.Create(context.Background(), uuid.New(), CreateProjectInput{
 		Name:        "Test Project",
 		Description: "A test project",
 	})
@@ -102,9 +117,9 @@ func TestProjectService_Create_Success(t *testing.T) {
 }
 
 func TestProjectService_Create_EmptyName(t *testing.T) {
-	svc := NewProjectService(&mockProjectRepo{}, &mockBoardRepo{}, &mockColumnRepo{})
+	f := newProjectFixture(&mockPolicy{})
 
-	_, err := svc.Create(context.Background(), uuid.New(), CreateProjectInput{
+	_, err := f.svc.Create(context.Background(), uuid.New(), CreateProjectInput{
 		Name: "",
 	})
 
@@ -116,21 +131,11 @@ func TestProjectService_Create_EmptyName(t *testing.T) {
 func TestProjectService_Delete_Forbidden(t *testing.T) {
 	ownerID := uuid.New()
 	otherUserID := uuid.New()
-	projectID := uuid.New()
-
-	repo := &mockProjectRepo{
-		getByIDFn: func(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
-			return &domain.Project{
-				ID:      projectID,
-				OwnerID: ownerID,
-				Name:    "Test",
-			}, nil
-		},
-	}
 
-	svc := NewProjectService(repo, &mockBoardRepo{}, &mockColumnRepo{})
+	f := newProjectFixture(ownerOnly(ownerID))
+	project := f.addProject(t, uuid.New(), ownerID, "Test")
 
-	err := svc.Delete(context.Background(), projectID, otherUserID)
+	err := f.svc.Delete(context.Background(), project.ID, otherUserID)
 	if err != domain.ErrForbidden {
 		t.Errorf("expected ErrForbidden, got %v", err)
 	}
@@ -138,30 +143,29 @@ func TestProjectService_Delete_Forbidden(t *testing.T) {
 
 func TestProjectService_Delete_Success(t *testing.T) {
 	ownerID := uuid.New()
-	projectID := uuid.New()
-	deleted := false
-
-	repo := &mockProjectRepo{
-		getByIDFn: func(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
-			return &domain.Project{
-				ID:      projectID,
-				OwnerID: ownerID,
-				Name:    "Test",
-			}, nil
-		},
-		deleteFn: func(ctx context.Context, id uuid.UUID) error {
-			deleted = true
-			return nil
-		},
-	}
 
-	svc := NewProjectService(repo, &mockBoardRepo{}, &mockColumnRepo{})
+	f := newProjectFixture(ownerOnly(ownerID))
+	project := f.addProject(t, uuid.New(), ownerID, "Test")
 
-	err := svc.Delete(context.Background(), projectID, ownerID)
+	err := f.svc.Delete(context.Background(), project.ID, ownerID)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if !deleted {
-		t.Error("expected delete to be called")
+	if _, err := f.projects.GetByID(context.Background(), project.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected the project to be deleted, got %v", err)
+	}
+}
+
+func TestProjectService_Create_RollsBackOnFailure(t *testing.T) {
+	f := newProjectFixture(&mockPolicy{})
+	svc := NewProjectService(f.projects, f.members, f.boards, failingColumns{f.columns}, f.orgs, f.audit, f.tx, &mockPolicy{})
+	ownerID := uuid.New()
+
+	_, err := svc.Create(context.Background(), ownerID, CreateProjectInput{Name: "Roadmap"})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if projects, _ := f.projects.ListByOwner(context.Background(), ownerID); len(projects) != 0 {
+		t.Errorf("expected the project, board and columns to be rolled back together, got %d projects", len(projects))
 	}
 }
@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/authz"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/repository/memory"
+)
+
+// routeFixture is a project with a member of every role, wired to the real
+// services, policy and in-memory repositories, so each route's service call
+// runs the same authorization it does in production.
+type routeFixture struct {
+	users    map[domain.ProjectRole]uuid.UUID
+	outsider uuid.UUID
+	// target is a viewer that membership routes act on; newcomer belongs to
+	// the organization but not the project.
+	target   uuid.UUID
+	newcomer *domain.User
+
+	project, board, column, emptyColumn, task uuid.UUID
+	label, spareLabel, comment, invitation    uuid.UUID
+
+	projects    *ProjectService
+	members     *ProjectMemberService
+	invitations *InvitationService
+	boards      *BoardService
+	tasks       *TaskService
+	comments    *CommentService
+	labels      *LabelService
+	audit       *AuditService
+	activity    *TaskActivityService
+	flow        *FlowAnalyticsService
+}
+
+func newRouteFixture(t *testing.T) *routeFixture {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.NewStore()
+	users := memory.NewUserRepo(store)
+	orgs := memory.NewOrganizationRepo(store)
+	orgMembers := memory.NewOrganizationMemberRepo(store)
+	projects := memory.NewProjectRepo(store)
+	members := memory.NewProjectMemberRepo(store)
+	boards := memory.NewBoardRepo(store)
+	columns := memory.NewColumnRepo(store)
+	tasks := memory.NewTaskRepo(store)
+	comments := memory.NewCommentRepo(store)
+	labels := memory.NewLabelRepo(store)
+	invitations := memory.NewInvitationRepo(store)
+	tx := memory.NewTxManager(store)
+
+	policy := authz.NewProjectPolicy(projects, members, boards, columns, tasks, labels, comments)
+	audit := NewAuditService(memory.NewAuditEventRepo(store), tx, policy)
+	orgService := NewOrganizationService(orgs, orgMembers, users, projects, members, audit, tx)
+	f := &routeFixture{
+		users:       map[domain.ProjectRole]uuid.UUID{},
+		projects:    NewProjectService(projects, members, boards, columns, orgService, audit, tx, policy),
+		members:     NewProjectMemberService(members, users, projects, orgMembers, audit, policy),
+		invitations: NewInvitationService(invitations, members, projects, orgMembers, users, tx, nil, audit, policy, &mockMailer{}, "http://app", time.Hour),
+		boards:      NewBoardService(boards, columns, audit, policy),
+		tasks:       NewTaskService(tasks, memory.NewTaskTransitionRepo(store), audit, policy),
+		comments:    NewCommentService(comments, audit, policy),
+		labels:      NewLabelService(labels, audit, policy),
+		audit:       audit,
+		activity:    NewTaskActivityService(memory.NewAuditEventRepo(store), comments, policy),
+		flow:        NewFlowAnalyticsService(tasks, columns, memory.NewTaskTransitionRepo(store), policy),
+	}
+
+	now := time.Now()
+	org := &domain.Organization{ID: uuid.New(), Name: "Acme", CreatedAt: now, UpdatedAt: now}
+	must(t, orgs.Create(ctx, org))
+	newUser := func(name string) *domain.User {
+		u := &domain.User{ID: uuid.New(), Email: name + "@example.com", Name: name, Role: domain.UserRoleMember, CreatedAt: now, UpdatedAt: now}
+		must(t, users.Create(ctx, u))
+		must(t, orgMembers.Create(ctx, &domain.OrganizationMember{OrganizationID: org.ID, UserID: u.ID, Role: domain.OrgRoleMember, CreatedAt: now, UpdatedAt: now}))
+		return u
+	}
+	for _, role := range []domain.ProjectRole{domain.ProjectRoleOwner, domain.ProjectRoleAdmin, domain.ProjectRoleMember, domain.ProjectRoleViewer} {
+		f.users[role] = newUser(string(role)).ID
+	}
+	f.outsider = newUser("outsider").ID
+	f.target = newUser("target").ID
+	f.newcomer = newUser("newcomer")
+
+	project := &domain.Project{ID: uuid.New(), OrganizationID: org.ID, Name: "Roadmap", OwnerID: f.users[domain.ProjectRoleOwner], CreatedAt: now, UpdatedAt: now}
+	must(t, projects.Create(ctx, project))
+	f.project = project.ID
+	addMember := func(userID uuid.UUID, role domain.ProjectRole) {
+		must(t, members.Create(ctx, &domain.ProjectMember{ProjectID: f.project, UserID: userID, Role: role, CreatedAt: now, UpdatedAt: now}))
+	}
+	for role, id := range f.users {
+		addMember(id, role)
+	}
+	addMember(f.target, domain.ProjectRoleViewer)
+
+	board := &domain.Board{ID: uuid.New(), ProjectID: f.project, Name: "Main", CreatedAt: now, UpdatedAt: now}
+	must(t, boards.Create(ctx, board))
+	f.board = board.ID
+	for i, id := range []*uuid.UUID{&f.column, &f.emptyColumn} {
+		*id = uuid.New()
+		must(t, columns.Create(ctx, &domain.Column{ID: *id, BoardID: f.board, Name: "Column", Position: float64(i), CreatedAt: now, UpdatedAt: now}))
+	}
+	task := &domain.Task{ID: uuid.New(), ColumnID: f.column, Title: "Ship it", Priority: "medium", CreatedAt: now, UpdatedAt: now}
+	must(t, tasks.Create(ctx, task))
+	f.task = task.ID
+	for _, id := range []*uuid.UUID{&f.label, &f.spareLabel} {
+		*id = uuid.New()
+		must(t, labels.Create(ctx, &domain.Label{ID: *id, ProjectID: f.project, Name: "bug", Color: "#ff0000", CreatedAt: now}))
+	}
+	must(t, labels.AddToTask(ctx, f.task, f.label))
+	comment := &domain.Comment{ID: uuid.New(), TaskID: f.task, AuthorID: f.users[domain.ProjectRoleMember], Content: "LGTM", CreatedAt: now, UpdatedAt: now}
+	must(t, comments.Create(ctx, comment))
+	f.comment = comment.ID
+	inv := &domain.Invitation{ID: uuid.New(), ProjectID: f.project, Email: "invitee@example.com", Role: domain.ProjectRoleMember, TokenHash: hashToken("invite"), InvitedBy: f.users[domain.ProjectRoleOwner], ExpiresAt: now.Add(time.Hour), CreatedAt: now}
+	must(t, invitations.Create(ctx, inv))
+	f.invitation = inv.ID
+	return f
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// atLeast lists the roles that rank at or above min.
+func atLeast(min domain.ProjectRole) []domain.ProjectRole {
+	var roles []domain.ProjectRole
+	for _, role := range []domain.ProjectRole{domain.ProjectRoleOwner, domain.ProjectRoleAdmin, domain.ProjectRoleMember, domain.ProjectRoleViewer} {
+		if role.AtLeast(min) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// TestRoutes_RoleMatrix calls the service method behind every project-scoped
+// route registered in cmd/api/main.go as each project role and as a user
+// outside the project. Allowed roles must succeed and everyone else must get
+// ErrForbidden. Each call gets a fresh fixture, since many of them change or
+// delete what the next would need.
+func TestRoutes_RoleMatrix(t *testing.T) {
+	type call func(ctx context.Context, f *routeFixture, userID uuid.UUID) error
+
+	name, content := "Renamed", "Edited"
+	routes := []struct {
+		route   string
+		allowed []domain.ProjectRole
+		call    call
+	}{
+		{"GET /projects/{projectID}", atLeast(domain.ProjectRoleViewer), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.projects.GetByID(ctx, f.project, userID)
+			return err
+		}},
+		{"PATCH /projects/{projectID}", atLeast(domain.ProjectRoleAdmin), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.projects.Update(ctx, f.project, userID, UpdateProjectInput{Name: &name})
+			return err
+		}},
+		{"DELETE /projects/{projectID}", atLeast(domain.ProjectRoleOwner), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			return f.projects.Delete(ctx, f.project, userID)
+		}},
+
+		{"POST /projects/{projectID}/members", atLeast(domain.ProjectRoleAdmin), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.members.Add(ctx, f.project, userID, AddMemberInput{Email: f.newcomer.Email})
+			return err
+		}},
+		{"GET /projects/{projectID}/members", atLeast(domain.ProjectRoleViewer), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.members.List(ctx, f.project, userID)
+			return err
+		}},
+		{"PATCH /projects/{projectID}/members/{userID}", atLeast(domain.ProjectRoleAdmin), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.members.UpdateRole(ctx, f.project, userID, f.target, UpdateMemberInput{Role: domain.ProjectRoleMember})
+			return err
+		}},
+		{"DELETE /projects/{projectID}/members/{userID}", atLeast(domain.ProjectRoleAdmin), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			return f.members.Remove(ctx, f.project, userID, f.target)
+		}},
+
+		{"POST /projects/{projectID}/invitations", atLeast(domain.ProjectRoleAdmin), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.invitations.Create(ctx, f.project, userID, CreateInvitationInput{Email: "new@example.com"})
+			return err
+		}},
+		{"GET /projects/{projectID}/invitations", atLeast(domain.ProjectRoleAdmin), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.invitations.ListPending(ctx, f.project, userID)
+			return err
+		}},
+		{"DELETE /projects/{projectID}/invitations/{invitationID}", atLeast(domain.ProjectRoleAdmin), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			return f.invitations.Revoke(ctx, f.project, f.invitation, userID)
+		}},
+
+		{"POST /projects/{projectID}/boards", atLeast(domain.ProjectRoleAdmin), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.boards.Create(ctx, f.project, userID, CreateBoardInput{Name: "Next"})
+			return err
+		}},
+		{"GET /projects/{projectID}/boards", atLeast(domain.ProjectRoleViewer), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.boards.ListByProject(ctx, f.project, userID)
+			return err
+		}},
+		{"GET /boards/{boardID}", atLeast(domain.ProjectRoleViewer), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.boards.GetByID(ctx, f.board, userID)
+			return err
+		}},
+		{"PATCH /boards/{boardID}", atLeast(domain.ProjectRoleAdmin), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.boards.Update(ctx, f.board, userID, UpdateBoardInput{Name: &name})
+			return err
+		}},
+		{"DELETE /boards/{boardID}", atLeast(domain.ProjectRoleAdmin), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			return f.boards.Delete(ctx, f.board, userID)
+		}},
+
+		{"POST /boards/{boardID}/columns", atLeast(domain.ProjectRoleAdmin), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.boards.CreateColumn(ctx, f.board, userID, CreateColumnInput{Name: "Review"})
+			return err
+		}},
+		{"GET /boards/{boardID}/columns", atLeast(domain.ProjectRoleViewer), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.boards.ListColumns(ctx, f.board, userID)
+			return err
+		}},
+		{"PATCH /columns/{columnID}", atLeast(domain.ProjectRoleAdmin), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.boards.UpdateColumn(ctx, f.column, userID, UpdateColumnInput{Name: &name})
+			return err
+		}},
+		{"DELETE /columns/{columnID}", atLeast(domain.ProjectRoleAdmin), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			return f.boards.DeleteColumn(ctx, f.emptyColumn, userID)
+		}},
+
+		{"POST /columns/{columnID}/tasks", atLeast(domain.ProjectRoleMember), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.tasks.Create(ctx, f.column, userID, CreateTaskInput{Title: "Another"})
+			return err
+		}},
+		{"GET /boards/{boardID}/tasks", atLeast(domain.ProjectRoleViewer), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.tasks.ListByBoard(ctx, f.board, userID, domain.TaskFilter{})
+			return err
+		}},
+		{"GET /tasks/{taskID}", atLeast(domain.ProjectRoleViewer), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.tasks.GetByID(ctx, f.task, userID)
+			return err
+		}},
+		{"PATCH /tasks/{taskID}", atLeast(domain.ProjectRoleMember), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.tasks.Update(ctx, f.task, userID, UpdateTaskInput{Title: &name})
+			return err
+		}},
+		{"PUT /tasks/{taskID}/move", atLeast(domain.ProjectRoleMember), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.tasks.Move(ctx, f.task, userID, MoveTaskInput{ColumnID: f.emptyColumn})
+			return err
+		}},
+		{"DELETE /tasks/{taskID}", atLeast(domain.ProjectRoleMember), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			return f.tasks.Delete(ctx, f.task, userID)
+		}},
+		{"GET /tasks/{taskID}/activity", atLeast(domain.ProjectRoleViewer), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.activity.Timeline(ctx, f.task, userID)
+			return err
+		}},
+		{"GET /boards/{boardID}/tasks/export", atLeast(domain.ProjectRoleViewer), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			if _, err := f.tasks.ListByBoard(ctx, f.board, userID, domain.TaskFilter{}); err != nil {
+				return err
+			}
+			_, err := f.boards.ListColumns(ctx, f.board, userID)
+			return err
+		}},
+		{"GET /boards/{boardID}/analytics/flow", atLeast(domain.ProjectRoleViewer), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.flow.Report(ctx, f.board, userID, domain.FlowFilter{})
+			return err
+		}},
+
+		{"POST /tasks/{taskID}/comments", atLeast(domain.ProjectRoleMember), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.comments.Create(ctx, f.task, userID, CreateCommentInput{Content: "+1"})
+			return err
+		}},
+		{"GET /tasks/{taskID}/comments", atLeast(domain.ProjectRoleViewer), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.comments.ListByTask(ctx, f.task, userID)
+			return err
+		}},
+		// Only the author, a member, may edit a comment.
+		{"PATCH /comments/{commentID}", []domain.ProjectRole{domain.ProjectRoleMember}, func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.comments.Update(ctx, f.comment, userID, content)
+			return err
+		}},
+		// The author deletes their own comment; admins moderate.
+		{"DELETE /comments/{commentID}", atLeast(domain.ProjectRoleMember), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			return f.comments.Delete(ctx, f.comment, userID)
+		}},
+
+		{"POST /projects/{projectID}/labels", atLeast(domain.ProjectRoleAdmin), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.labels.Create(ctx, f.project, userID, CreateLabelInput{Name: "feature", Color: "#00ff00"})
+			return err
+		}},
+		{"GET /projects/{projectID}/labels", atLeast(domain.ProjectRoleViewer), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.labels.ListByProject(ctx, f.project, userID)
+			return err
+		}},
+		{"DELETE /labels/{labelID}", atLeast(domain.ProjectRoleAdmin), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			return f.labels.Delete(ctx, f.label, userID)
+		}},
+		{"POST /tasks/{taskID}/labels", atLeast(domain.ProjectRoleMember), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			return f.labels.AddToTask(ctx, f.task, f.spareLabel, userID)
+		}},
+		{"DELETE /tasks/{taskID}/labels/{labelID}", atLeast(domain.ProjectRoleMember), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			return f.labels.RemoveFromTask(ctx, f.task, f.label, userID)
+		}},
+		{"GET /tasks/{taskID}/labels", atLeast(domain.ProjectRoleViewer), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.labels.ListByTask(ctx, f.task, userID)
+			return err
+		}},
+
+		{"GET /projects/{projectID}/audit", atLeast(domain.ProjectRoleAdmin), func(ctx context.Context, f *routeFixture, userID uuid.UUID) error {
+			_, err := f.audit.ListByProject(ctx, f.project, userID, domain.AuditFilter{})
+			return err
+		}},
+	}
+
+	roles := []domain.ProjectRole{domain.ProjectRoleOwner, domain.ProjectRoleAdmin, domain.ProjectRoleMember, domain.ProjectRoleViewer, ""}
+	for _, rt := range routes {
+		for _, role := range roles {
+			as := string(role)
+			if role == "" {
+				as = "outsider"
+			}
+			t.Run(rt.route+" as "+as, func(t *testing.T) {
+				f := newRouteFixture(t)
+				userID, ok := f.users[role]
+				if !ok {
+					userID = f.outsider
+				}
+				err := rt.call(context.Background(), f, userID)
+				allowed := slices.Contains(rt.allowed, role)
+				if allowed && err != nil {
+					t.Errorf("expected access, got %v", err)
+				}
+				if !allowed && !errors.Is(err, domain.ErrForbidden) {
+					t.Errorf("expected ErrForbidden, got %v", err)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/tracing"
+)
+
+type SessionService struct {
+	refreshTokenRepo domain.RefreshTokenRepository
+}
+
+func NewSessionService(refreshTokenRepo domain.RefreshTokenRepository) *SessionService {
+	return &SessionService{refreshTokenRepo: refreshTokenRepo}
+}
+
+// Session is a signed-in device as shown to its user.
+type Session struct {
+	ID         uuid.UUID `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+func (s *SessionService) List(ctx context.Context, userID, currentID uuid.UUID) ([]*Session, error) {
+	ctx, span := tracing.Start(ctx, "SessionService.List")
+	defer span.End()
+
+	tokens, err := s.refreshTokenRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]*Session, 0, len(tokens))
+	for _, t := range tokens {
+		sessions = append(sessions, &Session{
+			ID:         t.FamilyID,
+			UserAgent:  t.UserAgent,
+			IP:         t.IP,
+			Current:    t.FamilyID == currentID,
+			CreatedAt:  t.CreatedAt,
+			LastUsedAt: t.LastUsedAt,
+			ExpiresAt:  t.ExpiresAt,
+		})
+	}
+	return sessions, nil
+}
+
+// Revoke ends one of the user's sessions. Its access tokens stay valid until
+// they expire, but it can no longer be refreshed.
+func (s *SessionService) Revoke(ctx context.Context, userID, sessionID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "SessionService.Revoke")
+	defer span.End()
+
+	return s.refreshTokenRepo.DeleteFamily(ctx, userID, sessionID)
+}
+
+// RevokeOthers ends every session of the user except currentID.
+func (s *SessionService) RevokeOthers(ctx context.Context, userID, currentID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "SessionService.RevokeOthers")
+	defer span.End()
+
+	return s.refreshTokenRepo.DeleteOthers(ctx, userID, currentID)
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/config"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/requestinfo"
+)
+
+type sessionFixture struct {
+	*authStack
+	sessions *SessionService
+}
+
+func newSessionFixture(t *testing.T) *sessionFixture {
+	t.Helper()
+	f := &sessionFixture{authStack: newAuthStack(t, config.AuthConfig{})}
+	f.sessions = NewSessionService(f.refresh)
+	return f
+}
+
+func TestSessions_RefreshKeepsSessionAndRevokeOthers(t *testing.T) {
+	f := newSessionFixture(t)
+	user := f.user
+
+	laptop := requestinfo.WithClient(context.Background(), requestinfo.Client{UserAgent: "laptop", IP: "10.0.0.1"})
+	phone := requestinfo.WithClient(context.Background(), requestinfo.Client{UserAgent: "phone", IP: "10.0.0.2"})
+
+	first, err := f.auth.generateTokenPair(laptop, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.auth.generateTokenPair(phone, user); err != nil {
+		t.Fatal(err)
+	}
+
+	claims, _ := f.auth.ValidateAccessToken(first.AccessToken)
+	sessionID := claims.SessionID
+
+	_, refreshed, err := f.auth.RefreshToken(laptop, first.RefreshToken)
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if claims, _ := f.auth.ValidateAccessToken(refreshed.AccessToken); claims.SessionID != sessionID {
+		t.Errorf("expected refresh to keep session %s, got %s", sessionID, claims.SessionID)
+	}
+
+	list, _ := f.sessions.List(context.Background(), user.ID, sessionID)
+	if len(list) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(list))
+	}
+	for _, s := range list {
+		if s.Current != (s.ID == sessionID) {
+			t.Errorf("wrong current flag on %+v", s)
+		}
+		if s.Current && (s.UserAgent != "laptop" || s.IP != "10.0.0.1") {
+			t.Errorf("expected client info to be recorded, got %+v", s)
+		}
+	}
+
+	if err := f.sessions.RevokeOthers(context.Background(), user.ID, sessionID); err != nil {
+		t.Fatal(err)
+	}
+	list, _ = f.sessions.List(context.Background(), user.ID, sessionID)
+	if len(list) != 1 || list[0].ID != sessionID {
+		t.Errorf("expected only the current session to remain, got %+v", list)
+	}
+}
+
+func TestRefreshToken_ReuseRevokesFamily(t *testing.T) {
+	f := newSessionFixture(t)
+	ctx := requestinfo.WithClient(context.Background(), requestinfo.Client{UserAgent: "curl", IP: "203.0.113.9"})
+
+	stolen, err := f.auth.generateTokenPair(context.Background(), f.user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := f.auth.generateTokenPair(context.Background(), f.user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The legitimate client rotates first; the attacker keeps going on its branch.
+	_, legit, err := f.auth.RefreshToken(context.Background(), stolen.RefreshToken)
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+
+	if _, _, err := f.auth.RefreshToken(ctx, stolen.RefreshToken); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected reuse to be rejected, got %v", err)
+	}
+	if _, _, err := f.auth.RefreshToken(context.Background(), legit.RefreshToken); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("expected the whole family to be revoked, got %v", err)
+	}
+	if _, _, err := f.auth.RefreshToken(context.Background(), other.RefreshToken); err != nil {
+		t.Errorf("expected other sessions to survive, got %v", err)
+	}
+
+	if len(f.security.events) != 1 {
+		t.Fatalf("expected 1 security event, got %d", len(f.security.events))
+	}
+	if e := f.security.events[0]; e.Type != domain.SecurityEventRefreshTokenReuse || e.UserID != f.user.ID || e.IP != "203.0.113.9" {
+		t.Errorf("unexpected event %+v", e)
+	}
+}
+
+func TestPurgeExpiredTokens(t *testing.T) {
+	f := newSessionFixture(t)
+	ctx := context.Background()
+	if _, err := f.auth.generateTokenPair(ctx, f.user); err != nil {
+		t.Fatal(err)
+	}
+	must(t, f.refresh.Create(ctx, &domain.RefreshToken{
+		ID: uuid.New(), FamilyID: uuid.New(), UserID: f.user.ID, TokenHash: "expired", ExpiresAt: time.Now().Add(-time.Minute),
+	}))
+
+	n, err := f.auth.PurgeExpiredTokens(ctx)
+	if err != nil || n != 1 {
+		t.Errorf("expected 1 token purged, got n=%d err=%v", n, err)
+	}
+	if _, err := f.refresh.GetByTokenHash(ctx, "expired"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected the expired token to be gone, got %v", err)
+	}
+	if sessions, _ := f.refresh.ListByUser(ctx, f.user.ID); len(sessions) != 1 {
+		t.Errorf("expected the live session to survive, got %d", len(sessions))
+	}
+}
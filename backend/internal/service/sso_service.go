@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/oidc"
+	"github.com/letyshub/project-management/internal/tracing"
+)
+
+// ssoFlowTTL is how long a user has to finish logging in at the provider.
+const ssoFlowTTL = 10 * time.Minute
+
+type SSOService struct {
+	provider       *oidc.Provider
+	userRepo       domain.UserRepository
+	identityRepo   domain.UserIdentityRepository
+	txManager      domain.TxManager
+	authService    *AuthService
+	allowedDomains []string
+	flowKey        []byte
+}
+
+func NewSSOService(
+	provider *oidc.Provider,
+	userRepo domain.UserRepository,
+	identityRepo domain.UserIdentityRepository,
+	txManager domain.TxManager,
+	authService *AuthService,
+	allowedDomains []string,
+	flowKey []byte,
+) *SSOService {
+	domains := make([]string, 0, len(allowedDomains))
+	for _, d := range allowedDomains {
+		if d = strings.ToLower(strings.TrimSpace(d)); d != "" {
+			domains = append(domains, d)
+		}
+	}
+	return &SSOService{
+		provider:       provider,
+		userRepo:       userRepo,
+		identityRepo:   identityRepo,
+		txManager:      txManager,
+		authService:    authService,
+		allowedDomains: domains,
+		flowKey:        flowKey,
+	}
+}
+
+// Begin starts a login. It returns the provider URL to redirect to and the
+// sealed flow state the caller must hand back to Complete.
+func (s *SSOService) Begin() (authURL, sealedFlow string, err error) {
+	flow, err := oidc.NewFlow(ssoFlowTTL)
+	if err != nil {
+		return "", "", fmt.Errorf("starting sso flow: %w", err)
+	}
+	sealedFlow, err = flow.Seal(s.flowKey)
+	if err != nil {
+		return "", "", fmt.Errorf("sealing sso flow: %w", err)
+	}
+	return s.provider.AuthCodeURL(flow), sealedFlow, nil
+}
+
+// Complete finishes a login from the provider's callback. Known identities
+// sign in directly; otherwise the account is linked by verified email, or
+// provisioned if no user has that email yet. The provider login only counts
+// as the first factor: users with two-factor authentication get a challenge
+// to complete with a code, as from a password login.
+func (s *SSOService) Complete(ctx context.Context, sealedFlow, state, code string) (*LoginResult, error) {
+	ctx, span := tracing.Start(ctx, "SSOService.Complete")
+	defer span.End()
+
+	flow, err := oidc.OpenFlow(s.flowKey, sealedFlow)
+	if err != nil || state == "" || state != flow.State {
+		return nil, domain.ErrUnauthorized
+	}
+
+	idToken, err := s.provider.Exchange(ctx, flow, code)
+	if err != nil {
+		if errors.Is(err, oidc.ErrInvalidToken) {
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	user, err := s.resolveUser(ctx, idToken)
+	if err != nil {
+		return nil, err
+	}
+	return s.authService.startSession(ctx, user)
+}
+
+func (s *SSOService) resolveUser(ctx context.Context, idToken *oidc.IDToken) (*domain.User, error) {
+	email := strings.ToLower(strings.TrimSpace(idToken.Email))
+	if !s.domainAllowed(email) {
+		return nil, domain.ErrForbidden
+	}
+
+	identity, err := s.identityRepo.GetBySubject(ctx, idToken.Issuer, idToken.Subject)
+	if err == nil {
+		return s.userRepo.GetByID(ctx, identity.UserID)
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	// Linking or creating an account trusts the email, so the provider must
+	// have verified it.
+	if email == "" || !idToken.EmailVerified {
+		return nil, domain.ErrForbidden
+	}
+
+	var user *domain.User
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		user, err = s.link(ctx, idToken, email)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// link attaches the identity to the account with the same email, creating
+// the account if there is none. Accounts whose email was never verified are
+// refused with ErrEmailNotVerified: anyone could have registered one for the
+// address, and linking would let them keep signing in with their password
+// once the address's owner logs in through SSO.
+func (s *SSOService) link(ctx context.Context, idToken *oidc.IDToken, email string) (*domain.User, error) {
+	now := time.Now()
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	switch {
+	case err == nil:
+		if !user.EmailVerified() {
+			return nil, domain.ErrEmailNotVerified
+		}
+	case errors.Is(err, domain.ErrNotFound):
+		user, err = s.provision(ctx, idToken, email, now)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	identity := &domain.UserIdentity{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		Issuer:    idToken.Issuer,
+		Subject:   idToken.Subject,
+		Email:     email,
+		CreatedAt: now,
+	}
+	if err := s.identityRepo.Create(ctx, identity); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// provision creates a user for a first-time SSO login. The account has no
+// password; the owner can set one through the password reset flow.
+func (s *SSOService) provision(ctx context.Context, idToken *oidc.IDToken, email string, now time.Time) (*domain.User, error) {
+	name := strings.TrimSpace(idToken.Name)
+	if name == "" {
+		name, _, _ = strings.Cut(email, "@")
+	}
+	user := &domain.User{
+		ID:              uuid.New(),
+		Email:           email,
+		Name:            name,
+		Role:            domain.UserRoleMember,
+		EmailVerifiedAt: &now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *SSOService) domainAllowed(email string) bool {
+	if len(s.allowedDomains) == 0 {
+		return true
+	}
+	_, domainPart, ok := strings.Cut(email, "@")
+	if !ok {
+		return false
+	}
+	for _, d := range s.allowedDomains {
+		if domainPart == d {
+			return true
+		}
+	}
+	return false
+}
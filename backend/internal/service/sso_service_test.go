@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/letyshub/project-management/internal/config"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/jwtkeys"
+	"github.com/letyshub/project-management/internal/oidc"
+	"github.com/letyshub/project-management/internal/oidc/oidctest"
+	"github.com/letyshub/project-management/internal/repository/memory"
+)
+
+type ssoFixture struct {
+	*authStack
+	svc        *SSOService
+	issuer     *oidctest.Issuer
+	identities *memory.UserIdentityRepo
+}
+
+func newSSOFixture(t *testing.T, allowedDomains ...string) *ssoFixture {
+	t.Helper()
+	iss := oidctest.NewIssuer(t, "client", "secret")
+	provider, err := oidc.Discover(context.Background(), iss.Client(), oidc.Config{
+		Issuer: iss.URL, ClientID: "client", ClientSecret: "secret", RedirectURL: "http://app/callback",
+	})
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+
+	f := &ssoFixture{authStack: newAuthStack(t, config.AuthConfig{}), issuer: iss}
+	f.identities = memory.NewUserIdentityRepo(f.store)
+	f.svc = NewSSOService(provider, f.users, f.identities, f.tx, f.auth, allowedDomains, jwtkeys.DeriveKey("test", "oidc-flow"))
+	return f
+}
+
+// linked reports whether subject has an identity at the mock issuer.
+func (f *ssoFixture) linked(t *testing.T, subject string) bool {
+	t.Helper()
+	_, err := f.identities.GetBySubject(context.Background(), f.issuer.URL, subject)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		t.Fatal(err)
+	}
+	return err == nil
+}
+
+// login runs the whole browser round trip against the mock issuer.
+func (f *ssoFixture) login(t *testing.T, claims jwt.MapClaims) (*domain.User, error) {
+	t.Helper()
+	result, err := f.complete(t, claims)
+	if err != nil {
+		return nil, err
+	}
+	return result.User, nil
+}
+
+func (f *ssoFixture) complete(t *testing.T, claims jwt.MapClaims) (*LoginResult, error) {
+	t.Helper()
+	authURL, flow, err := f.svc.Begin()
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	code, state, err := f.issuer.Authorize(authURL, claims)
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	return f.svc.Complete(context.Background(), flow, state, code)
+}
+
+func TestSSOService_ProvisionsNewUser(t *testing.T) {
+	f := newSSOFixture(t)
+
+	user, err := f.login(t, jwt.MapClaims{"sub": "s1", "email": "Grace@Example.com", "email_verified": true, "name": "Grace"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Email != "grace@example.com" || user.Name != "Grace" || !user.EmailVerified() {
+		t.Errorf("unexpected user: %+v", user)
+	}
+	if !f.linked(t, "s1") {
+		t.Fatal("expected the identity to be linked")
+	}
+
+	again, err := f.login(t, jwt.MapClaims{"sub": "s1", "email": "grace@example.com", "email_verified": true})
+	if err != nil {
+		t.Fatalf("second login: %v", err)
+	}
+	if again.ID != user.ID {
+		t.Error("expected the second login to reuse the provisioned user")
+	}
+}
+
+func TestSSOService_LinksExistingUserByVerifiedEmail(t *testing.T) {
+	f := newSSOFixture(t)
+	existing := f.addUser(t, "Bob", "bob@example.com", domain.UserRoleMember)
+	must(t, f.users.MarkEmailVerified(context.Background(), existing.ID, time.Now()))
+
+	user, err := f.login(t, jwt.MapClaims{"sub": "s2", "email": "bob@example.com", "email_verified": true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.ID != existing.ID {
+		t.Errorf("expected to link existing user")
+	}
+}
+
+func TestSSOService_RefusesToLinkUnverifiedAccount(t *testing.T) {
+	f := newSSOFixture(t)
+	squatter := f.addUser(t, "Bob", "bob@example.com", domain.UserRoleMember)
+
+	if _, err := f.login(t, jwt.MapClaims{"sub": "s2", "email": "bob@example.com", "email_verified": true}); !errors.Is(err, domain.ErrEmailNotVerified) {
+		t.Fatalf("expected ErrEmailNotVerified, got %v", err)
+	}
+	if f.linked(t, "s2") || f.reload(t, squatter.ID).EmailVerified() {
+		t.Error("expected the account to be left unlinked and unverified")
+	}
+}
+
+func TestSSOService_RequiresTwoFactor(t *testing.T) {
+	f := newSSOFixture(t)
+	ctx := context.Background()
+	user, err := f.login(t, jwt.MapClaims{"sub": "s6", "email": "grace@example.com", "email_verified": true})
+	if err != nil {
+		t.Fatalf("first login: %v", err)
+	}
+	must(t, f.twoFactor.SaveTOTP(ctx, &domain.TOTPEnrollment{UserID: user.ID, Secret: "JBSWY3DPEHPK3PXP", CreatedAt: time.Now()}))
+	must(t, f.twoFactor.ConfirmTOTP(ctx, user.ID, time.Now()))
+
+	result, err := f.complete(t, jwt.MapClaims{"sub": "s6", "email": "grace@example.com", "email_verified": true})
+	if err != nil {
+		t.Fatalf("second login: %v", err)
+	}
+	if result.Tokens != nil || result.ChallengeToken == "" {
+		t.Errorf("expected a two-factor challenge instead of tokens, got %+v", result)
+	}
+}
+
+func TestSSOService_Rejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		domains []string
+		claims  jwt.MapClaims
+	}{
+		{
+			name:   "unverified email",
+			claims: jwt.MapClaims{"sub": "s3", "email": "eve@example.com", "email_verified": false},
+		},
+		{
+			name:    "domain not allowed",
+			domains: []string{"corp.example"},
+			claims:  jwt.MapClaims{"sub": "s4", "email": "eve@example.com", "email_verified": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newSSOFixture(t, tt.domains...)
+			if _, err := f.login(t, tt.claims); !errors.Is(err, domain.ErrForbidden) {
+				t.Errorf("expected ErrForbidden, got %v", err)
+			}
+			if _, err := f.users.GetByEmail(context.Background(), "eve@example.com"); !errors.Is(err, domain.ErrNotFound) {
+				t.Errorf("expected no user to be created, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSSOService_StateMismatch(t *testing.T) {
+	f := newSSOFixture(t)
+	authURL, flow, _ := f.svc.Begin()
+	code, _, err := f.issuer.Authorize(authURL, jwt.MapClaims{"sub": "s5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.svc.Complete(context.Background(), flow, "forged", code); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/authz"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/tracing"
+)
+
+// hiddenTaskFields are left out of the timeline: IDs and timestamps never
+// change, and positions change on every reorder.
+var hiddenTaskFields = map[string]bool{
+	"id":         true,
+	"created_at": true,
+	"updated_at": true,
+	"position":   true,
+}
+
+// TaskActivityService builds a task's timeline from the audit log, which
+// already records every change to a task field by field, and its comments.
+type TaskActivityService struct {
+	auditRepo   domain.AuditEventRepository
+	commentRepo domain.CommentRepository
+	policy      authz.Policy
+}
+
+func NewTaskActivityService(auditRepo domain.AuditEventRepository, commentRepo domain.CommentRepository, policy authz.Policy) *TaskActivityService {
+	return &TaskActivityService{auditRepo: auditRepo, commentRepo: commentRepo, policy: policy}
+}
+
+// Timeline returns the task's changes and comments, oldest first. Anyone who
+// can read the task can read its timeline.
+func (s *TaskActivityService) Timeline(ctx context.Context, taskID, userID uuid.UUID) ([]*domain.TaskActivity, error) {
+	ctx, span := tracing.Start(ctx, "TaskActivityService.Timeline")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionRead, authz.Task(taskID)); err != nil {
+		return nil, err
+	}
+
+	var timeline []*domain.TaskActivity
+	filter := domain.AuditFilter{EntityType: domain.AuditEntityTask, EntityID: &taskID, Limit: maxAuditListLimit}
+	for {
+		events, err := s.auditRepo.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range events {
+			entry, err := taskChange(e)
+			if err != nil {
+				return nil, err
+			}
+			if entry != nil {
+				timeline = append(timeline, entry)
+			}
+		}
+		if len(events) < filter.Limit {
+			break
+		}
+		filter.Offset += filter.Limit
+	}
+
+	comments, err := s.commentRepo.ListByTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range comments {
+		timeline = append(timeline, &domain.TaskActivity{
+			Type:      domain.TaskActivityComment,
+			ActorID:   c.AuthorID,
+			CreatedAt: c.CreatedAt,
+			Comment:   c,
+		})
+	}
+
+	slices.SortStableFunc(timeline, func(a, b *domain.TaskActivity) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return timeline, nil
+}
+
+// taskChange turns an audit event into a timeline entry, or nil when none of
+// the fields it changed are shown.
+func taskChange(e *domain.AuditEvent) (*domain.TaskActivity, error) {
+	entry := &domain.TaskActivity{
+		Type:      domain.TaskActivityChange,
+		ActorID:   e.ActorID,
+		CreatedAt: e.CreatedAt,
+		Action:    e.Action,
+	}
+	if e.Action == domain.AuditActionCreate {
+		return entry, nil
+	}
+
+	before, err := auditFields(e.Before)
+	if err != nil {
+		return nil, err
+	}
+	after, err := auditFields(e.After)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]string, 0, len(before)+len(after))
+	for k := range before {
+		fields = append(fields, k)
+	}
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			fields = append(fields, k)
+		}
+	}
+	slices.Sort(fields)
+
+	for _, field := range fields {
+		if hiddenTaskFields[field] {
+			continue
+		}
+		entry.Changes = append(entry.Changes, domain.FieldChange{
+			Field: field,
+			Old:   jsonOrNull(before[field]),
+			New:   jsonOrNull(after[field]),
+		})
+	}
+	if len(entry.Changes) == 0 {
+		return nil, nil
+	}
+	return entry, nil
+}
+
+func jsonOrNull(v json.RawMessage) json.RawMessage {
+	if v == nil {
+		return json.RawMessage("null")
+	}
+	return v
+}
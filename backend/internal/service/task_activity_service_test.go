@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+)
+
+type mockCommentRepo struct {
+	comments []*domain.Comment
+}
+
+func (m *mockCommentRepo) Create(ctx context.Context, comment *domain.Comment) error { return nil }
+func (m *mockCommentRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Comment, error) {
+	return nil, domain.ErrNotFound
+}
+func (m *mockCommentRepo) ListByTask(ctx context.Context, taskID uuid.UUID) ([]*domain.Comment, error) {
+	return m.comments, nil
+}
+func (m *mockCommentRepo) Update(ctx context.Context, comment *domain.Comment) error { return nil }
+func (m *mockCommentRepo) Delete(ctx context.Context, id uuid.UUID) error            { return nil }
+
+func TestTaskActivityService_Timeline(t *testing.T) {
+	taskID, actorID := uuid.New(), uuid.New()
+	start := time.Now()
+	event := func(action, before, after string, minutes int) *domain.AuditEvent {
+		e := &domain.AuditEvent{
+			ID: uuid.New(), ActorID: actorID, EntityType: domain.AuditEntityTask, EntityID: taskID,
+			Action: action, CreatedAt: start.Add(time.Duration(minutes) * time.Minute),
+		}
+		if before != "" {
+			e.Before = json.RawMessage(before)
+		}
+		if after != "" {
+			e.After = json.RawMessage(after)
+		}
+		return e
+	}
+	// The audit log lists newest first.
+	audit := &fakeAuditRepo{events: []*domain.AuditEvent{
+		event(domain.AuditActionMove, `{"position":1000}`, `{"position":500}`, 4),
+		event(domain.AuditActionMove, `{"column_id":"a","position":1000}`, `{"column_id":"b","position":2000}`, 3),
+		event(domain.AuditActionUpdate, `{"assignee_id":null,"priority":"low"}`, `{"assignee_id":"u","priority":"high"}`, 1),
+		event(domain.AuditActionCreate, "", `{"title":"Ship it"}`, 0),
+	}}
+	comments := &mockCommentRepo{comments: []*domain.Comment{
+		{ID: uuid.New(), TaskID: taskID, AuthorID: actorID, Content: "On it", CreatedAt: start.Add(2 * time.Minute)},
+	}}
+	svc := NewTaskActivityService(audit, comments, &mockPolicy{})
+
+	timeline, err := svc.Timeline(context.Background(), taskID, actorID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(timeline) != 4 {
+		t.Fatalf("expected create, update, comment and move, got %d entries", len(timeline))
+	}
+
+	if timeline[0].Action != domain.AuditActionCreate || timeline[0].Changes != nil {
+		t.Errorf("expected the create first, without changes, got %+v", timeline[0])
+	}
+	update := timeline[1].Changes
+	if len(update) != 2 || update[0].Field != "assignee_id" || string(update[0].Old) != "null" || string(update[0].New) != `"u"` ||
+		update[1].Field != "priority" || string(update[1].Old) != `"low"` || string(update[1].New) != `"high"` {
+		t.Errorf("unexpected update changes %+v", update)
+	}
+	if timeline[2].Type != domain.TaskActivityComment || timeline[2].Comment.Content != "On it" {
+		t.Errorf("expected the comment third, got %+v", timeline[2])
+	}
+	move := timeline[3].Changes
+	if len(move) != 1 || move[0].Field != "column_id" {
+		t.Errorf("expected only the column change on the move, got %+v", move)
+	}
+}
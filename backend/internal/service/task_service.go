@@ -7,27 +7,25 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/letyshub/project-management/internal/authz"
 	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/metrics"
+	"github.com/letyshub/project-management/internal/tracing"
 )
 
 type TaskService struct {
-	taskRepo    domain.TaskRepository
-	columnRepo  domain.ColumnRepository
-	boardRepo   domain.BoardRepository
-	projectRepo domain.ProjectRepository
+	taskRepo       domain.TaskRepository
+	transitionRepo domain.TaskTransitionRepository
+	auditService   *AuditService
+	policy         authz.Policy
 }
 
-func NewTaskService(
-	taskRepo domain.TaskRepository,
-	columnRepo domain.ColumnRepository,
-	boardRepo domain.BoardRepository,
-	projectRepo domain.ProjectRepository,
-) *TaskService {
+func NewTaskService(taskRepo domain.TaskRepository, transitionRepo domain.TaskTransitionRepository, auditService *AuditService, policy authz.Policy) *TaskService {
 	return &TaskService{
-		taskRepo:    taskRepo,
-		columnRepo:  columnRepo,
-		boardRepo:   boardRepo,
-		projectRepo: projectRepo,
+		taskRepo:       taskRepo,
+		transitionRepo: transitionRepo,
+		auditService:   auditService,
+		policy:         policy,
 	}
 }
 
@@ -38,8 +36,11 @@ type CreateTaskInput struct {
 	AssigneeID  *uuid.UUID `json:"assignee_id"`
 }
 
-func (s *TaskService) Create(ctx context.Context, columnID uuid.UUID, ownerID uuid.UUID, input CreateTaskInput) (*domain.Task, error) {
-	if err := s.authorizeColumn(ctx, columnID, ownerID); err != nil {
+func (s *TaskService) Create(ctx context.Context, columnID uuid.UUID, userID uuid.UUID, input CreateTaskInput) (*domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.Create")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionWrite, authz.Column(columnID)); err != nil {
 		return nil, err
 	}
 	if input.Title == "" {
@@ -78,17 +79,44 @@ func (s *TaskService) Create(ctx context.Context, columnID uuid.UUID, ownerID uu
 		UpdatedAt:   now,
 	}
 
-	if err := s.taskRepo.Create(ctx, task); err != nil {
+	projectID, err := s.policy.ProjectOf(ctx, authz.Column(columnID))
+	if err != nil {
 		return nil, err
 	}
+	err = s.auditService.apply(ctx, auditEntry{
+		actorID: userID, projectID: &projectID,
+		entityType: domain.AuditEntityTask, entityID: task.ID, action: domain.AuditActionCreate,
+		after: task,
+	}, func(ctx context.Context) error {
+		if err := s.taskRepo.Create(ctx, task); err != nil {
+			return err
+		}
+		return s.recordTransition(ctx, task, nil, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	metrics.TasksCreated.Inc()
 	return task, nil
 }
 
-func (s *TaskService) GetByID(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
+func (s *TaskService) GetByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.GetByID")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionRead, authz.Task(id)); err != nil {
+		return nil, err
+	}
 	return s.taskRepo.GetByID(ctx, id)
 }
 
-func (s *TaskService) ListByBoard(ctx context.Context, boardID uuid.UUID, filter domain.TaskFilter) ([]*domain.Task, error) {
+func (s *TaskService) ListByBoard(ctx context.Context, boardID uuid.UUID, userID uuid.UUID, filter domain.TaskFilter) ([]*domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.ListByBoard")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionRead, authz.Board(boardID)); err != nil {
+		return nil, err
+	}
 	return s.taskRepo.ListByBoard(ctx, boardID, filter)
 }
 
@@ -99,14 +127,18 @@ type UpdateTaskInput struct {
 	AssigneeID  *uuid.UUID `json:"assignee_id"`
 }
 
-func (s *TaskService) Update(ctx context.Context, id uuid.UUID, ownerID uuid.UUID, input UpdateTaskInput) (*domain.Task, error) {
-	task, err := s.taskRepo.GetByID(ctx, id)
-	if err != nil {
+func (s *TaskService) Update(ctx context.Context, id uuid.UUID, userID uuid.UUID, input UpdateTaskInput) (*domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.Update")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionWrite, authz.Task(id)); err != nil {
 		return nil, err
 	}
-	if err := s.authorizeColumn(ctx, task.ColumnID, ownerID); err != nil {
+	task, err := s.taskRepo.GetByID(ctx, id)
+	if err != nil {
 		return nil, err
 	}
+	before := *task
 
 	if input.Title != nil {
 		if *input.Title == "" {
@@ -128,7 +160,18 @@ func (s *TaskService) Update(ctx context.Context, id uuid.UUID, ownerID uuid.UUI
 	}
 	task.UpdatedAt = time.Now()
 
-	if err := s.taskRepo.Update(ctx, task); err != nil {
+	projectID, err := s.policy.ProjectOf(ctx, authz.Task(id))
+	if err != nil {
+		return nil, err
+	}
+	err = s.auditService.apply(ctx, auditEntry{
+		actorID: userID, projectID: &projectID,
+		entityType: domain.AuditEntityTask, entityID: task.ID, action: domain.AuditActionUpdate,
+		before: before, after: task,
+	}, func(ctx context.Context) error {
+		return s.taskRepo.Update(ctx, task)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return task, nil
@@ -139,53 +182,93 @@ type MoveTaskInput struct {
 	Position float64   `json:"position"`
 }
 
-func (s *TaskService) Move(ctx context.Context, id uuid.UUID, ownerID uuid.UUID, input MoveTaskInput) (*domain.Task, error) {
-	task, err := s.taskRepo.GetByID(ctx, id)
+func (s *TaskService) Move(ctx context.Context, id uuid.UUID, userID uuid.UUID, input MoveTaskInput) (*domain.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.Move")
+	defer span.End()
+
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionWrite, authz.Task(id)); err != nil {
+		return nil, err
+	}
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionWrite, authz.Column(input.ColumnID)); err != nil {
+		return nil, err
+	}
+	fromProject, err := s.policy.ProjectOf(ctx, authz.Task(id))
+	if err != nil {
+		return nil, err
+	}
+	toProject, err := s.policy.ProjectOf(ctx, authz.Column(input.ColumnID))
 	if err != nil {
 		return nil, err
 	}
-	if err := s.authorizeColumn(ctx, input.ColumnID, ownerID); err != nil {
+	if fromProject != toProject {
+		return nil, fmt.Errorf("%w: task cannot be moved to another project", domain.ErrValidation)
+	}
+
+	task, err := s.taskRepo.GetByID(ctx, id)
+	if err != nil {
 		return nil, err
 	}
+	before := *task
 
 	task.ColumnID = input.ColumnID
 	task.Position = input.Position
 	task.UpdatedAt = time.Now()
 
-	if err := s.taskRepo.Update(ctx, task); err != nil {
+	err = s.auditService.apply(ctx, auditEntry{
+		actorID: userID, projectID: &toProject,
+		entityType: domain.AuditEntityTask, entityID: task.ID, action: domain.AuditActionMove,
+		before: before, after: task,
+	}, func(ctx context.Context) error {
+		if err := s.taskRepo.Update(ctx, task); err != nil {
+			return err
+		}
+		if before.ColumnID == task.ColumnID {
+			return nil
+		}
+		return s.recordTransition(ctx, task, &before.ColumnID, userID)
+	})
+	if err != nil {
 		return nil, err
 	}
+	metrics.TasksMoved.Inc()
 	return task, nil
 }
 
-func (s *TaskService) Delete(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error {
-	task, err := s.taskRepo.GetByID(ctx, id)
-	if err != nil {
-		return err
-	}
-	if err := s.authorizeColumn(ctx, task.ColumnID, ownerID); err != nil {
-		return err
-	}
-	return s.taskRepo.Delete(ctx, id)
-}
+func (s *TaskService) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "TaskService.Delete")
+	defer span.End()
 
-func (s *TaskService) authorizeColumn(ctx context.Context, columnID uuid.UUID, ownerID uuid.UUID) error {
-	col, err := s.columnRepo.GetByID(ctx, columnID)
-	if err != nil {
+	if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionWrite, authz.Task(id)); err != nil {
 		return err
 	}
-	board, err := s.boardRepo.GetByID(ctx, col.BoardID)
+	task, err := s.taskRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	project, err := s.projectRepo.GetByID(ctx, board.ProjectID)
+	projectID, err := s.policy.ProjectOf(ctx, authz.Task(id))
 	if err != nil {
 		return err
 	}
-	if project.OwnerID != ownerID {
-		return domain.ErrForbidden
-	}
-	return nil
+	return s.auditService.apply(ctx, auditEntry{
+		actorID: userID, projectID: &projectID,
+		entityType: domain.AuditEntityTask, entityID: id, action: domain.AuditActionDelete,
+		before: task,
+	}, func(ctx context.Context) error {
+		return s.taskRepo.Delete(ctx, id)
+	})
+}
+
+// recordTransition notes that task entered its current column, coming from
+// from (nil on creation). The flow analytics are computed from these rows.
+func (s *TaskService) recordTransition(ctx context.Context, task *domain.Task, from *uuid.UUID, actorID uuid.UUID) error {
+	return s.transitionRepo.Create(ctx, &domain.TaskTransition{
+		ID:           uuid.New(),
+		TaskID:       task.ID,
+		FromColumnID: from,
+		ToColumnID:   task.ColumnID,
+		ActorID:      actorID,
+		CreatedAt:    task.UpdatedAt,
+	})
 }
 
 func isValidPriority(p string) bool {
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/authz"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/tracing"
+)
+
+// PersonalAccessTokenPrefix marks a bearer credential as a personal access
+// token rather than a JWT.
+const PersonalAccessTokenPrefix = "pm_"
+
+// lastUsedResolution bounds how often a busy token writes its last-used time.
+const lastUsedResolution = time.Minute
+
+type TokenService struct {
+	tokenRepo    domain.PersonalAccessTokenRepository
+	userRepo     domain.UserRepository
+	auditService *AuditService
+	policy       authz.Policy
+}
+
+func NewTokenService(
+	tokenRepo domain.PersonalAccessTokenRepository,
+	userRepo domain.UserRepository,
+	auditService *AuditService,
+	policy authz.Policy,
+) *TokenService {
+	return &TokenService{
+		tokenRepo:    tokenRepo,
+		userRepo:     userRepo,
+		auditService: auditService,
+		policy:       policy,
+	}
+}
+
+type CreateTokenInput struct {
+	Name      string            `json:"name"`
+	Scope     domain.TokenScope `json:"scope"`
+	ProjectID *uuid.UUID        `json:"project_id"`
+	ExpiresAt *time.Time        `json:"expires_at"`
+}
+
+// CreatedToken carries the raw secret, which is only available at creation.
+type CreatedToken struct {
+	*domain.PersonalAccessToken
+	Token string `json:"token"`
+}
+
+func (s *TokenService) Create(ctx context.Context, userID uuid.UUID, input CreateTokenInput) (*CreatedToken, error) {
+	ctx, span := tracing.Start(ctx, "TokenService.Create")
+	defer span.End()
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", domain.ErrValidation)
+	}
+	scope := input.Scope
+	if scope == "" {
+		scope = domain.TokenScopeRead
+	}
+	if !scope.Valid() {
+		return nil, fmt.Errorf("%w: scope must be read or write", domain.ErrValidation)
+	}
+	now := time.Now()
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(now) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", domain.ErrValidation)
+	}
+	if input.ProjectID != nil {
+		if err := s.policy.Authorize(ctx, authz.User(userID), authz.ActionRead, authz.Project(*input.ProjectID)); err != nil {
+			return nil, err
+		}
+	}
+
+	secret, err := generateRandomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("generating access token: %w", err)
+	}
+	raw := PersonalAccessTokenPrefix + secret
+
+	token := &domain.PersonalAccessToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    raw[:len(PersonalAccessTokenPrefix)+8],
+		TokenHash: hashToken(raw),
+		Scope:     scope,
+		ProjectID: input.ProjectID,
+		ExpiresAt: input.ExpiresAt,
+		CreatedAt: now,
+	}
+	err = s.auditService.apply(ctx, auditEntry{
+		actorID:    userID,
+		entityType: domain.AuditEntityAccessToken,
+		entityID:   token.ID,
+		action:     domain.AuditActionCreate,
+		after:      token,
+	}, func(ctx context.Context) error {
+		return s.tokenRepo.Create(ctx, token)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &CreatedToken{PersonalAccessToken: token, Token: raw}, nil
+}
+
+func (s *TokenService) List(ctx context.Context, userID uuid.UUID) ([]*domain.PersonalAccessToken, error) {
+	ctx, span := tracing.Start(ctx, "TokenService.List")
+	defer span.End()
+
+	return s.tokenRepo.ListByUser(ctx, userID)
+}
+
+func (s *TokenService) Revoke(ctx context.Context, userID, tokenID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "TokenService.Revoke")
+	defer span.End()
+
+	tokens, err := s.tokenRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(tokens, func(t *domain.PersonalAccessToken) bool { return t.ID == tokenID })
+	if i < 0 {
+		return domain.ErrNotFound
+	}
+	return s.auditService.apply(ctx, auditEntry{
+		actorID:    userID,
+		entityType: domain.AuditEntityAccessToken,
+		entityID:   tokenID,
+		action:     domain.AuditActionDelete,
+		before:     tokens[i],
+	}, func(ctx context.Context) error {
+		return s.tokenRepo.Delete(ctx, tokenID, userID)
+	})
+}
+
+// Authenticate resolves a raw personal access token to its record and owner,
+// recording when it was last used.
+func (s *TokenService) Authenticate(ctx context.Context, raw string) (*domain.PersonalAccessToken, *domain.User, error) {
+	ctx, span := tracing.Start(ctx, "TokenService.Authenticate")
+	defer span.End()
+
+	token, err := s.tokenRepo.GetByTokenHash(ctx, hashToken(raw))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, domain.ErrUnauthorized
+		}
+		return nil, nil, err
+	}
+	now := time.Now()
+	if token.ExpiresAt != nil && now.After(*token.ExpiresAt) {
+		return nil, nil, domain.ErrUnauthorized
+	}
+
+	user, err := s.userRepo.GetByID(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, domain.ErrUnauthorized
+		}
+		return nil, nil, err
+	}
+	if !user.Active() {
+		return nil, nil, domain.ErrAccountDeactivated
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastUsedResolution {
+		if err := s.tokenRepo.UpdateLastUsed(ctx, token.ID, now); err != nil {
+			return nil, nil, err
+		}
+		token.LastUsedAt = &now
+	}
+	return token, user, nil
+}
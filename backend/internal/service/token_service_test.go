@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/config"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/repository/memory"
+)
+
+// countingTokens counts the last-used writes that reach the store.
+type countingTokens struct {
+	*memory.PersonalAccessTokenRepo
+	lastUsedWrites int
+}
+
+func (r *countingTokens) UpdateLastUsed(ctx context.Context, id uuid.UUID, lastUsedAt time.Time) error {
+	r.lastUsedWrites++
+	return r.PersonalAccessTokenRepo.UpdateLastUsed(ctx, id, lastUsedAt)
+}
+
+type tokenFixture struct {
+	*authStack
+	svc    *TokenService
+	tokens *countingTokens
+}
+
+func newTokenFixture(t *testing.T) *tokenFixture {
+	t.Helper()
+	f := &tokenFixture{authStack: newAuthStack(t, config.AuthConfig{})}
+	f.tokens = &countingTokens{PersonalAccessTokenRepo: memory.NewPersonalAccessTokenRepo(f.store)}
+	f.svc = NewTokenService(f.tokens, f.users, f.audit, &mockPolicy{})
+	return f
+}
+
+func TestTokenService_RecordsAudit(t *testing.T) {
+	f := newTokenFixture(t)
+	ctx := context.Background()
+	userID := f.user.ID
+
+	created, err := f.svc.Create(ctx, userID, CreateTokenInput{Name: "ci", Scope: domain.TokenScopeWrite})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.svc.Revoke(ctx, uuid.New(), created.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected another user's token to be not found, got %v", err)
+	}
+	if err := f.svc.Revoke(ctx, userID, created.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	events := auditEvents(t, f.store)
+	if len(events) != 2 {
+		t.Fatalf("expected two audit events, got %d", len(events))
+	}
+	for i, action := range []string{domain.AuditActionCreate, domain.AuditActionDelete} {
+		e := events[i]
+		if e.Action != action || e.EntityType != domain.AuditEntityAccessToken || e.EntityID != created.ID || e.ActorID != userID {
+			t.Errorf("event %d: expected %s of the token by its owner, got %+v", i, action, e)
+		}
+	}
+	if after := string(events[0].After); !strings.Contains(after, `"scope":"write"`) || strings.Contains(after, created.Token) {
+		t.Errorf("expected the scope but not the secret to be recorded, got %s", after)
+	}
+}
+
+func TestTokenService_AuthenticateRejectsUnusableTokens(t *testing.T) {
+	f := newTokenFixture(t)
+	ctx := context.Background()
+	deactivatedAt := time.Now()
+	deactivated := f.addUser(t, "Bob", "bob@example.com", domain.UserRoleMember)
+	must(t, f.users.SetDeactivated(ctx, deactivated.ID, &deactivatedAt, deactivatedAt))
+
+	expired := time.Now().Add(-time.Minute)
+	f.tokens.Create(ctx, &domain.PersonalAccessToken{ID: uuid.New(), UserID: f.user.ID, TokenHash: hashToken("pm_expired"), ExpiresAt: &expired})
+	f.tokens.Create(ctx, &domain.PersonalAccessToken{ID: uuid.New(), UserID: deactivated.ID, TokenHash: hashToken("pm_deactivated")})
+	revoked, err := f.svc.Create(ctx, f.user.ID, CreateTokenInput{Name: "ci"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.svc.Revoke(ctx, f.user.ID, revoked.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		raw  string
+		want error
+	}{
+		{name: "unknown", raw: "pm_unknown", want: domain.ErrUnauthorized},
+		{name: "expired", raw: "pm_expired", want: domain.ErrUnauthorized},
+		{name: "revoked", raw: revoked.Token, want: domain.ErrUnauthorized},
+		{name: "deactivated owner", raw: "pm_deactivated", want: domain.ErrAccountDeactivated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := f.svc.Authenticate(ctx, tt.raw); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+	if f.tokens.lastUsedWrites != 0 {
+		t.Errorf("expected rejected tokens not to be marked used, got %d writes", f.tokens.lastUsedWrites)
+	}
+}
+
+func TestTokenService_AuthenticateWritesLastUsedOncePerMinute(t *testing.T) {
+	f := newTokenFixture(t)
+	ctx := context.Background()
+
+	created, err := f.svc.Create(ctx, f.user.ID, CreateTokenInput{Name: "ci"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		token, got, err := f.svc.Authenticate(ctx, created.Token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ID != f.user.ID || token.LastUsedAt == nil {
+			t.Fatalf("expected the owner and a last-used time, got %v and %v", got.ID, token.LastUsedAt)
+		}
+	}
+	if f.tokens.lastUsedWrites != 1 {
+		t.Fatalf("expected one last-used write within a minute, got %d", f.tokens.lastUsedWrites)
+	}
+
+	must(t, f.tokens.PersonalAccessTokenRepo.UpdateLastUsed(ctx, created.ID, time.Now().Add(-lastUsedResolution)))
+	if _, _, err := f.svc.Authenticate(ctx, created.Token); err != nil {
+		t.Fatal(err)
+	}
+	if f.tokens.lastUsedWrites != 2 {
+		t.Errorf("expected another write once a minute has passed, got %d", f.tokens.lastUsedWrites)
+	}
+}
@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/totp"
+	"github.com/letyshub/project-management/internal/tracing"
+)
+
+const recoveryCodeCount = 10
+
+type TwoFactorService struct {
+	twoFactorRepo domain.TwoFactorRepository
+	userRepo      domain.UserRepository
+	txManager     domain.TxManager
+	authService   *AuthService
+	issuer        string
+}
+
+func NewTwoFactorService(
+	twoFactorRepo domain.TwoFactorRepository,
+	userRepo domain.UserRepository,
+	txManager domain.TxManager,
+	authService *AuthService,
+	issuer string,
+) *TwoFactorService {
+	return &TwoFactorService{
+		twoFactorRepo: twoFactorRepo,
+		userRepo:      userRepo,
+		txManager:     txManager,
+		authService:   authService,
+		issuer:        issuer,
+	}
+}
+
+type TwoFactorStatus struct {
+	Enabled                bool `json:"enabled"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
+func (s *TwoFactorService) Status(ctx context.Context, userID uuid.UUID) (*TwoFactorStatus, error) {
+	ctx, span := tracing.Start(ctx, "TwoFactorService.Status")
+	defer span.End()
+
+	enrollment, err := s.twoFactorRepo.GetTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return &TwoFactorStatus{}, nil
+		}
+		return nil, err
+	}
+	if !enrollment.Enabled() {
+		return &TwoFactorStatus{}, nil
+	}
+	remaining, err := s.twoFactorRepo.CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &TwoFactorStatus{Enabled: true, RecoveryCodesRemaining: remaining}, nil
+}
+
+type TOTPSetup struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// BeginEnrollment creates a new unconfirmed secret. The provisioning URI is
+// meant to be rendered as a QR code for the user's authenticator app.
+func (s *TwoFactorService) BeginEnrollment(ctx context.Context, userID uuid.UUID) (*TOTPSetup, error) {
+	ctx, span := tracing.Start(ctx, "TwoFactorService.BeginEnrollment")
+	defer span.End()
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.twoFactorRepo.GetTOTP(ctx, userID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+	if existing != nil && existing.Enabled() {
+		return nil, fmt.Errorf("%w: two-factor authentication is already enabled", domain.ErrValidation)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("generating totp secret: %w", err)
+	}
+	enrollment := &domain.TOTPEnrollment{
+		UserID:    userID,
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}
+	if err := s.twoFactorRepo.SaveTOTP(ctx, enrollment); err != nil {
+		return nil, err
+	}
+
+	return &TOTPSetup{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(s.issuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmEnrollment turns on two-factor authentication once the user proves
+// their app works, and returns recovery codes. They are shown only once.
+func (s *TwoFactorService) ConfirmEnrollment(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "TwoFactorService.ConfirmEnrollment")
+	defer span.End()
+
+	enrollment, err := s.twoFactorRepo.GetTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%w: start enrollment first", domain.ErrValidation)
+		}
+		return nil, err
+	}
+	if enrollment.Enabled() {
+		return nil, fmt.Errorf("%w: two-factor authentication is already enabled", domain.ErrValidation)
+	}
+	if err := s.checkTOTP(ctx, enrollment, code); err != nil {
+		return nil, err
+	}
+	var codes []string
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.twoFactorRepo.ConfirmTOTP(ctx, userID, time.Now()); err != nil {
+			return err
+		}
+		codes, err = s.issueRecoveryCodes(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.authService.auditService.recordAccount(ctx, userID, userID, domain.AuditActionTwoFactorEnable)
+	return codes, nil
+}
+
+// Disable turns off two-factor authentication; code may be an authenticator
+// code or a recovery code. Wrong codes are throttled as in CompleteLogin.
+func (s *TwoFactorService) Disable(ctx context.Context, userID uuid.UUID, code string) error {
+	ctx, span := tracing.Start(ctx, "TwoFactorService.Disable")
+	defer span.End()
+
+	enrollment, err := s.enabledEnrollment(ctx, userID)
+	if err != nil {
+		return err
+	}
+	err = s.authService.throttled(ctx, userID, func() error {
+		return s.verify(ctx, enrollment, code)
+	})
+	if err != nil {
+		return err
+	}
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.twoFactorRepo.Delete(ctx, userID); err != nil {
+			return err
+		}
+		return s.authService.auditService.record(ctx, auditEntry{
+			actorID:    userID,
+			entityType: domain.AuditEntityUser,
+			entityID:   userID,
+			action:     domain.AuditActionTwoFactorDisable,
+		})
+	})
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes after checking code.
+// Wrong codes are throttled as in CompleteLogin.
+func (s *TwoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "TwoFactorService.RegenerateRecoveryCodes")
+	defer span.End()
+
+	enrollment, err := s.enabledEnrollment(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.authService.throttled(ctx, userID, func() error {
+		return s.checkTOTP(ctx, enrollment, code)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.issueRecoveryCodes(ctx, userID)
+}
+
+type CompleteLoginInput struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+}
+
+// CompleteLogin exchanges a login challenge and a code for a token pair.
+// Wrong codes, including recovery codes, count as failed logins for the
+// user's email address and the client IP, and a locked login gets a
+// RateLimitError as from Login. A challenge is used up by a successful
+// exchange or after twoFactorChallengeAttempts attempts.
+func (s *TwoFactorService) CompleteLogin(ctx context.Context, input CompleteLoginInput) (*domain.User, *TokenPair, error) {
+	ctx, span := tracing.Start(ctx, "TwoFactorService.CompleteLogin")
+	defer span.End()
+
+	userID, challengeID, err := s.authService.parseChallenge(input.ChallengeToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, domain.ErrUnauthorized
+		}
+		return nil, nil, err
+	}
+	keys := s.authService.throttleKeys(ctx, user.Email)
+	if err := s.authService.checkLoginThrottle(ctx, keys); err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if err := s.twoFactorRepo.UseChallengeAttempt(ctx, challengeID, twoFactorChallengeAttempts, now); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, domain.ErrUnauthorized
+		}
+		return nil, nil, err
+	}
+	enrollment, err := s.enabledEnrollment(ctx, userID)
+	if err != nil {
+		return nil, nil, domain.ErrUnauthorized
+	}
+	if err := s.verify(ctx, enrollment, input.Code); err != nil {
+		if errors.Is(err, domain.ErrValidation) {
+			if err := s.authService.recordLoginFailure(ctx, keys); err != nil {
+				return nil, nil, err
+			}
+		}
+		return nil, nil, err
+	}
+	if err := s.twoFactorRepo.ConsumeChallenge(ctx, challengeID, now); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, domain.ErrUnauthorized
+		}
+		return nil, nil, err
+	}
+	if err := s.authService.throttleRepo.Delete(ctx, keys.account); err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := s.authService.generateTokenPair(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, tokens, nil
+}
+
+// Reset removes another user's two-factor enrollment, for users who lost
+// both their device and recovery codes. Only admins may do this.
+func (s *TwoFactorService) Reset(ctx context.Context, actorID, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "TwoFactorService.Reset")
+	defer span.End()
+
+	actor, err := s.userRepo.GetByID(ctx, actorID)
+	if err != nil {
+		return err
+	}
+	if actor.Role != domain.UserRoleAdmin {
+		return domain.ErrForbidden
+	}
+	if err := s.twoFactorRepo.Delete(ctx, userID); err != nil {
+		return err
+	}
+	s.authService.auditService.recordAccount(ctx, actorID, userID, domain.AuditActionTwoFactorDisable)
+	return nil
+}
+
+func (s *TwoFactorService) enabledEnrollment(ctx context.Context, userID uuid.UUID) (*domain.TOTPEnrollment, error) {
+	enrollment, err := s.twoFactorRepo.GetTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%w: two-factor authentication is not enabled", domain.ErrValidation)
+		}
+		return nil, err
+	}
+	if !enrollment.Enabled() {
+		return nil, fmt.Errorf("%w: two-factor authentication is not enabled", domain.ErrValidation)
+	}
+	return enrollment, nil
+}
+
+// verify accepts an authenticator code or, failing that, a recovery code.
+func (s *TwoFactorService) verify(ctx context.Context, enrollment *domain.TOTPEnrollment, code string) error {
+	err := s.checkTOTP(ctx, enrollment, code)
+	if err == nil || !errors.Is(err, domain.ErrValidation) {
+		return err
+	}
+
+	normalized := normalizeRecoveryCode(code)
+	if normalized == "" {
+		return err
+	}
+	if useErr := s.twoFactorRepo.UseRecoveryCode(ctx, enrollment.UserID, hashToken(normalized), time.Now()); useErr != nil {
+		if errors.Is(useErr, domain.ErrNotFound) {
+			return err
+		}
+		return useErr
+	}
+	return nil
+}
+
+func (s *TwoFactorService) checkTOTP(ctx context.Context, enrollment *domain.TOTPEnrollment, code string) error {
+	invalid := fmt.Errorf("%w: invalid authentication code", domain.ErrValidation)
+
+	step, ok := totp.Validate(enrollment.Secret, code, time.Now(), 1)
+	if !ok {
+		return invalid
+	}
+	if err := s.twoFactorRepo.UseTOTPStep(ctx, enrollment.UserID, step); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return invalid
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *TwoFactorService) issueRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		raw, err := generateRandomToken(5)
+		if err != nil {
+			return nil, fmt.Errorf("generating recovery code: %w", err)
+		}
+		codes[i] = raw[:5] + "-" + raw[5:]
+		hashes[i] = hashToken(raw)
+	}
+	if err := s.twoFactorRepo.ReplaceRecoveryCodes(ctx, userID, hashes, time.Now()); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
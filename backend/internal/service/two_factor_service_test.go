@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/letyshub/project-management/internal/config"
+	"github.com/letyshub/project-management/internal/domain"
+	"github.com/letyshub/project-management/internal/totp"
+)
+
+type twoFactorFixture struct {
+	*authStack
+	svc *TwoFactorService
+}
+
+func newTwoFactorFixture(t *testing.T) *twoFactorFixture {
+	t.Helper()
+	f := &twoFactorFixture{authStack: newAuthStack(t, config.AuthConfig{})}
+	f.svc = NewTwoFactorService(f.twoFactor, f.users, f.tx, f.auth, "Task Flow")
+	return f
+}
+
+// enable enrolls the user and returns the secret and recovery codes. The
+// confirmation code is taken from the previous time step so the current one
+// is still unused.
+func (f *twoFactorFixture) enable(t *testing.T) (string, []string) {
+	t.Helper()
+	setup, err := f.svc.BeginEnrollment(context.Background(), f.user.ID)
+	if err != nil {
+		t.Fatalf("begin enrollment: %v", err)
+	}
+	code, _ := totp.Code(setup.Secret, totp.Step(time.Now())-1)
+	recovery, err := f.svc.ConfirmEnrollment(context.Background(), f.user.ID, code)
+	if err != nil {
+		t.Fatalf("confirm enrollment: %v", err)
+	}
+	return setup.Secret, recovery
+}
+
+func TestTwoFactor_LoginRequiresChallenge(t *testing.T) {
+	f := newTwoFactorFixture(t)
+	ctx := context.Background()
+
+	result, err := f.auth.Login(ctx, LoginInput{Email: f.user.Email, Password: "password123"})
+	if err != nil || result.Tokens == nil {
+		t.Fatalf("expected tokens before 2FA is enabled, got %+v, %v", result, err)
+	}
+
+	secret, _ := f.enable(t)
+
+	result, err = f.auth.Login(ctx, LoginInput{Email: f.user.Email, Password: "password123"})
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if result.Tokens != nil || result.ChallengeToken == "" {
+		t.Fatalf("expected a challenge instead of tokens")
+	}
+	if _, err := f.auth.ValidateAccessToken(result.ChallengeToken); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("challenge must not be accepted as an access token, got %v", err)
+	}
+	if _, err := f.auth.keys.Parse(result.ChallengeToken, &jwt.RegisteredClaims{}); err == nil {
+		t.Error("expected the challenge not to verify with the published access token keys")
+	}
+
+	code, _ := totp.Code(secret, totp.Step(time.Now()))
+	input := CompleteLoginInput{ChallengeToken: result.ChallengeToken, Code: code}
+	if _, tokens, err := f.svc.CompleteLogin(ctx, input); err != nil || tokens == nil {
+		t.Fatalf("complete login: %v", err)
+	}
+	if _, _, err := f.svc.CompleteLogin(ctx, input); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("expected a used challenge to be rejected, got %v", err)
+	}
+	input.ChallengeToken, _ = f.auth.issueChallenge(ctx, f.user)
+	if _, _, err := f.svc.CompleteLogin(ctx, input); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("expected a replayed code to be rejected, got %v", err)
+	}
+}
+
+func TestTwoFactor_RecoveryCodesAreSingleUse(t *testing.T) {
+	f := newTwoFactorFixture(t)
+	ctx := context.Background()
+	_, recovery := f.enable(t)
+	if len(recovery) != recoveryCodeCount {
+		t.Fatalf("expected %d recovery codes, got %d", recoveryCodeCount, len(recovery))
+	}
+
+	challenge, _ := f.auth.issueChallenge(ctx, f.user)
+	input := CompleteLoginInput{ChallengeToken: challenge, Code: recovery[0]}
+	if _, _, err := f.svc.CompleteLogin(ctx, input); err != nil {
+		t.Fatalf("recovery code login: %v", err)
+	}
+	input.ChallengeToken, _ = f.auth.issueChallenge(ctx, f.user)
+	if _, _, err := f.svc.CompleteLogin(ctx, input); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("expected a used recovery code to be rejected, got %v", err)
+	}
+
+	status, _ := f.svc.Status(ctx, f.user.ID)
+	if !status.Enabled || status.RecoveryCodesRemaining != recoveryCodeCount-1 {
+		t.Errorf("unexpected status: %+v", status)
+	}
+}
+
+func TestTwoFactor_ResetRequiresAdmin(t *testing.T) {
+	f := newTwoFactorFixture(t)
+	ctx := context.Background()
+	f.enable(t)
+
+	if err := f.svc.Reset(ctx, f.user.ID, f.user.ID); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("expected ErrForbidden for non-admin, got %v", err)
+	}
+
+	must(t, f.users.UpdateRole(ctx, f.user.ID, domain.UserRoleAdmin, time.Now()))
+	if err := f.svc.Reset(ctx, f.user.ID, f.user.ID); err != nil {
+		t.Fatalf("admin reset: %v", err)
+	}
+	if status, _ := f.svc.Status(ctx, f.user.ID); status.Enabled {
+		t.Error("expected 2FA to be disabled after reset")
+	}
+}
+
+func TestTwoFactor_ChallengeAttemptsAreLimited(t *testing.T) {
+	f := newTwoFactorFixture(t)
+	ctx := context.Background()
+	secret, _ := f.enable(t)
+
+	challenge, _ := f.auth.issueChallenge(ctx, f.user)
+	wrong := CompleteLoginInput{ChallengeToken: challenge, Code: "000000"}
+	for i := 0; i < twoFactorChallengeAttempts; i++ {
+		if _, _, err := f.svc.CompleteLogin(ctx, wrong); !errors.Is(err, domain.ErrValidation) {
+			t.Fatalf("attempt %d: expected an invalid code, got %v", i+1, err)
+		}
+	}
+
+	// The challenge is used up, even for the right code.
+	code, _ := totp.Code(secret, totp.Step(time.Now()))
+	if _, _, err := f.svc.CompleteLogin(ctx, CompleteLoginInput{ChallengeToken: challenge, Code: code}); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("expected the challenge to be used up, got %v", err)
+	}
+}
+
+func TestTwoFactor_WrongCodesLockLogin(t *testing.T) {
+	f := newTwoFactorFixture(t)
+	f.auth.authCfg = testAuthConfig
+	ctx := context.Background()
+	secret, recovery := f.enable(t)
+
+	// Failures count across challenges, so logging in again doesn't help.
+	for i := 0; i < 3; i++ {
+		challenge, _ := f.auth.issueChallenge(ctx, f.user)
+		if _, _, err := f.svc.CompleteLogin(ctx, CompleteLoginInput{ChallengeToken: challenge, Code: "wrong-code"}); !errors.Is(err, domain.ErrValidation) {
+			t.Fatalf("attempt %d: expected an invalid code, got %v", i+1, err)
+		}
+	}
+
+	challenge, _ := f.auth.issueChallenge(ctx, f.user)
+	code, _ := totp.Code(secret, totp.Step(time.Now()))
+	for _, c := range []string{code, recovery[0]} {
+		_, _, err := f.svc.CompleteLogin(ctx, CompleteLoginInput{ChallengeToken: challenge, Code: c})
+		var rl *domain.RateLimitError
+		if !errors.Is(err, domain.ErrLoginLocked) || !errors.As(err, &rl) || rl.RetryAfter <= 0 {
+			t.Fatalf("expected login locked, got %v", err)
+		}
+	}
+	if _, err := f.auth.Login(ctx, LoginInput{Email: f.user.Email, Password: "password123"}); !errors.Is(err, domain.ErrLoginLocked) {
+		t.Errorf("expected password login to be locked too, got %v", err)
+	}
+}
+
+func TestTwoFactor_WrongCodesLockDisableAndRegenerate(t *testing.T) {
+	f := newTwoFactorFixture(t)
+	f.auth.authCfg = testAuthConfig
+	ctx := context.Background()
+	secret, recovery := f.enable(t)
+
+	if err := f.svc.Disable(ctx, f.user.ID, "wrong-code"); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("expected an invalid code, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := f.svc.RegenerateRecoveryCodes(ctx, f.user.ID, "000000"); !errors.Is(err, domain.ErrValidation) {
+			t.Fatalf("attempt %d: expected an invalid code, got %v", i+1, err)
+		}
+	}
+
+	code, _ := totp.Code(secret, totp.Step(time.Now()))
+	if err := f.svc.Disable(ctx, f.user.ID, recovery[0]); !errors.Is(err, domain.ErrLoginLocked) {
+		t.Errorf("expected disabling to be locked, got %v", err)
+	}
+	if _, err := f.svc.RegenerateRecoveryCodes(ctx, f.user.ID, code); !errors.Is(err, domain.ErrLoginLocked) {
+		t.Errorf("expected regenerating recovery codes to be locked, got %v", err)
+	}
+	if status, err := f.svc.Status(ctx, f.user.ID); err != nil || !status.Enabled {
+		t.Errorf("expected 2FA to stay enabled, got %+v, %v", status, err)
+	}
+	if _, err := f.auth.Login(ctx, LoginInput{Email: f.user.Email, Password: "password123"}); !errors.Is(err, domain.ErrLoginLocked) {
+		t.Errorf("expected password login to be locked too, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 appendix B, SHA-1 rows.
+func TestHOTP_RFC6238Vectors(t *testing.T) {
+	key := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+	for _, tt := range tests {
+		step := Step(time.Unix(tt.unix, 0))
+		if got := hotp(key, uint64(step), 8); got != tt.want {
+			t.Errorf("t=%d: expected %s, got %s", tt.unix, tt.want, got)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	now := time.Unix(1111111111, 0)
+
+	code, err := Code(secret, Step(now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != "050471" {
+		t.Fatalf("expected 050471, got %s", code)
+	}
+
+	if step, ok := Validate(secret, code, now, 1); !ok || step != Step(now) {
+		t.Errorf("expected current code to validate")
+	}
+	if _, ok := Validate(secret, code, now.Add(Period), 1); !ok {
+		t.Errorf("expected previous step to validate within skew")
+	}
+	if _, ok := Validate(secret, code, now.Add(3*Period), 1); ok {
+		t.Errorf("expected code outside skew to fail")
+	}
+	if _, ok := Validate(secret, "000000", now, 1); ok {
+		t.Errorf("expected wrong code to fail")
+	}
+	if _, ok := Validate(strings.ToLower(secret), code, now, 0); !ok {
+		t.Errorf("expected lowercase secret to be accepted")
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("Task Flow", "ada@example.com", "ABC")
+	want := "otpauth://totp/Task%20Flow:ada@example.com?algorithm=SHA1&digits=6&issuer=Task+Flow&period=30&secret=ABC"
+	if uri != want {
+		t.Errorf("expected %s, got %s", want, uri)
+	}
+}
@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/letyshub/project-management/internal/config"
+)
+
+func TestSetupFileExporter(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	shutdown, err := Setup(ctx, config.TracingConfig{
+		Exporter:    config.TracingFile,
+		File:        path,
+		SampleRatio: 1,
+		ServiceName: "test",
+	})
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+
+	ctx, parent := Start(ctx, "TaskService.Move")
+	_, child := Start(ctx, "ProjectPolicy.Authorize")
+	child.End()
+	parent.End()
+	if err := shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read traces: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one line per span, got %d:\n%s", len(lines), data)
+	}
+	var span struct{ Name string }
+	if err := json.Unmarshal([]byte(lines[1]), &span); err != nil {
+		t.Fatalf("decode span: %v", err)
+	}
+	if span.Name != "TaskService.Move" {
+		t.Errorf("expected the parent span last, got %q", span.Name)
+	}
+}
+
+func TestQueryOperation(t *testing.T) {
+	tests := map[string]string{
+		"SELECT id FROM tasks WHERE id = $1":          "SELECT",
+		"\n\t\tinsert INTO tasks (title) VALUES ($1)": "INSERT",
+		"": "query",
+	}
+	for sql, want := range tests {
+		if got := queryOperation(sql); got != want {
+			t.Errorf("queryOperation(%q) = %q, want %q", sql, got, want)
+		}
+	}
+}
@@ -0,0 +1,5 @@
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP WITH TIME ZONE;
+
+-- Accounts created before verification existed are trusted as-is, so turning
+-- on EMAIL_VERIFICATION doesn't lock them out.
+UPDATE users SET email_verified_at = created_at;
@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN deactivated_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_users_role ON users (role);
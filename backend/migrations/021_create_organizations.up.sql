@@ -0,0 +1,43 @@
+CREATE TABLE organizations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    personal_owner_id UUID UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE organization_members (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'member',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX idx_organization_members_user_id ON organization_members (user_id);
+
+-- Every existing user gets a personal workspace they own.
+INSERT INTO organizations (name, personal_owner_id, created_at, updated_at)
+SELECT 'Personal', id, created_at, created_at FROM users;
+
+INSERT INTO organization_members (organization_id, user_id, role, created_at, updated_at)
+SELECT id, personal_owner_id, 'owner', created_at, created_at FROM organizations;
+
+-- Existing projects move into their owner's personal workspace.
+ALTER TABLE projects ADD COLUMN organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE;
+
+UPDATE projects p SET organization_id = o.id
+FROM organizations o WHERE o.personal_owner_id = p.owner_id;
+
+ALTER TABLE projects ALTER COLUMN organization_id SET NOT NULL;
+
+CREATE INDEX idx_projects_organization_id ON projects (organization_id);
+
+-- Collaborators join the workspace of every project they already work on.
+INSERT INTO organization_members (organization_id, user_id, role, created_at, updated_at)
+SELECT p.organization_id, pm.user_id, 'member', MIN(pm.created_at), MIN(pm.created_at)
+FROM project_members pm
+JOIN projects p ON p.id = pm.project_id
+GROUP BY p.organization_id, pm.user_id
+ON CONFLICT DO NOTHING;
@@ -0,0 +1 @@
+ALTER TABLE columns DROP COLUMN IF EXISTS category;
@@ -0,0 +1,7 @@
+ALTER TABLE columns ADD COLUMN category VARCHAR(20) NOT NULL DEFAULT '';
+
+-- Tag the columns every project starts with, so existing boards have flow
+-- metrics without being configured.
+UPDATE columns SET category = 'backlog' WHERE name = 'To Do';
+UPDATE columns SET category = 'in_progress' WHERE name = 'In Progress';
+UPDATE columns SET category = 'done' WHERE name = 'Done';
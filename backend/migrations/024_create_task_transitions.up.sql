@@ -0,0 +1,12 @@
+-- Every column a task enters, starting with the one it was created in. The
+-- column IDs have no foreign keys so history survives a column's deletion.
+CREATE TABLE task_transitions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    from_column_id UUID,
+    to_column_id UUID NOT NULL,
+    actor_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_task_transitions_task_id ON task_transitions (task_id, created_at);
@@ -0,0 +1,28 @@
+// Package migrations embeds the SQL schema migrations so the binaries apply
+// them without reading this directory at runtime.
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// Postgres holds the PostgreSQL migrations at the top of this directory.
+//
+//go:embed *.sql
+var Postgres embed.FS
+
+//go:embed sqlite/*.sql
+var sqliteFiles embed.FS
+
+// SQLite holds the migrations for the SQLite backend, kept in the sqlite
+// directory because its schema is written separately.
+var SQLite = mustSub(sqliteFiles, "sqlite")
+
+func mustSub(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
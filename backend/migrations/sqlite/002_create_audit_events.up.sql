@@ -0,0 +1,28 @@
+-- The audit log deliberately has no foreign keys: events must outlive the
+-- users, projects and entities they mention.
+CREATE TABLE audit_events (
+    id TEXT PRIMARY KEY,
+    actor_id TEXT NOT NULL,
+    project_id TEXT,
+    entity_type TEXT NOT NULL,
+    entity_id TEXT NOT NULL,
+    action TEXT NOT NULL,
+    before TEXT,
+    after TEXT,
+    ip_address TEXT NOT NULL DEFAULT '',
+    request_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_audit_events_project_id ON audit_events (project_id, created_at DESC);
+CREATE INDEX idx_audit_events_created_at ON audit_events (created_at DESC);
+
+CREATE TRIGGER audit_events_no_update BEFORE UPDATE ON audit_events
+BEGIN
+    SELECT RAISE(ABORT, 'audit_events is append-only');
+END;
+
+CREATE TRIGGER audit_events_no_delete BEFORE DELETE ON audit_events
+BEGIN
+    SELECT RAISE(ABORT, 'audit_events is append-only');
+END;
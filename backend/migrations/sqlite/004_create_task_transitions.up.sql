@@ -0,0 +1,12 @@
+-- Every column a task enters, starting with the one it was created in. The
+-- column IDs have no foreign keys so history survives a column's deletion.
+CREATE TABLE task_transitions (
+    id TEXT PRIMARY KEY,
+    task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    from_column_id TEXT,
+    to_column_id TEXT NOT NULL,
+    actor_id TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_task_transitions_task_id ON task_transitions (task_id, created_at);
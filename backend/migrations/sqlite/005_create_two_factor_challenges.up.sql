@@ -0,0 +1,12 @@
+-- Login challenges issued after the password check. Each can be exchanged
+-- once and only for a limited number of code attempts.
+CREATE TABLE two_factor_challenges (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    consumed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_two_factor_challenges_expires_at ON two_factor_challenges (expires_at);